@@ -4,14 +4,18 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"github.com/mdhender/moid/internal/actions"
+	"github.com/mdhender/moid/internal/backup"
 	"github.com/mdhender/moid/internal/commands"
 	"github.com/mdhender/moid/internal/config"
 	"github.com/mdhender/moid/internal/controllers"
 	"github.com/mdhender/moid/internal/encryption"
+	"github.com/mdhender/moid/internal/generators/sqlc"
 	"github.com/mdhender/moid/internal/ratelimiter"
 	"github.com/mdhender/moid/internal/services"
 	"github.com/mdhender/moid/internal/sqlite"
+	"github.com/mdhender/moid/internal/turns"
 	"github.com/mdhender/moid/internal/views"
 	"path/filepath"
 )
@@ -39,8 +43,11 @@ type application struct {
 		Articles      *controllers.Articles
 		Auth          *controllers.Auth
 		Blogs         *controllers.Blogs
+		Empires       *controllers.Empires
 		Home          *controllers.Home
 		Lqia          *controllers.Lqia
+		Maps          *controllers.Maps
+		Orders        *controllers.Orders
 		PaddleWebhook *controllers.PaddleWebhook
 		Ptg           *controllers.Ptg
 		Purchases     *controllers.Purchases
@@ -48,9 +55,29 @@ type application struct {
 	}
 
 	Commands struct {
-		PaddleMigrate *commands.PaddleMigrate
+		AddColony       *commands.AddColony
+		AddFleet        *commands.AddFleet
+		AddMine         *commands.AddMine
+		Backup          *commands.Backup
+		Combat          *commands.Combat
+		GenerateCluster *commands.GenerateCluster
+		GenerateReports *commands.GenerateReports
+		GenerateWarps   *commands.GenerateWarps
+		IssueKey        *commands.IssueKey
+		Migrate         *commands.Migrate
+		PaddleMigrate   *commands.PaddleMigrate
+		Restore         *commands.Restore
+		Reveal          *commands.Reveal
+		RollbackTurn    *commands.RollbackTurn
+		RunTurn         *commands.RunTurn
+		SetDeadline     *commands.SetDeadline
+		SetSchedule     *commands.SetSchedule
+		SetupGame       *commands.SetupGame
 	}
 
+	Scheduler *turns.Scheduler // nil if the scheduler is disabled
+	Backups   *backup.Job      // nil if the backup job is disabled
+
 	Views *views.View
 }
 
@@ -66,16 +93,34 @@ func newApplication(
 
 	// wire up the database store.
 	// TODO: we should close the database when the application is done.
-	app.Database.Store, err = sqlite.Open(cfg.Database.Path, app.Database.Context)
+	storeOptions := []sqlite.Option{
+		sqlite.WithCreate(cfg.Database.Create),
+		sqlite.WithBusyTimeout(cfg.Database.BusyTimeout),
+		sqlite.WithPool(cfg.Database.MaxOpenConns, cfg.Database.MaxIdleConns, cfg.Database.ConnMaxLifetime),
+	}
+	if cfg.Database.Migrate {
+		storeOptions = append(storeOptions, sqlite.WithMigrations(sqlc.Migrations))
+	}
+	app.Database.Store, err = sqlite.Open(cfg.Database.Path, app.Database.Context, storeOptions...)
 	if err != nil {
 		return nil, err
 	}
 
+	// wire up the facades for the application
+	if app.Facades.Articles, err = actions.NewArticlesFacade(app.Database.Store); err != nil {
+		return nil, err
+	}
+
 	// wire up the controllers for the application
 	// should we be creating views for the controllers here?
+	if loginView, err := views.NewView("login.gohtml", filepath.Join(app.Config.Views.Path, "login.gohtml")); err != nil {
+		return nil, err
+	} else if app.Controllers.Auth, err = controllers.NewAuthController(app.Database.Store, loginView, cfg.Server.Scheme == "https"); err != nil {
+		return nil, err
+	}
 	if blogsView, err := views.NewView("blogs.gohtml", filepath.Join(app.Config.Views.Path, "blogs.gohtml")); err != nil {
 		return nil, err
-	} else if app.Controllers.Blogs, err = controllers.NewBlogsController(app.Database.Store, blogsView); err != nil {
+	} else if app.Controllers.Blogs, err = controllers.NewBlogsController(app.Facades.Articles, blogsView); err != nil {
 		return nil, err
 	}
 	if homeView, err := views.NewView("home.gohtml", filepath.Join(app.Config.Views.Path, "home.gohtml")); err != nil {
@@ -83,11 +128,143 @@ func newApplication(
 	} else if app.Controllers.Home, err = controllers.NewHomeController(app.Database.Store, homeView); err != nil {
 		return nil, err
 	}
+	if mapsView, err := views.NewView("cluster-map.gohtml", filepath.Join(app.Config.Views.Path, "cluster-map.gohtml")); err != nil {
+		return nil, err
+	} else if app.Controllers.Maps, err = controllers.NewMapsController(app.Database.Store, mapsView); err != nil {
+		return nil, err
+	}
+	if adminView, err := views.NewView("admin.gohtml", filepath.Join(app.Config.Views.Path, "admin.gohtml")); err != nil {
+		return nil, err
+	} else if adminGameView, err := views.NewView("admin-game.gohtml", filepath.Join(app.Config.Views.Path, "admin-game.gohtml")); err != nil {
+		return nil, err
+	} else if adminSystemView, err := views.NewView("admin-system.gohtml", filepath.Join(app.Config.Views.Path, "admin-system.gohtml")); err != nil {
+		return nil, err
+	} else if app.Controllers.Admin, err = controllers.NewAdminController(app.Database.Store, adminView, adminGameView, adminSystemView); err != nil {
+		return nil, err
+	}
+	if app.Controllers.Empires, err = controllers.NewEmpiresController(app.Database.Store); err != nil {
+		return nil, err
+	}
+	if ordersView, err := views.NewView("orders.gohtml", filepath.Join(app.Config.Views.Path, "orders.gohtml")); err != nil {
+		return nil, err
+	} else if app.Controllers.Orders, err = controllers.NewOrdersController(app.Database.Store, ordersView); err != nil {
+		return nil, err
+	}
 	if reportsView, err := views.NewView("reports.gohtml", filepath.Join(app.Config.Views.Path, "reports.gohtml")); err != nil {
 		return nil, err
-	} else if app.Controllers.Reports, err = controllers.NewReportsController(app.Database.Store, reportsView); err != nil {
+	} else if reportView, err := views.NewView("report.gohtml", filepath.Join(app.Config.Views.Path, "report.gohtml")); err != nil {
+		return nil, err
+	} else if app.Controllers.Reports, err = controllers.NewReportsController(app.Database.Store, reportsView, reportView); err != nil {
 		return nil, err
 	}
 
+	// wire up the commands for the application
+	if app.Commands.AddColony, err = commands.NewAddColonyCommand(app.Database.Store); err != nil {
+		return nil, err
+	}
+	if app.Commands.AddFleet, err = commands.NewAddFleetCommand(app.Database.Store); err != nil {
+		return nil, err
+	}
+	if app.Commands.AddMine, err = commands.NewAddMineCommand(app.Database.Store); err != nil {
+		return nil, err
+	}
+	if app.Commands.Backup, err = commands.NewBackupCommand(app.Database.Store, cfg.Backup.Path, cfg.Backup.Daily, cfg.Backup.Weekly); err != nil {
+		return nil, err
+	}
+	if app.Commands.Combat, err = commands.NewCombatCommand(); err != nil {
+		return nil, err
+	}
+	if app.Commands.GenerateCluster, err = commands.NewGenerateClusterCommand(app.Database.Store); err != nil {
+		return nil, err
+	}
+	if app.Commands.GenerateReports, err = commands.NewGenerateReportsCommand(app.Database.Store); err != nil {
+		return nil, err
+	}
+	if app.Commands.GenerateWarps, err = commands.NewGenerateWarpsCommand(app.Database.Store); err != nil {
+		return nil, err
+	}
+	if app.Commands.IssueKey, err = commands.NewIssueKeyCommand(app.Database.Store); err != nil {
+		return nil, err
+	}
+	if app.Commands.Migrate, err = commands.NewMigrateCommand(app.Database.Store); err != nil {
+		return nil, err
+	}
+	if app.Commands.Restore, err = commands.NewRestoreCommand(app.Database.Store); err != nil {
+		return nil, err
+	}
+	if app.Commands.Reveal, err = commands.NewRevealCommand(app.Database.Store); err != nil {
+		return nil, err
+	}
+	if app.Commands.RollbackTurn, err = commands.NewRollbackTurnCommand(app.Database.Store); err != nil {
+		return nil, err
+	}
+	if app.Commands.RunTurn, err = commands.NewRunTurnCommand(app.Database.Store); err != nil {
+		return nil, err
+	}
+	if app.Commands.SetDeadline, err = commands.NewSetDeadlineCommand(app.Database.Store); err != nil {
+		return nil, err
+	}
+	if app.Commands.SetSchedule, err = commands.NewSetScheduleCommand(app.Database.Store); err != nil {
+		return nil, err
+	}
+	if app.Commands.SetupGame, err = commands.NewSetupGameCommand(app.Database.Store); err != nil {
+		return nil, err
+	}
+
+	// wire up the turn scheduler. it isn't started until the server starts.
+	if !cfg.Scheduler.Disabled {
+		if app.Scheduler, err = turns.NewScheduler(app.Database.Store, cfg.Scheduler.Interval); err != nil {
+			return nil, err
+		}
+	}
+
+	// wire up the backup job. it isn't started until the server starts.
+	if cfg.Backup.Interval > 0 {
+		if app.Backups, err = backup.NewJob(app.Database.Store, cfg.Backup.Path, cfg.Backup.Interval, cfg.Backup.Daily, cfg.Backup.Weekly); err != nil {
+			return nil, err
+		}
+	}
+
 	return app, nil
 }
+
+// Run runs the named command with the given arguments.
+func (a *application) Run(name string, args []string) error {
+	switch name {
+	case "add-colony":
+		return a.Commands.AddColony.Run(a.Database.Context, args)
+	case "add-fleet":
+		return a.Commands.AddFleet.Run(a.Database.Context, args)
+	case "add-mine":
+		return a.Commands.AddMine.Run(a.Database.Context, args)
+	case "backup":
+		return a.Commands.Backup.Run(a.Database.Context, args)
+	case "combat":
+		return a.Commands.Combat.Run(a.Database.Context, args)
+	case "generate-cluster":
+		return a.Commands.GenerateCluster.Run(a.Database.Context, args)
+	case "generate-reports":
+		return a.Commands.GenerateReports.Run(a.Database.Context, args)
+	case "generate-warps":
+		return a.Commands.GenerateWarps.Run(a.Database.Context, args)
+	case "issue-key":
+		return a.Commands.IssueKey.Run(a.Database.Context, args)
+	case "migrate":
+		return a.Commands.Migrate.Run(a.Database.Context, args)
+	case "restore":
+		return a.Commands.Restore.Run(a.Database.Context, args)
+	case "reveal":
+		return a.Commands.Reveal.Run(a.Database.Context, args)
+	case "rollback-turn":
+		return a.Commands.RollbackTurn.Run(a.Database.Context, args)
+	case "run-turn":
+		return a.Commands.RunTurn.Run(a.Database.Context, args)
+	case "set-deadline":
+		return a.Commands.SetDeadline.Run(a.Database.Context, args)
+	case "set-schedule":
+		return a.Commands.SetSchedule.Run(a.Database.Context, args)
+	case "setup-game":
+		return a.Commands.SetupGame.Run(a.Database.Context, args)
+	}
+	return fmt.Errorf("%s: unknown command", name)
+}
@@ -1,6 +1,7 @@
 #!/bin/bash
 ##############################################################################
-# This script rebuilds the localhost database.
+# This script rebuilds the localhost database from scratch, losing all data.
+# To upgrade an existing database in place, run "moid migrate -- up".
 ##############################################################################
 # Confirm that we're running from the root of the repository.
 [ -f go.mod -a -f bin/rebuild_db.sh ] || {
@@ -11,11 +12,35 @@
 #
 db=testdata/localhost/moid.db
 ##############################################################################
+# Only the initial migration drops tables, so later migrations would fail
+# on a database that already has their tables. Start from scratch instead.
+if [ -f "${db}" ]; then
+  echo " info: removing '${db}'..."
+  rm -f "${db}" || exit 2
+fi
+##############################################################################
 # In theory, the DDL contains all the commands needed to rebuild the database
 # without removing the file and starting from scratch. In a better world,
 # we'd be able to do this without having to drop the tables.
 for ddl in \
   internal/generators/sqlc/202502110915_initial.sql \
+  internal/generators/sqlc/202502241000_warps.sql \
+  internal/generators/sqlc/202502251000_orders.sql \
+  internal/generators/sqlc/202502261000_turns.sql \
+  internal/generators/sqlc/202502271000_reports.sql \
+  internal/generators/sqlc/202502281000_knowledge.sql \
+  internal/generators/sqlc/202503011000_mining.sql \
+  internal/generators/sqlc/202503021000_colonies.sql \
+  internal/generators/sqlc/202503031000_fleets.sql \
+  internal/generators/sqlc/202503041000_snapshots.sql \
+  internal/generators/sqlc/202503051000_schedules.sql \
+  internal/generators/sqlc/202503061000_audit.sql \
+  internal/generators/sqlc/202503071000_stars.sql \
+  internal/generators/sqlc/202503081000_checksums.sql \
+  internal/generators/sqlc/202503091000_articles.sql \
+  internal/generators/sqlc/202503101000_battles.sql \
+  internal/generators/sqlc/202503111000_rejections.sql \
+  internal/generators/sqlc/202503121000_sessions.sql \
 ; do
   echo " info: running '${ddl}..."
   [ -f "${ddl}" ] || {
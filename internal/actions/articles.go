@@ -3,217 +3,71 @@
 package actions
 
 import (
-	"database/sql"
+	"context"
 	"github.com/mdhender/moid/internal/models"
+	"github.com/mdhender/moid/internal/sqlite"
 	"time"
 )
 
+// ArticlesFacade manages the articles for the blog. The lookups and
+// updates return sqlite.ErrNotFound if the article doesn't exist.
 type ArticlesFacade struct {
-	db *sql.DB
+	db *sqlite.Store
 }
 
-func (f *ArticlesFacade) GetPublishedArticles() ([]models.Article, error) {
-
-	sqlStmt := `
-SELECT id, title, slug, published, date_published, date_updated
-FROM articles
-WHERE published = 1
-ORDER BY id DESC;
-`
-
-	rows, err := f.db.Query(sqlStmt)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var articles []models.Article
-
-	for rows.Next() {
-		var article models.Article
-
-		err := rows.Scan(
-			&article.ID,
-			&article.Title,
-			&article.Slug,
-			&article.Published,
-			&article.DatePublished,
-			&article.DateUpdated,
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		articles = append(articles, article)
-
+// NewArticlesFacade creates a new instance of the Articles facade
+func NewArticlesFacade(db *sqlite.Store) (*ArticlesFacade, error) {
+	f := &ArticlesFacade{
+		db: db,
 	}
-
-	return articles, nil
+	return f, nil
 }
 
-func (f *ArticlesFacade) CreateArticle(article *models.Article) error {
-	sqlStmt := `
-        INSERT INTO articles (title, slug, published, date_published, date_updated)
-        VALUES (?, ?, ?, ?, ?);
-    `
-	result, err := f.db.Exec(sqlStmt,
-		article.Title,
-		article.Slug,
-		article.Published,
-		article.DatePublished,
-		article.DateUpdated)
-	if err != nil {
-		return err
-	}
-	id, err := result.LastInsertId()
-	if err != nil {
-		return err
-	}
-	article.ID = int(id)
-	return nil
+func (f *ArticlesFacade) GetPublishedArticles(ctx context.Context) ([]*models.Article, error) {
+	return f.db.ReadPublishedArticles(ctx)
 }
 
-func (f *ArticlesFacade) GetArticleByID(id int) (*models.Article, error) {
-	sqlStmt := `
-        SELECT id, title, slug, published, date_published, date_updated
-        FROM articles
-        WHERE id = ?;
-    `
-	var article models.Article
-	err := f.db.QueryRow(sqlStmt, id).Scan(
-		&article.ID,
-		&article.Title,
-		&article.Slug,
-		&article.Published,
-		&article.DatePublished,
-		&article.DateUpdated)
-	if err != nil {
-		return nil, err
-	}
-	return &article, nil
+func (f *ArticlesFacade) GetDraftArticles(ctx context.Context) ([]*models.Article, error) {
+	return f.db.ReadDraftArticles(ctx)
 }
 
-func (f *ArticlesFacade) GetArticleBySlug(slug string) (*models.Article, error) {
-	sqlStmt := `
-        SELECT id, title, slug, published, date_published, date_updated
-        FROM articles
-        WHERE slug = ?;
-    `
-	var article models.Article
-	err := f.db.QueryRow(sqlStmt, slug).Scan(
-		&article.ID,
-		&article.Title,
-		&article.Slug,
-		&article.Published,
-		&article.DatePublished,
-		&article.DateUpdated)
-	if err != nil {
-		return nil, err
-	}
-	return &article, nil
+func (f *ArticlesFacade) GetRecentArticles(ctx context.Context, limit int) ([]*models.Article, error) {
+	return f.db.ReadRecentArticles(ctx, limit)
 }
 
-func (f *ArticlesFacade) UpdateArticle(article *models.Article) error {
-	sqlStmt := `
-        UPDATE articles 
-        SET title = ?, slug = ?, published = ?, date_updated = ?
-        WHERE id = ?;
-    `
-	_, err := f.db.Exec(sqlStmt,
-		article.Title,
-		article.Slug,
-		article.Published,
-		time.Now(),
-		article.ID)
-	return err
+func (f *ArticlesFacade) GetArticleByID(ctx context.Context, id int) (*models.Article, error) {
+	return f.db.ReadArticle(ctx, id)
 }
 
-func (f *ArticlesFacade) PublishArticle(id int) error {
-	sqlStmt := `
-        UPDATE articles 
-        SET published = 1, date_published = ?, date_updated = ?
-        WHERE id = ?;
-    `
-	now := time.Now()
-	_, err := f.db.Exec(sqlStmt, now, now, id)
-	return err
+func (f *ArticlesFacade) GetArticleBySlug(ctx context.Context, slug string) (*models.Article, error) {
+	return f.db.ReadArticleBySlug(ctx, slug)
 }
 
-func (f *ArticlesFacade) UnpublishArticle(id int) error {
-	sqlStmt := `
-        UPDATE articles 
-        SET published = 0, date_updated = ?
-        WHERE id = ?;
-    `
-	_, err := f.db.Exec(sqlStmt, time.Now(), id)
-	return err
+// CreateArticle saves a new article and sets its ID and update time.
+// A published article without a publication date is dated now.
+func (f *ArticlesFacade) CreateArticle(ctx context.Context, article *models.Article) error {
+	now := time.Now().UTC()
+	article.DateUpdated = now
+	if article.Published && article.DatePublished.IsZero() {
+		article.DatePublished = now
+	}
+	return f.db.CreateArticle(ctx, article)
 }
 
-func (f *ArticlesFacade) DeleteArticle(id int) error {
-	sqlStmt := `DELETE FROM articles WHERE id = ?;`
-	_, err := f.db.Exec(sqlStmt, id)
-	return err
+// UpdateArticle saves the title and slug. Use PublishArticle and
+// UnpublishArticle to change whether the article is published.
+func (f *ArticlesFacade) UpdateArticle(ctx context.Context, article *models.Article) error {
+	return f.db.UpdateArticle(ctx, article, time.Now().UTC())
 }
 
-func (f *ArticlesFacade) GetDraftArticles() ([]models.Article, error) {
-	sqlStmt := `
-        SELECT id, title, slug, published, date_published, date_updated
-        FROM articles
-        WHERE published = 0
-        ORDER BY id DESC;
-    `
-	rows, err := f.db.Query(sqlStmt)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var articles []models.Article
-	for rows.Next() {
-		var article models.Article
-		err := rows.Scan(
-			&article.ID,
-			&article.Title,
-			&article.Slug,
-			&article.Published,
-			&article.DatePublished,
-			&article.DateUpdated)
-		if err != nil {
-			return nil, err
-		}
-		articles = append(articles, article)
-	}
-	return articles, nil
+func (f *ArticlesFacade) PublishArticle(ctx context.Context, id int) error {
+	return f.db.PublishArticle(ctx, id, time.Now().UTC())
 }
 
-func (f *ArticlesFacade) GetRecentArticles(limit int) ([]models.Article, error) {
-	sqlStmt := `
-        SELECT id, title, slug, published, date_published, date_updated
-        FROM articles
-        WHERE published = 1
-        ORDER BY date_published DESC
-        LIMIT ?;
-    `
-	rows, err := f.db.Query(sqlStmt, limit)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
+func (f *ArticlesFacade) UnpublishArticle(ctx context.Context, id int) error {
+	return f.db.UnpublishArticle(ctx, id, time.Now().UTC())
+}
 
-	var articles []models.Article
-	for rows.Next() {
-		var article models.Article
-		err := rows.Scan(
-			&article.ID,
-			&article.Title,
-			&article.Slug,
-			&article.Published,
-			&article.DatePublished,
-			&article.DateUpdated)
-		if err != nil {
-			return nil, err
-		}
-		articles = append(articles, article)
-	}
-	return articles, nil
+func (f *ArticlesFacade) DeleteArticle(ctx context.Context, id int) error {
+	return f.db.DeleteArticle(ctx, id)
 }
@@ -0,0 +1,221 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+// Package backup makes, prunes, and restores compressed snapshots
+// of the live database.
+package backup
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/mdhender/moid/internal/sqlite"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrInUse is returned when a running server holds the database open.
+var ErrInUse = errors.New("database in use by a running server")
+
+// backups are named by the time they were made, in UTC.
+const (
+	prefix = "moid-"
+	suffix = ".db.gz"
+	stamp  = "20060102T150405Z"
+)
+
+// Backup is a compressed snapshot in the backup directory.
+type Backup struct {
+	Path      string
+	CreatedAt time.Time
+}
+
+// Run writes a snapshot of the live database to the directory. The
+// snapshot is made with VACUUM INTO, checked with PRAGMA integrity_check,
+// and compressed. The directory is created if needed.
+// It returns the path to the compressed snapshot.
+func Run(ctx context.Context, db *sqlite.Store, dir string, now time.Time) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	name := prefix + now.UTC().Format(stamp)
+	snapshot := filepath.Join(dir, name+".db")
+	target := filepath.Join(dir, name+suffix)
+	if _, err := os.Stat(target); err == nil {
+		return "", fmt.Errorf("%s: already exists", target)
+	}
+	defer func() {
+		_ = os.Remove(snapshot)
+	}()
+
+	started := time.Now()
+	if err := db.VacuumInto(ctx, snapshot); err != nil {
+		return "", err
+	} else if err := sqlite.CheckIntegrity(ctx, snapshot); err != nil {
+		return "", err
+	} else if err := compress(snapshot, target); err != nil {
+		return "", err
+	}
+	log.Printf("backup: %s: created in %v\n", target, time.Since(started))
+	return target, nil
+}
+
+// List returns the backups in the directory, newest first.
+// Files that aren't named like backups are ignored.
+func List(dir string) ([]*Backup, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var list []*Backup
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, suffix) {
+			continue
+		}
+		createdAt, err := time.Parse(stamp, strings.TrimSuffix(strings.TrimPrefix(name, prefix), suffix))
+		if err != nil {
+			continue
+		}
+		list = append(list, &Backup{Path: filepath.Join(dir, name), CreatedAt: createdAt})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].CreatedAt.After(list[j].CreatedAt)
+	})
+	return list, nil
+}
+
+// Prune deletes the backups in the directory that aren't needed to keep
+// the newest backup from each of the last daily days and each of the last
+// weekly ISO weeks that have backups. The newest backup is always kept.
+// It returns the paths of the backups that were deleted.
+func Prune(dir string, daily, weekly int) ([]string, error) {
+	if daily < 0 || weekly < 0 {
+		return nil, fmt.Errorf("%d daily, %d weekly: invalid retention", daily, weekly)
+	}
+	list, err := List(dir)
+	if err != nil {
+		return nil, err
+	}
+	days, weeks := map[string]bool{}, map[string]bool{}
+	var deleted []string
+	for n, b := range list {
+		keep := n == 0
+		if day := b.CreatedAt.Format(time.DateOnly); !days[day] && len(days) < daily {
+			days[day], keep = true, true
+		}
+		year, week := b.CreatedAt.ISOWeek()
+		if key := fmt.Sprintf("%d-W%02d", year, week); !weeks[key] && len(weeks) < weekly {
+			weeks[key], keep = true, true
+		}
+		if keep {
+			continue
+		}
+		if err := os.Remove(b.Path); err != nil {
+			return deleted, err
+		}
+		log.Printf("backup: %s: pruned\n", b.Path)
+		deleted = append(deleted, b.Path)
+	}
+	return deleted, nil
+}
+
+// Restore replaces the database at the path with the compressed backup.
+// The backup is decompressed next to the database and checked before
+// anything is replaced, and the database being replaced is kept with
+// a ".replaced-" suffix. It returns ErrInUse if a server is running
+// against the database; the caller must close its own connections.
+func Restore(ctx context.Context, from, to string, now time.Time) error {
+	if pid, ok := InUse(to); ok {
+		return fmt.Errorf("%s: pid %d: %w", to, pid, ErrInUse)
+	}
+	restored := to + ".restoring"
+	defer func() {
+		_ = os.Remove(restored)
+	}()
+	if err := decompress(from, restored); err != nil {
+		return err
+	} else if err := sqlite.CheckIntegrity(ctx, restored); err != nil {
+		return err
+	}
+
+	// the write-ahead log belongs to the database being replaced,
+	// so it moves with it.
+	replaced := to + ".replaced-" + now.UTC().Format(stamp)
+	for _, ext := range []string{"", "-wal", "-shm"} {
+		if err := os.Rename(to+ext, replaced+ext); errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		log.Printf("backup: %s: kept as %s\n", to+ext, replaced+ext)
+	}
+	if err := os.Rename(restored, to); err != nil {
+		return err
+	}
+	log.Printf("backup: %s: restored from %s\n", to, from)
+	return nil
+}
+
+// compress gzips the file. The target is written under a temporary
+// name and renamed so that a partial file is never taken for a backup.
+func compress(from, to string) error {
+	r, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	tmp := to + ".tmp"
+	defer func() {
+		_ = os.Remove(tmp)
+	}()
+	w, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(w)
+	zw.Name = filepath.Base(from)
+	if _, err := io.Copy(zw, r); err != nil {
+		_ = w.Close()
+		return err
+	} else if err := zw.Close(); err != nil {
+		_ = w.Close()
+		return err
+	} else if err := w.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, to)
+}
+
+func decompress(from, to string) error {
+	r, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("%s: %w", from, err)
+	}
+	w, err := os.Create(to)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(w, zr); err != nil {
+		_ = w.Close()
+		return fmt.Errorf("%s: %w", from, err)
+	} else if err := zr.Close(); err != nil {
+		_ = w.Close()
+		return fmt.Errorf("%s: %w", from, err)
+	}
+	return w.Close()
+}
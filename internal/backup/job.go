@@ -0,0 +1,62 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"github.com/mdhender/moid/internal/sqlite"
+	"log"
+	"time"
+)
+
+// Job makes a backup and prunes the old ones at every interval
+// while the server is running.
+type Job struct {
+	db       *sqlite.Store
+	path     string
+	interval time.Duration
+	daily    int
+	weekly   int
+}
+
+// NewJob creates a job that writes backups to the path at the interval
+// and keeps the given number of daily and weekly backups.
+func NewJob(db *sqlite.Store, path string, interval time.Duration, daily, weekly int) (*Job, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("%v: invalid interval", interval)
+	} else if daily < 0 || weekly < 0 {
+		return nil, fmt.Errorf("%d daily, %d weekly: invalid retention", daily, weekly)
+	}
+	return &Job{db: db, path: path, interval: interval, daily: daily, weekly: weekly}, nil
+}
+
+// Start makes a backup at every interval until the context is cancelled.
+// The first backup is made one interval after the server starts so that
+// restarting the server doesn't pile up backups.
+func (j *Job) Start(ctx context.Context) {
+	log.Printf("backup: %s: backing up every %v\n", j.path, j.interval)
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("backup: %s: stopped\n", j.path)
+			return
+		case <-ticker.C:
+			j.Run(ctx, time.Now())
+		}
+	}
+}
+
+// Run makes one backup and prunes the old ones.
+// Errors are logged since there is no one to return them to.
+func (j *Job) Run(ctx context.Context, now time.Time) {
+	if _, err := Run(ctx, j.db, j.path, now); err != nil {
+		log.Printf("backup: %v\n", err)
+		return
+	}
+	if _, err := Prune(j.path, j.daily, j.weekly); err != nil {
+		log.Printf("backup: %v\n", err)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// Lock records that this process is a server running against the
+// database by writing its pid to a file next to the database. A lock
+// left behind by a server that died is taken over. It returns
+// ErrInUse if another running server holds the lock.
+//
+// The lock is advisory; it keeps restore from replacing the file
+// out from under a server. Call the returned function to release it.
+func Lock(path string) (func(), error) {
+	if pid, ok := InUse(path); ok {
+		return nil, fmt.Errorf("%s: pid %d: %w", path, pid, ErrInUse)
+	}
+	name := lockFile(path)
+	if err := os.WriteFile(name, []byte(strconv.Itoa(os.Getpid())+"\n"), 0o644); err != nil {
+		return nil, err
+	}
+	return func() {
+		// don't remove a lock that another server took over.
+		if pid, ok := readLock(name); ok && pid == os.Getpid() {
+			_ = os.Remove(name)
+		}
+	}, nil
+}
+
+// InUse returns the pid of the running server that holds the lock on
+// the database. It returns false if there is no lock or if the process
+// that wrote it is no longer running.
+func InUse(path string) (int, bool) {
+	pid, ok := readLock(lockFile(path))
+	if !ok {
+		return 0, false
+	} else if pid == os.Getpid() {
+		return pid, true
+	}
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return 0, false
+	}
+	// signal 0 checks that the process exists without disturbing it.
+	// EPERM means it exists but belongs to another user.
+	if err := p.Signal(syscall.Signal(0)); err != nil && !errors.Is(err, syscall.EPERM) {
+		return 0, false
+	}
+	return pid, true
+}
+
+func lockFile(path string) string {
+	return path + ".server.pid"
+}
+
+func readLock(name string) (int, bool) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return 0, false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid < 1 {
+		return 0, false
+	}
+	return pid, true
+}
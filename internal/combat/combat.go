@@ -0,0 +1,291 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+// Package combat resolves battles between opposing forces.
+//
+// A battle is fought in rounds. In each round, every unit with an attack
+// fires one shot at a unit in an opposing force. The target is chosen at
+// random, weighted by the number of units in each group. The chance to
+// hit is 50% plus 10% for each point the attack is over the target's
+// defense (or minus 10% for each point under), but never less than 5%
+// or more than 95%. Each hit does one point of damage and a unit is
+// destroyed when its damage reaches its hit points. Damage carries over
+// to the next unit in the group, so groups are whittled down one unit
+// at a time.
+//
+// Fire is simultaneous; units destroyed in a round still fire in that
+// round. The battle ends when no force with units that can fire has an
+// opponent with units left, or when the round limit is reached. A force
+// without weapons can still be attacked; it just can't fire back.
+//
+// The only source of randomness is the generator passed to Resolve, so
+// the same forces and seed always produce the same battle.
+package combat
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+)
+
+// DefaultRounds is the round limit if the battle doesn't set one.
+const DefaultRounds = 10
+
+// Force is one side of a battle.
+type Force struct {
+	Name  string  `json:"name"`
+	Units []*Unit `json:"units"`
+}
+
+// Unit is a group of identical units in a force.
+type Unit struct {
+	Name      string `json:"name"`
+	Count     int    `json:"count"`
+	Attack    int    `json:"attack"`
+	Defense   int    `json:"defense"`
+	HitPoints int    `json:"hit_points"`
+}
+
+// Result is the outcome of a battle.
+type Result struct {
+	Rounds    []*Round `json:"rounds"`
+	Survivors []*Force `json:"survivors"` // every force, with the units left in each group
+	Winner    string   `json:"winner"`    // the only force with units left, or empty if there isn't one
+}
+
+// Round is the log of a single round.
+type Round struct {
+	Number int     `json:"number"`
+	Fire   []*Fire `json:"fire"`
+	Losses []*Loss `json:"losses"`
+}
+
+// Fire is the shots one group fired at another group in a round.
+type Fire struct {
+	Force       string `json:"force"`
+	Unit        string `json:"unit"`
+	TargetForce string `json:"target_force"`
+	TargetUnit  string `json:"target_unit"`
+	Shots       int    `json:"shots"`
+	Hits        int    `json:"hits"`
+	ChanceToHit int    `json:"chance_to_hit"` // percent
+}
+
+// Loss is the units a group lost in a round.
+type Loss struct {
+	Force     string `json:"force"`
+	Unit      string `json:"unit"`
+	Destroyed int    `json:"destroyed"`
+	Remaining int    `json:"remaining"`
+}
+
+// Casualties returns the units destroyed in each group, keyed by force and unit name.
+func (r *Result) Casualties() map[string]map[string]int {
+	casualties := map[string]map[string]int{}
+	for _, round := range r.Rounds {
+		for _, loss := range round.Losses {
+			if casualties[loss.Force] == nil {
+				casualties[loss.Force] = map[string]int{}
+			}
+			casualties[loss.Force][loss.Unit] += loss.Destroyed
+		}
+	}
+	return casualties
+}
+
+// Log returns the battle log as lines of text.
+func (r *Result) Log() []string {
+	var lines []string
+	for _, round := range r.Rounds {
+		for _, fire := range round.Fire {
+			lines = append(lines, fmt.Sprintf("round %d: %s %s fired %d shots at %s %s (%d%%), %d hits",
+				round.Number, fire.Force, fire.Unit, fire.Shots, fire.TargetForce, fire.TargetUnit, fire.ChanceToHit, fire.Hits))
+		}
+		for _, loss := range round.Losses {
+			lines = append(lines, fmt.Sprintf("round %d: %s lost %d %s, %d remaining",
+				round.Number, loss.Force, loss.Destroyed, loss.Unit, loss.Remaining))
+		}
+	}
+	if r.Winner != "" {
+		lines = append(lines, fmt.Sprintf("%s won after %d rounds", r.Winner, len(r.Rounds)))
+	} else {
+		lines = append(lines, fmt.Sprintf("no winner after %d rounds", len(r.Rounds)))
+	}
+	return lines
+}
+
+// ChanceToHit returns the percent chance that a shot with the attack
+// hits a unit with the defense.
+func ChanceToHit(attack, defense int) int {
+	return min(max(50+10*(attack-defense), 5), 95)
+}
+
+// Resolve fights the battle between the forces. The forces are not
+// changed. Forces and the units in each force are processed in the
+// order given. If rounds is zero, DefaultRounds is used.
+func Resolve(r *rand.Rand, forces []*Force, rounds int) (*Result, error) {
+	if r == nil {
+		return nil, errors.New("missing random number generator")
+	} else if len(forces) < 2 {
+		return nil, errors.New("a battle needs at least two forces")
+	} else if rounds == 0 {
+		rounds = DefaultRounds
+	} else if rounds < 0 {
+		return nil, fmt.Errorf("%d: invalid number of rounds", rounds)
+	}
+
+	// copy the forces so that we can update them.
+	var sides []*side
+	for _, force := range forces {
+		s := &side{name: force.Name}
+		for _, unit := range force.Units {
+			if unit.Count < 0 || unit.Attack < 0 || unit.Defense < 0 || unit.HitPoints < 1 {
+				return nil, fmt.Errorf("%s: %s: invalid unit", force.Name, unit.Name)
+			}
+			u := *unit
+			s.groups = append(s.groups, &group{Unit: &u})
+		}
+		sides = append(sides, s)
+	}
+
+	result := &Result{}
+	for n := 1; n <= rounds && fighting(sides); n++ {
+		round := &Round{Number: n}
+		for i, s := range sides {
+			for _, g := range s.groups {
+				if g.Attack == 0 || g.Count == 0 {
+					continue
+				}
+				fire := map[*group]*Fire{}
+				var order []*group
+				for shot := 0; shot < g.Count; shot++ {
+					ts, tg := target(r, sides, i)
+					if tg == nil {
+						break
+					}
+					f, ok := fire[tg]
+					if !ok {
+						f = &Fire{Force: s.name, Unit: g.Name, TargetForce: ts.name, TargetUnit: tg.Name, ChanceToHit: ChanceToHit(g.Attack, tg.Defense)}
+						fire[tg] = f
+						order = append(order, tg)
+					}
+					f.Shots++
+					if r.IntN(100) < f.ChanceToHit {
+						f.Hits++
+						tg.hits++
+					}
+				}
+				for _, tg := range order {
+					round.Fire = append(round.Fire, fire[tg])
+				}
+			}
+		}
+		// fire is simultaneous, so damage is applied after everyone has fired.
+		for _, s := range sides {
+			for _, g := range s.groups {
+				if g.hits == 0 {
+					continue
+				}
+				g.damage += g.hits
+				g.hits = 0
+				destroyed := min(g.damage/g.HitPoints, g.Count)
+				g.damage -= destroyed * g.HitPoints
+				g.Count -= destroyed
+				if g.Count == 0 {
+					g.damage = 0
+				}
+				if destroyed != 0 {
+					round.Losses = append(round.Losses, &Loss{Force: s.name, Unit: g.Name, Destroyed: destroyed, Remaining: g.Count})
+				}
+			}
+		}
+		result.Rounds = append(result.Rounds, round)
+	}
+
+	var alive []string
+	for _, s := range sides {
+		force := &Force{Name: s.name}
+		for _, g := range s.groups {
+			force.Units = append(force.Units, g.Unit)
+		}
+		result.Survivors = append(result.Survivors, force)
+		if s.units() != 0 {
+			alive = append(alive, s.name)
+		}
+	}
+	if len(alive) == 1 {
+		result.Winner = alive[0]
+	}
+	return result, nil
+}
+
+// side is a force while the battle is being fought.
+type side struct {
+	name   string
+	groups []*group
+}
+
+// group is a unit group while the battle is being fought.
+type group struct {
+	*Unit
+	damage int // damage on the next unit to be destroyed
+	hits   int // hits taken this round
+}
+
+func (s *side) units() int {
+	total := 0
+	for _, g := range s.groups {
+		total += g.Count
+	}
+	return total
+}
+
+func (s *side) armed() bool {
+	for _, g := range s.groups {
+		if g.Count != 0 && g.Attack != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// fighting returns true if at least one armed force has an opponent with units.
+func fighting(sides []*side) bool {
+	for i, s := range sides {
+		if !s.armed() {
+			continue
+		}
+		for j, o := range sides {
+			if i != j && o.units() != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// target picks a unit group in a force other than the shooter's, weighted
+// by the number of units in each group. It returns nil if there are no targets.
+func target(r *rand.Rand, sides []*side, shooter int) (*side, *group) {
+	total := 0
+	for i, s := range sides {
+		if i != shooter {
+			total += s.units()
+		}
+	}
+	if total == 0 {
+		return nil, nil
+	}
+	n := r.IntN(total)
+	for i, s := range sides {
+		if i == shooter {
+			continue
+		}
+		for _, g := range s.groups {
+			if n < g.Count {
+				return s, g
+			}
+			n -= g.Count
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package combat
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"testing"
+)
+
+func TestExamples(t *testing.T) {
+	for _, e := range Examples {
+		t.Run(e.Name, func(t *testing.T) {
+			if err := e.Verify(); err != nil {
+				t.Error(err)
+			}
+			// the rules hold for every seed, not just the example's.
+			for seed := uint64(0); seed < 200; seed++ {
+				other := *e
+				other.Seed = seed
+				if err := other.Verify(); err != nil {
+					t.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckCatchesBrokenRules(t *testing.T) {
+	e := FindExample("three-way")
+	if e == nil {
+		t.Fatal("missing example")
+	}
+	for _, tc := range []struct {
+		name   string
+		change func(result *Result)
+	}{
+		{"extra round", func(result *Result) {
+			result.Rounds = append(result.Rounds, &Round{Number: len(result.Rounds) + 1})
+		}},
+		{"missing shot", func(result *Result) { result.Rounds[0].Fire[0].Shots-- }},
+		{"wrong chance to hit", func(result *Result) { result.Rounds[0].Fire[0].ChanceToHit++ }},
+		{"unarmed fire", func(result *Result) {
+			fire := *result.Rounds[0].Fire[0]
+			fire.Force, fire.Unit = "green", "transport"
+			result.Rounds[0].Fire = append(result.Rounds[0].Fire, &fire)
+		}},
+		{"friendly fire", func(result *Result) {
+			fire := result.Rounds[0].Fire[0]
+			fire.TargetForce, fire.TargetUnit = fire.Force, fire.Unit
+		}},
+		{"missing loss", func(result *Result) {
+			for _, round := range result.Rounds {
+				if len(round.Losses) != 0 {
+					round.Losses = round.Losses[1:]
+					return
+				}
+			}
+		}},
+		{"wrong remaining", func(result *Result) {
+			for _, round := range result.Rounds {
+				if len(round.Losses) != 0 {
+					round.Losses[0].Remaining++
+					return
+				}
+			}
+		}},
+		{"wrong survivors", func(result *Result) { result.Survivors[0].Units[0].Count++ }},
+		{"wrong winner", func(result *Result) { result.Winner = "green" }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := Resolve(e.Rand(), e.Forces, e.Rounds)
+			if err != nil {
+				t.Fatal(err)
+			} else if err := Check(e.Forces, e.Rounds, result); err != nil {
+				t.Fatalf("before breaking: %v", err)
+			}
+			tc.change(result)
+			if err := Check(e.Forces, e.Rounds, result); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestChanceToHit(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		attack, defense int
+		want            int
+	}{
+		{"even", 4, 4, 50},
+		{"one over", 5, 4, 60},
+		{"one under", 3, 4, 40},
+		{"four over", 8, 4, 90},
+		{"five over clamps", 9, 4, 95},
+		{"far over clamps", 20, 0, 95},
+		{"four under", 0, 4, 10},
+		{"five under clamps", 0, 5, 5},
+		{"far under clamps", 0, 20, 5},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ChanceToHit(tc.attack, tc.defense); got != tc.want {
+				t.Errorf("ChanceToHit(%d, %d): got %d, want %d", tc.attack, tc.defense, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestResolveIsDeterministic(t *testing.T) {
+	for _, e := range Examples {
+		t.Run(e.Name, func(t *testing.T) {
+			first, err := Resolve(e.Rand(), e.Forces, e.Rounds)
+			if err != nil {
+				t.Fatal(err)
+			}
+			second, err := Resolve(e.Rand(), e.Forces, e.Rounds)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(first, second) {
+				t.Errorf("same seed gave different battles:\n%v\n%v", first.Log(), second.Log())
+			}
+		})
+	}
+}
+
+func TestResolveDoesNotChangeForces(t *testing.T) {
+	e := FindExample("ambush")
+	if e == nil {
+		t.Fatal("missing example")
+	}
+	before := e.Forces[1].Units[0].Count
+	if _, err := Resolve(e.Rand(), e.Forces, e.Rounds); err != nil {
+		t.Fatal(err)
+	} else if after := e.Forces[1].Units[0].Count; after != before {
+		t.Errorf("forces changed: got %d units, want %d", after, before)
+	}
+}
+
+func TestResolveErrors(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 0))
+	red := &Force{Name: "red", Units: []*Unit{{Name: "warship", Count: 1, Attack: 4, Defense: 4, HitPoints: 3}}}
+	for _, tc := range []struct {
+		name   string
+		r      *rand.Rand
+		forces []*Force
+		rounds int
+	}{
+		{"no generator", nil, []*Force{red, red}, 0},
+		{"one force", r, []*Force{red}, 0},
+		{"negative rounds", r, []*Force{red, red}, -1},
+		{"no hit points", r, []*Force{red, {Name: "blue", Units: []*Unit{{Name: "hulk", Count: 1}}}}, 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Resolve(tc.r, tc.forces, tc.rounds); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
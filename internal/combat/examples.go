@@ -0,0 +1,260 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package combat
+
+import (
+	"fmt"
+	"math/rand/v2"
+)
+
+// Example is a battle that is used to check the rules. The GM can run
+// the examples to check that the battles still follow the rules in the
+// package documentation.
+type Example struct {
+	Name        string
+	Description string
+	Seed        uint64
+	Rounds      int
+	Forces      []*Force
+}
+
+// Rand returns the generator for the example.
+func (e *Example) Rand() *rand.Rand {
+	return rand.New(rand.NewPCG(e.Seed, 0))
+}
+
+// Verify fights the example's battle and returns an error if the battle
+// breaks any of the rules in the package documentation.
+func (e *Example) Verify() error {
+	result, err := Resolve(e.Rand(), e.Forces, e.Rounds)
+	if err != nil {
+		return fmt.Errorf("%s: %w", e.Name, err)
+	} else if err := Check(e.Forces, e.Rounds, result); err != nil {
+		return fmt.Errorf("%s: seed %d: %w", e.Name, e.Seed, err)
+	}
+	return nil
+}
+
+// Examples are battles that exercise the rules as described in the
+// package documentation. They don't have expected outcomes; Verify checks
+// every round of the battle against the rules instead.
+//
+// The worked examples from the 1978 rulebook have not been transcribed
+// yet, so nothing here checks the rules against the rulebook. When they
+// are added, each one should cite its page or section and list the
+// outcome that the rulebook gives.
+var Examples = []*Example{
+	{
+		Name:        "unarmed",
+		Description: "forces without weapons can't fight, so no rounds are fought",
+		Seed:        1,
+		Forces: []*Force{
+			{Name: "red", Units: []*Unit{{Name: "transport", Count: 5, Defense: 2, HitPoints: 2}}},
+			{Name: "blue", Units: []*Unit{{Name: "transport", Count: 3, Defense: 2, HitPoints: 2}}},
+		},
+	},
+	{
+		Name:        "ambush",
+		Description: "warships catch unarmed transports, which can't fire back",
+		Seed:        1978,
+		Forces: []*Force{
+			{Name: "red", Units: []*Unit{{Name: "warship", Count: 10, Attack: 4, Defense: 4, HitPoints: 3}}},
+			{Name: "blue", Units: []*Unit{{Name: "transport", Count: 4, Defense: 2, HitPoints: 2}}},
+		},
+	},
+	{
+		Name:        "even",
+		Description: "equal fleets of warships fight to the round limit or to the end",
+		Seed:        1978,
+		Forces: []*Force{
+			{Name: "red", Units: []*Unit{{Name: "warship", Count: 6, Attack: 4, Defense: 4, HitPoints: 3}}},
+			{Name: "blue", Units: []*Unit{{Name: "warship", Count: 6, Attack: 4, Defense: 4, HitPoints: 3}}},
+		},
+	},
+	{
+		Name:        "swarm",
+		Description: "many weak fighters against two heavy cruisers, with the chance to hit clamped at both ends",
+		Seed:        1978,
+		Forces: []*Force{
+			{Name: "red", Units: []*Unit{{Name: "fighter", Count: 30, Attack: 2, Defense: 1, HitPoints: 1}}},
+			{Name: "blue", Units: []*Unit{{Name: "cruiser", Count: 2, Attack: 6, Defense: 6, HitPoints: 10}}},
+		},
+	},
+	{
+		Name:        "three-way",
+		Description: "every force fires at the others, choosing targets by numbers",
+		Seed:        1978,
+		Forces: []*Force{
+			{Name: "red", Units: []*Unit{{Name: "warship", Count: 5, Attack: 4, Defense: 4, HitPoints: 3}}},
+			{Name: "green", Units: []*Unit{
+				{Name: "warship", Count: 3, Attack: 4, Defense: 4, HitPoints: 3},
+				{Name: "transport", Count: 2, Defense: 2, HitPoints: 2},
+			}},
+			{Name: "blue", Units: []*Unit{{Name: "warship", Count: 4, Attack: 4, Defense: 4, HitPoints: 3}}},
+		},
+	},
+}
+
+// FindExample returns the example with the name, or nil.
+func FindExample(name string) *Example {
+	for _, e := range Examples {
+		if e.Name == name {
+			return e
+		}
+	}
+	return nil
+}
+
+// Check returns an error if the result of the battle between the forces
+// breaks any of the rules in the package documentation: who may fire,
+// how many shots they fire, the chance to hit, how damage destroys
+// units, when the battle ends, and who wins. The units in each force
+// must have different names.
+func Check(forces []*Force, rounds int, result *Result) error {
+	if rounds == 0 {
+		rounds = DefaultRounds
+	}
+	if len(result.Rounds) > rounds {
+		return fmt.Errorf("fought %d rounds, limit is %d", len(result.Rounds), rounds)
+	}
+
+	// track every group through the battle.
+	type key struct{ force, unit string }
+	type tracked struct {
+		*Unit
+		force  string
+		count  int
+		damage int
+	}
+	var groups []*tracked
+	byKey := map[key]*tracked{}
+	for _, force := range forces {
+		for _, unit := range force.Units {
+			k := key{force.Name, unit.Name}
+			if byKey[k] != nil {
+				return fmt.Errorf("%s: %s: listed twice", force.Name, unit.Name)
+			}
+			g := &tracked{Unit: unit, force: force.Name, count: unit.Count}
+			byKey[k] = g
+			groups = append(groups, g)
+		}
+	}
+	units := func(force string) int {
+		total := 0
+		for _, g := range groups {
+			if g.force == force {
+				total += g.count
+			}
+		}
+		return total
+	}
+	// the battle goes on while a force that can fire has an opponent with units.
+	fighting := func() bool {
+		for _, g := range groups {
+			if g.Attack == 0 || g.count == 0 {
+				continue
+			}
+			for _, force := range forces {
+				if force.Name != g.force && units(force.Name) != 0 {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	for n, round := range result.Rounds {
+		if round.Number != n+1 {
+			return fmt.Errorf("round %d: numbered %d", n+1, round.Number)
+		} else if !fighting() {
+			return fmt.Errorf("round %d: fought after the battle was over", round.Number)
+		}
+		// every armed unit fires one shot at a unit in an opposing force.
+		shots := map[*tracked]int{}
+		hits := map[*tracked]int{}
+		for _, fire := range round.Fire {
+			shooter, target := byKey[key{fire.Force, fire.Unit}], byKey[key{fire.TargetForce, fire.TargetUnit}]
+			if shooter == nil || target == nil {
+				return fmt.Errorf("round %d: %s %s fired at %s %s: no such unit", round.Number, fire.Force, fire.Unit, fire.TargetForce, fire.TargetUnit)
+			} else if shooter.Attack == 0 || shooter.count == 0 {
+				return fmt.Errorf("round %d: %s %s fired, but can't", round.Number, fire.Force, fire.Unit)
+			} else if target.force == shooter.force {
+				return fmt.Errorf("round %d: %s %s fired at its own force", round.Number, fire.Force, fire.Unit)
+			} else if target.count == 0 {
+				return fmt.Errorf("round %d: %s %s fired at %s %s, which had no units left", round.Number, fire.Force, fire.Unit, fire.TargetForce, fire.TargetUnit)
+			} else if want := ChanceToHit(shooter.Attack, target.Defense); fire.ChanceToHit != want {
+				return fmt.Errorf("round %d: %s %s: chance to hit %d%%, want %d%%", round.Number, fire.Force, fire.Unit, fire.ChanceToHit, want)
+			} else if fire.Shots < 1 || fire.Hits < 0 || fire.Hits > fire.Shots {
+				return fmt.Errorf("round %d: %s %s: %d hits from %d shots", round.Number, fire.Force, fire.Unit, fire.Hits, fire.Shots)
+			}
+			shots[shooter] += fire.Shots
+			hits[target] += fire.Hits
+		}
+		for _, g := range groups {
+			if g.Attack != 0 && shots[g] != g.count {
+				return fmt.Errorf("round %d: %s %s: fired %d shots, want %d", round.Number, g.force, g.Name, shots[g], g.count)
+			}
+		}
+
+		// fire is simultaneous, so losses are taken after everyone has fired.
+		// each hit is one point of damage, which carries over to the next unit.
+		losses := map[*tracked]*Loss{}
+		for _, loss := range round.Losses {
+			g := byKey[key{loss.Force, loss.Unit}]
+			if g == nil {
+				return fmt.Errorf("round %d: %s %s lost units: no such unit", round.Number, loss.Force, loss.Unit)
+			} else if losses[g] != nil {
+				return fmt.Errorf("round %d: %s %s lost units twice", round.Number, loss.Force, loss.Unit)
+			}
+			losses[g] = loss
+		}
+		for _, g := range groups {
+			g.damage += hits[g]
+			destroyed := min(g.damage/g.HitPoints, g.count)
+			g.damage -= destroyed * g.HitPoints
+			g.count -= destroyed
+			if g.count == 0 {
+				g.damage = 0
+			}
+			loss := losses[g]
+			if destroyed == 0 && loss != nil {
+				return fmt.Errorf("round %d: %s %s lost %d units, want none", round.Number, g.force, g.Name, loss.Destroyed)
+			} else if destroyed != 0 && loss == nil {
+				return fmt.Errorf("round %d: %s %s lost no units, want %d", round.Number, g.force, g.Name, destroyed)
+			} else if loss != nil && (loss.Destroyed != destroyed || loss.Remaining != g.count) {
+				return fmt.Errorf("round %d: %s %s lost %d with %d remaining, want %d with %d remaining",
+					round.Number, g.force, g.Name, loss.Destroyed, loss.Remaining, destroyed, g.count)
+			}
+		}
+	}
+	if len(result.Rounds) < rounds && fighting() {
+		return fmt.Errorf("stopped after %d rounds, but the battle wasn't over", len(result.Rounds))
+	}
+
+	// the survivors are the units left, and the winner is the only force with units.
+	if len(result.Survivors) != len(forces) {
+		return fmt.Errorf("%d forces survived, want %d", len(result.Survivors), len(forces))
+	}
+	for i, force := range result.Survivors {
+		if force.Name != forces[i].Name || len(force.Units) != len(forces[i].Units) {
+			return fmt.Errorf("survivors: force %d: got %q, want %q", i+1, force.Name, forces[i].Name)
+		}
+		for _, unit := range force.Units {
+			if g := byKey[key{force.Name, unit.Name}]; g == nil || unit.Count != g.count {
+				return fmt.Errorf("survivors: %s %s: got %d units", force.Name, unit.Name, unit.Count)
+			}
+		}
+	}
+	var alive []string
+	for _, force := range forces {
+		if units(force.Name) != 0 {
+			alive = append(alive, force.Name)
+		}
+	}
+	if len(alive) == 1 && result.Winner != alive[0] {
+		return fmt.Errorf("winner: got %q, want %q", result.Winner, alive[0])
+	} else if len(alive) != 1 && result.Winner != "" {
+		return fmt.Errorf("winner: got %q, want no winner", result.Winner)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+	"github.com/mdhender/moid/internal/orders"
+	"github.com/mdhender/moid/internal/sqlite"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// AddColony lets the GM found a colony or add colonists to one.
+type AddColony struct {
+	db *sqlite.Store
+}
+
+// NewAddColonyCommand creates a new instance of the AddColony command
+func NewAddColonyCommand(db *sqlite.Store) (*AddColony, error) {
+	c := &AddColony{
+		db: db,
+	}
+	return c, nil
+}
+
+// Run adds the colonists and enclosures to the empire's colony on the
+// planet, founding the colony if needed. The options are:
+//
+//	--game=code              the code of the game (required)
+//	--empire=id              the empire (required)
+//	--at=x,y,z/star/orbit    the planet (required)
+//	--kind=open|enclosed     the kind of colony (default open)
+//	--population=n           the number of colonists to add
+//	--enclosures=n           the number of enclosures to add (enclosed colonies only)
+func (c *AddColony) Run(ctx context.Context, args []string) error {
+	var code string
+	var empireID, population, enclosures int
+	var at *orders.Location
+	kind := models.OPEN
+	for _, arg := range args {
+		opt, val, ok := strings.Cut(arg, "=")
+		if opt == "--game" && ok && val != "" {
+			code = val
+		} else if opt == "--empire" && ok {
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return fmt.Errorf("%q: invalid empire", val)
+			}
+			empireID = n
+		} else if opt == "--at" && ok {
+			loc, err := orders.ParseLocation(val)
+			if err != nil {
+				return err
+			}
+			at = &loc
+		} else if opt == "--kind" && ok {
+			k, err := sqlite.ColonyKind(val)
+			if err != nil {
+				return err
+			}
+			kind = k
+		} else if opt == "--population" && ok {
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 0 {
+				return fmt.Errorf("%q: invalid population", val)
+			}
+			population = n
+		} else if opt == "--enclosures" && ok {
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 0 {
+				return fmt.Errorf("%q: invalid number of enclosures", val)
+			}
+			enclosures = n
+		} else {
+			return fmt.Errorf("unknown option: %q", arg)
+		}
+	}
+	if code == "" {
+		return fmt.Errorf("missing game")
+	} else if empireID == 0 {
+		return fmt.Errorf("missing empire")
+	} else if at == nil {
+		return fmt.Errorf("missing location")
+	} else if kind == models.OPEN && enclosures != 0 {
+		return fmt.Errorf("open colonies don't have enclosures")
+	}
+
+	cluster, err := c.db.ReadCluster(ctx, code)
+	if err != nil {
+		return err
+	}
+	planet := planetAt(cluster, *at)
+	if planet == nil {
+		return fmt.Errorf("%s: no planet", at)
+	}
+	colony, err := c.db.AddColony(ctx, code, empireID, planet.ID, kind, population, enclosures)
+	if err != nil {
+		return err
+	}
+	log.Printf("colony: %s: empire %d: %s: colony %d: %s: population %d of %d\n",
+		code, empireID, at, colony.ID, colony.Kind, colony.Population, colony.Capacity(planet.Habitability))
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+	"github.com/mdhender/moid/internal/orders"
+	"github.com/mdhender/moid/internal/sqlite"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// AddFleet lets the GM create a fleet for an empire.
+type AddFleet struct {
+	db *sqlite.Store
+}
+
+// NewAddFleetCommand creates a new instance of the AddFleet command
+func NewAddFleetCommand(db *sqlite.Store) (*AddFleet, error) {
+	c := &AddFleet{
+		db: db,
+	}
+	return c, nil
+}
+
+// Run creates a fleet orbiting the planet. The options are:
+//
+//	--game=code              the code of the game (required)
+//	--empire=id              the empire (required)
+//	--at=x,y,z/star/orbit    the planet (required)
+//	--scouts=n               the number of scouts
+//	--transports=n           the number of transports
+//	--warships=n             the number of warships
+//	--fuel=n                 the fuel in the fleet's tanks
+func (c *AddFleet) Run(ctx context.Context, args []string) error {
+	var code string
+	var empireID, fuel int
+	var at *orders.Location
+	ships := map[string]int{}
+	for _, arg := range args {
+		opt, val, ok := strings.Cut(arg, "=")
+		if opt == "--game" && ok && val != "" {
+			code = val
+		} else if opt == "--empire" && ok {
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return fmt.Errorf("%q: invalid empire", val)
+			}
+			empireID = n
+		} else if opt == "--at" && ok {
+			loc, err := orders.ParseLocation(val)
+			if err != nil {
+				return err
+			}
+			at = &loc
+		} else if (opt == "--scouts" || opt == "--transports" || opt == "--warships") && ok {
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 0 {
+				return fmt.Errorf("%q: invalid number of ships", val)
+			}
+			ships[strings.TrimSuffix(strings.TrimPrefix(opt, "--"), "s")] = n
+		} else if opt == "--fuel" && ok {
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 0 {
+				return fmt.Errorf("%q: invalid fuel", val)
+			}
+			fuel = n
+		} else {
+			return fmt.Errorf("unknown option: %q", arg)
+		}
+	}
+	if code == "" {
+		return fmt.Errorf("missing game")
+	} else if empireID == 0 {
+		return fmt.Errorf("missing empire")
+	} else if at == nil {
+		return fmt.Errorf("missing location")
+	}
+
+	cluster, err := c.db.ReadCluster(ctx, code)
+	if err != nil {
+		return err
+	}
+	var system *models.System
+	for _, s := range cluster.Systems {
+		if s.X == at.X && s.Y == at.Y && s.Z == at.Z {
+			system = s
+			break
+		}
+	}
+	planet := planetAt(cluster, *at)
+	if system == nil || planet == nil {
+		return fmt.Errorf("%s: no planet", at)
+	}
+	fleet, err := c.db.AddFleet(ctx, code, empireID, system.ID, planet.ID, ships, fuel)
+	if err != nil {
+		return err
+	}
+	log.Printf("fleet: %s: empire %d: %s: fleet %d: %d fuel\n", code, empireID, at, fleet.ID, fleet.Fuel)
+	return nil
+}
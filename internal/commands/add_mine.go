@@ -0,0 +1,101 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+	"github.com/mdhender/moid/internal/orders"
+	"github.com/mdhender/moid/internal/sqlite"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// AddMine lets the GM add mining units to a planet for an empire.
+type AddMine struct {
+	db *sqlite.Store
+}
+
+// NewAddMineCommand creates a new instance of the AddMine command
+func NewAddMineCommand(db *sqlite.Store) (*AddMine, error) {
+	c := &AddMine{
+		db: db,
+	}
+	return c, nil
+}
+
+// Run adds the units to the empire's mine on the planet. The options are:
+//
+//	--game=code              the code of the game (required)
+//	--empire=id              the empire (required)
+//	--at=x,y,z/star/orbit    the planet (required)
+//	--units=n                the number of mining units to add (required)
+func (c *AddMine) Run(ctx context.Context, args []string) error {
+	var code string
+	var empireID, units int
+	var at *orders.Location
+	for _, arg := range args {
+		opt, val, ok := strings.Cut(arg, "=")
+		if opt == "--game" && ok && val != "" {
+			code = val
+		} else if opt == "--empire" && ok {
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return fmt.Errorf("%q: invalid empire", val)
+			}
+			empireID = n
+		} else if opt == "--at" && ok {
+			loc, err := orders.ParseLocation(val)
+			if err != nil {
+				return err
+			}
+			at = &loc
+		} else if opt == "--units" && ok {
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return fmt.Errorf("%q: invalid number of units", val)
+			}
+			units = n
+		} else {
+			return fmt.Errorf("unknown option: %q", arg)
+		}
+	}
+	if code == "" {
+		return fmt.Errorf("missing game")
+	} else if empireID == 0 {
+		return fmt.Errorf("missing empire")
+	} else if at == nil {
+		return fmt.Errorf("missing location")
+	} else if units == 0 {
+		return fmt.Errorf("missing units")
+	}
+
+	cluster, err := c.db.ReadCluster(ctx, code)
+	if err != nil {
+		return err
+	}
+	planet := planetAt(cluster, *at)
+	if planet == nil {
+		return fmt.Errorf("%s: no planet", at)
+	}
+	mine, err := c.db.AddMiningUnits(ctx, code, empireID, planet.ID, units)
+	if err != nil {
+		return err
+	}
+	log.Printf("mine: %s: empire %d: %s: mine %d has %d units\n", code, empireID, at, mine.ID, mine.Units)
+	return nil
+}
+
+// planetAt returns the planet at the location, or nil if the orbit is empty.
+func planetAt(cluster *models.Cluster, at orders.Location) *models.Planet {
+	for _, system := range cluster.Systems {
+		if system.X != at.X || system.Y != at.Y || system.Z != at.Z || at.Star > len(system.Stars) {
+			continue
+		} else if star := system.Stars[at.Star-1]; at.Orbit < len(star.Orbits) {
+			return star.Orbits[at.Orbit]
+		}
+	}
+	return nil
+}
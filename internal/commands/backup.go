@@ -0,0 +1,74 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/mdhender/moid/internal/backup"
+	"github.com/mdhender/moid/internal/sqlite"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Backup writes a compressed snapshot of the database and prunes old ones.
+type Backup struct {
+	db     *sqlite.Store
+	path   string
+	daily  int
+	weekly int
+}
+
+// NewBackupCommand creates a new instance of the Backup command
+func NewBackupCommand(db *sqlite.Store, path string, daily, weekly int) (*Backup, error) {
+	c := &Backup{
+		db:     db,
+		path:   path,
+		daily:  daily,
+		weekly: weekly,
+	}
+	return c, nil
+}
+
+// Run makes the backup. It is safe to run while the server is running.
+// The options default to the backup configuration and are:
+//
+//	--path=dir           the directory for the backups
+//	--daily=n            the number of daily backups to keep
+//	--weekly=n           the number of weekly backups to keep
+func (c *Backup) Run(ctx context.Context, args []string) error {
+	path, daily, weekly := c.path, c.daily, c.weekly
+	for _, arg := range args {
+		opt, val, ok := strings.Cut(arg, "=")
+		if opt == "--path" && ok && val != "" {
+			path = val
+		} else if opt == "--daily" && ok {
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 0 {
+				return fmt.Errorf("%q: invalid daily", val)
+			}
+			daily = n
+		} else if opt == "--weekly" && ok {
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 0 {
+				return fmt.Errorf("%q: invalid weekly", val)
+			}
+			weekly = n
+		} else {
+			return fmt.Errorf("unknown option: %q", arg)
+		}
+	}
+
+	target, err := backup.Run(ctx, c.db, path, time.Now())
+	if err != nil {
+		return err
+	}
+	deleted, err := backup.Prune(path, daily, weekly)
+	if err != nil {
+		return err
+	}
+	log.Printf("backup: %s: pruned %d backups\n", target, len(deleted))
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/mdhender/moid/internal/combat"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Combat lets the GM fight ad-hoc battles and check the combat examples.
+type Combat struct{}
+
+// NewCombatCommand creates a new instance of the Combat command
+func NewCombatCommand() (*Combat, error) {
+	c := &Combat{}
+	return c, nil
+}
+
+// Run fights a battle and prints the log. The options are:
+//
+//	--verify                     check that every example follows the rules and print the results
+//	--example=name               fight one of the examples
+//	--seed=n                     the seed for the battle (default 1)
+//	--rounds=n                   the round limit (default 10)
+//	--force=name                 start a new force
+//	--unit=name,n,atk,def,hp     add n units to the last force
+//	--json                       print the result as JSON
+//
+// For example,
+//
+//	--force=red --unit=warship,10,4,4,3 --force=blue --unit=transport,4,0,2,2
+func (c *Combat) Run(ctx context.Context, args []string) error {
+	var seed uint64 = 1
+	var rounds int
+	var forces []*combat.Force
+	var example *combat.Example
+	var verify, asJSON bool
+	for _, arg := range args {
+		opt, val, ok := strings.Cut(arg, "=")
+		if opt == "--verify" && !ok {
+			verify = true
+		} else if opt == "--json" && !ok {
+			asJSON = true
+		} else if opt == "--example" && ok {
+			if example = combat.FindExample(val); example == nil {
+				return fmt.Errorf("%q: unknown example", val)
+			}
+		} else if opt == "--seed" && ok {
+			n, err := strconv.ParseUint(val, 10, 64)
+			if err != nil {
+				return fmt.Errorf("%q: invalid seed", val)
+			}
+			seed = n
+		} else if opt == "--rounds" && ok {
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return fmt.Errorf("%q: invalid number of rounds", val)
+			}
+			rounds = n
+		} else if opt == "--force" && ok && val != "" {
+			forces = append(forces, &combat.Force{Name: val})
+		} else if opt == "--unit" && ok {
+			if len(forces) == 0 {
+				return fmt.Errorf("%q: unit must follow a force", val)
+			}
+			unit, err := parseUnit(val)
+			if err != nil {
+				return err
+			}
+			force := forces[len(forces)-1]
+			force.Units = append(force.Units, unit)
+		} else {
+			return fmt.Errorf("unknown option: %q", arg)
+		}
+	}
+
+	if verify {
+		failed := 0
+		for _, e := range combat.Examples {
+			if err := e.Verify(); err != nil {
+				fmt.Printf("FAIL %v\n", err)
+				failed++
+				continue
+			}
+			fmt.Printf("ok   %s: %s\n", e.Name, e.Description)
+		}
+		if failed != 0 {
+			return fmt.Errorf("%d of %d examples failed", failed, len(combat.Examples))
+		}
+		return nil
+	}
+
+	r := rand.New(rand.NewPCG(seed, 0))
+	if example != nil {
+		if len(forces) != 0 {
+			return fmt.Errorf("can't add forces to an example")
+		}
+		r, forces = example.Rand(), example.Forces
+		if rounds == 0 {
+			rounds = example.Rounds
+		}
+	}
+	result, err := combat.Resolve(r, forces, rounds)
+	if err != nil {
+		return err
+	}
+	if asJSON {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(os.Stdout, "%s\n", data)
+		return err
+	}
+	for _, line := range result.Log() {
+		fmt.Println(line)
+	}
+	return nil
+}
+
+// parseUnit returns the unit from text like "warship,10,4,4,3".
+func parseUnit(text string) (*combat.Unit, error) {
+	fields := strings.Split(text, ",")
+	if len(fields) != 5 || fields[0] == "" {
+		return nil, fmt.Errorf("%q: want name,count,attack,defense,hit-points", text)
+	}
+	var n [4]int
+	for i, field := range fields[1:] {
+		v, err := strconv.Atoi(field)
+		if err != nil || v < 0 {
+			return nil, fmt.Errorf("%q: invalid unit", text)
+		}
+		n[i] = v
+	}
+	return &combat.Unit{Name: fields[0], Count: n[0], Attack: n[1], Defense: n[2], HitPoints: n[3]}, nil
+}
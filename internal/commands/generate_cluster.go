@@ -0,0 +1,101 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/mdhender/moid/internal/generators/cluster"
+	"github.com/mdhender/moid/internal/sqlite"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// GenerateCluster creates the cluster for a game from a seed.
+type GenerateCluster struct {
+	db *sqlite.Store
+}
+
+// NewGenerateClusterCommand creates a new instance of the GenerateCluster command
+func NewGenerateClusterCommand(db *sqlite.Store) (*GenerateCluster, error) {
+	c := &GenerateCluster{
+		db: db,
+	}
+	return c, nil
+}
+
+// Run generates the cluster and saves it to the game. The options are:
+//
+//	--game=code    the code of the game to populate (required unless --dry-run)
+//	--seed=n       the seed for the generator (required)
+//	--systems=n    the number of systems to generate (default 100)
+//	--output=path  write the cluster as JSON to the path
+//	--dry-run      generate the cluster but do not save it
+func (c *GenerateCluster) Run(ctx context.Context, args []string) error {
+	var code, output string
+	var seed uint64
+	var seedSet, dryRun bool
+	var options []cluster.Option
+	for _, arg := range args {
+		opt, val, ok := strings.Cut(arg, "=")
+		if opt == "--game" && ok && val != "" {
+			code = val
+		} else if opt == "--seed" && ok {
+			n, err := strconv.ParseUint(val, 10, 64)
+			if err != nil {
+				return fmt.Errorf("%q: invalid seed", val)
+			}
+			seed, seedSet = n, true
+		} else if opt == "--systems" && ok {
+			n, err := strconv.Atoi(val)
+			if err != nil {
+				return fmt.Errorf("%q: invalid number of systems", val)
+			}
+			options = append(options, cluster.WithSystems(n))
+		} else if opt == "--output" && ok && val != "" {
+			output = val
+		} else if opt == "--dry-run" {
+			dryRun = val == "" || val == "true" || val == "yes"
+		} else {
+			return fmt.Errorf("unknown option: %q", arg)
+		}
+	}
+	if !seedSet {
+		return fmt.Errorf("missing seed")
+	} else if code == "" && !dryRun {
+		return fmt.Errorf("missing game")
+	}
+
+	g, err := cluster.New(seed, options...)
+	if err != nil {
+		return err
+	}
+	cl, err := g.Generate()
+	if err != nil {
+		return err
+	}
+	log.Printf("cluster: seed %d: generated %d systems\n", seed, len(cl.Systems))
+
+	// the output is written before saving so that it doesn't include IDs.
+	if output != "" {
+		data, err := json.MarshalIndent(cl, "", "  ")
+		if err != nil {
+			return err
+		} else if err = os.WriteFile(output, data, 0644); err != nil {
+			return err
+		}
+		log.Printf("cluster: wrote %q\n", output)
+	}
+
+	if dryRun {
+		return nil
+	}
+	if err := c.db.CreateCluster(ctx, code, cl); err != nil {
+		return err
+	}
+	// the turn engine reuses the cluster's seed.
+	return c.db.SetGameSeed(ctx, code, seed)
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/mdhender/moid/internal/reports"
+	"github.com/mdhender/moid/internal/sqlite"
+	"strings"
+)
+
+// GenerateReports creates the reports for the current turn of a game.
+type GenerateReports struct {
+	db *sqlite.Store
+}
+
+// NewGenerateReportsCommand creates a new instance of the GenerateReports command
+func NewGenerateReportsCommand(db *sqlite.Store) (*GenerateReports, error) {
+	c := &GenerateReports{
+		db: db,
+	}
+	return c, nil
+}
+
+// Run creates or replaces the report for every empire in the game. The options are:
+//
+//	--game=code    the code of the game (required)
+func (c *GenerateReports) Run(ctx context.Context, args []string) error {
+	var code string
+	for _, arg := range args {
+		opt, val, ok := strings.Cut(arg, "=")
+		if opt == "--game" && ok && val != "" {
+			code = val
+		} else {
+			return fmt.Errorf("unknown option: %q", arg)
+		}
+	}
+	if code == "" {
+		return fmt.Errorf("missing game")
+	}
+	return reports.Generate(ctx, c.db, code)
+}
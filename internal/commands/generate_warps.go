@@ -0,0 +1,70 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/mdhender/moid/internal/generators/cluster"
+	"github.com/mdhender/moid/internal/sqlite"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// GenerateWarps replaces the warp lines in a game's cluster.
+type GenerateWarps struct {
+	db *sqlite.Store
+}
+
+// NewGenerateWarpsCommand creates a new instance of the GenerateWarps command
+func NewGenerateWarpsCommand(db *sqlite.Store) (*GenerateWarps, error) {
+	c := &GenerateWarps{
+		db: db,
+	}
+	return c, nil
+}
+
+// Run connects the systems in the game and saves the warps. The options are:
+//
+//	--game=code         the code of the game (required)
+//	--max-distance=n    the longest warp that may be added (default 5)
+//	--max-warps=n       the most warps that may be added to a system (default 4)
+//	--connected=bool    add warps so that every system is reachable (default true)
+func (c *GenerateWarps) Run(ctx context.Context, args []string) error {
+	var code string
+	rules := cluster.DefaultWarpRules()
+	for _, arg := range args {
+		opt, val, ok := strings.Cut(arg, "=")
+		if opt == "--game" && ok && val != "" {
+			code = val
+		} else if opt == "--max-distance" && ok {
+			n, err := strconv.ParseFloat(val, 64)
+			if err != nil || n < 0 {
+				return fmt.Errorf("%q: invalid distance", val)
+			}
+			rules.MaxDistance = n
+		} else if opt == "--max-warps" && ok {
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 0 {
+				return fmt.Errorf("%q: invalid number of warps", val)
+			}
+			rules.MaxWarps = n
+		} else if opt == "--connected" {
+			rules.Connected = val == "" || val == "true" || val == "yes"
+		} else {
+			return fmt.Errorf("unknown option: %q", arg)
+		}
+	}
+	if code == "" {
+		return fmt.Errorf("missing game")
+	}
+
+	cl, err := c.db.ReadClusterSystems(ctx, code)
+	if err != nil {
+		return err
+	}
+	warps := cluster.ConnectWarps(cl.Systems, rules)
+	log.Printf("warps: %s: connected %d systems with %d warps\n", code, len(cl.Systems), len(warps))
+	return c.db.SaveWarps(ctx, code, warps)
+}
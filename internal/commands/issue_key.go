@@ -0,0 +1,56 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/mdhender/moid/internal/sqlite"
+	"log"
+	"strings"
+)
+
+// IssueKey gives a player a new access key for logging in.
+type IssueKey struct {
+	db *sqlite.Store
+}
+
+// NewIssueKeyCommand creates a new instance of the IssueKey command
+func NewIssueKeyCommand(db *sqlite.Store) (*IssueKey, error) {
+	c := &IssueKey{
+		db: db,
+	}
+	return c, nil
+}
+
+// Run creates the key and prints it. The player is created if needed and
+// any old key stops working. Only the hash of the key is saved, so it
+// can't be shown again. The options are:
+//
+//	--player=name        the name of the player (required)
+//	--gm                 let the player use the admin pages
+func (c *IssueKey) Run(ctx context.Context, args []string) error {
+	var name string
+	var gm bool
+	for _, arg := range args {
+		opt, val, ok := strings.Cut(arg, "=")
+		if opt == "--player" && ok && val != "" {
+			name = val
+		} else if opt == "--gm" && !ok {
+			gm = true
+		} else {
+			return fmt.Errorf("unknown option: %q", arg)
+		}
+	}
+	if name == "" {
+		return fmt.Errorf("missing player")
+	}
+
+	key, err := c.db.IssueKey(ctx, name, gm)
+	if err != nil {
+		return err
+	}
+	log.Printf("issue-key: %s: issued a new key (gm %v)\n", name, gm)
+	fmt.Println(key)
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/mdhender/moid/internal/generators/sqlc"
+	"github.com/mdhender/moid/internal/sqlite"
+	"log"
+	"time"
+)
+
+// Migrate applies the DDL scripts that are embedded in the binary.
+type Migrate struct {
+	db *sqlite.Store
+}
+
+// NewMigrateCommand creates a new instance of the Migrate command
+func NewMigrateCommand(db *sqlite.Store) (*Migrate, error) {
+	c := &Migrate{
+		db: db,
+	}
+	return c, nil
+}
+
+// Run reports on or applies the migrations. Migrations never drop data,
+// so "up" is safe to run against a production database. The actions are:
+//
+//	status               list every migration and whether it has been applied
+//	up                   apply the pending migrations in version order
+func (c *Migrate) Run(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("expected one action, \"status\" or \"up\"")
+	}
+	switch args[0] {
+	case "status":
+		return c.status(ctx)
+	case "up":
+		return c.up(ctx)
+	}
+	return fmt.Errorf("unknown action: %q", args[0])
+}
+
+func (c *Migrate) status(ctx context.Context) error {
+	migrations, err := c.db.MigrationStatus(ctx, sqlc.Migrations)
+	if err != nil {
+		return err
+	}
+	pending := 0
+	for _, m := range migrations {
+		var state string
+		if m.Missing {
+			state = "applied " + m.AppliedAt.Format(time.DateTime) + ", MISSING from this release"
+		} else if m.Changed {
+			state = "applied " + m.AppliedAt.Format(time.DateTime) + ", CHANGED since it was applied"
+		} else if m.Pending() {
+			state, pending = "pending", pending+1
+		} else {
+			state = "applied " + m.AppliedAt.Format(time.DateTime)
+		}
+		fmt.Printf("%-40s %s\n", m.Script, state)
+	}
+	log.Printf("migrate: %d migrations, %d pending\n", len(migrations), pending)
+	return nil
+}
+
+func (c *Migrate) up(ctx context.Context) error {
+	applied, err := c.db.Migrate(ctx, sqlc.Migrations)
+	if err != nil {
+		return err
+	}
+	log.Printf("migrate: applied %d migrations\n", len(applied))
+	return nil
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/mdhender/moid/internal/backup"
+	"github.com/mdhender/moid/internal/sqlite"
+	"strings"
+	"time"
+)
+
+// Restore replaces the database with a backup.
+type Restore struct {
+	db *sqlite.Store
+}
+
+// NewRestoreCommand creates a new instance of the Restore command
+func NewRestoreCommand(db *sqlite.Store) (*Restore, error) {
+	c := &Restore{
+		db: db,
+	}
+	return c, nil
+}
+
+// Run restores the backup over the configured database. It refuses to
+// run while a server has the database open. The database being replaced
+// is kept next to it. The options are:
+//
+//	--from=file          the compressed backup to restore (required)
+func (c *Restore) Run(ctx context.Context, args []string) error {
+	var from string
+	for _, arg := range args {
+		opt, val, ok := strings.Cut(arg, "=")
+		if opt == "--from" && ok && val != "" {
+			from = val
+		} else {
+			return fmt.Errorf("unknown option: %q", arg)
+		}
+	}
+	if from == "" {
+		return fmt.Errorf("missing from")
+	}
+
+	// our own connections have to be closed before the file is replaced.
+	path := c.db.Path()
+	if err := c.db.Close(); err != nil {
+		return err
+	}
+	return backup.Restore(ctx, from, path, time.Now())
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+	"github.com/mdhender/moid/internal/sqlite"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// Reveal lets the GM add a system to an empire's knowledge.
+type Reveal struct {
+	db *sqlite.Store
+}
+
+// NewRevealCommand creates a new instance of the Reveal command
+func NewRevealCommand(db *sqlite.Store) (*Reveal, error) {
+	c := &Reveal{
+		db: db,
+	}
+	return c, nil
+}
+
+// Run records that the empire observed the system on the game's current
+// turn. The options are:
+//
+//	--game=code       the code of the game (required)
+//	--empire=id       the empire (required)
+//	--system=x,y,z    the coordinates of the system (required)
+//	--level=level     what is revealed (default system):
+//	                    system   - the coordinates and number of stars
+//	                    orbits   - the kind and habitability of every planet
+//	                    deposits - the deposits on every planet
+func (c *Reveal) Run(ctx context.Context, args []string) error {
+	var code, level string
+	var empireID int
+	var coords []int
+	for _, arg := range args {
+		opt, val, ok := strings.Cut(arg, "=")
+		if opt == "--game" && ok && val != "" {
+			code = val
+		} else if opt == "--empire" && ok {
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return fmt.Errorf("%q: invalid empire", val)
+			}
+			empireID = n
+		} else if opt == "--system" && ok {
+			coords = nil
+			for _, field := range strings.Split(val, ",") {
+				n, err := strconv.Atoi(field)
+				if err != nil {
+					return fmt.Errorf("%q: invalid coordinates", val)
+				}
+				coords = append(coords, n)
+			}
+			if len(coords) != 3 {
+				return fmt.Errorf("%q: invalid coordinates", val)
+			}
+		} else if opt == "--level" && ok && (val == "system" || val == "orbits" || val == "deposits") {
+			level = val
+		} else {
+			return fmt.Errorf("unknown option: %q", arg)
+		}
+	}
+	if code == "" {
+		return fmt.Errorf("missing game")
+	} else if empireID == 0 {
+		return fmt.Errorf("missing empire")
+	} else if coords == nil {
+		return fmt.Errorf("missing system")
+	} else if level == "" {
+		level = "system"
+	}
+
+	game, err := c.db.ReadGame(ctx, code)
+	if err != nil {
+		return err
+	}
+	cluster, err := c.db.ReadCluster(ctx, code)
+	if err != nil {
+		return err
+	}
+	var system *models.System
+	for _, s := range cluster.Systems {
+		if s.X == coords[0] && s.Y == coords[1] && s.Z == coords[2] {
+			system = s
+			break
+		}
+	}
+	if system == nil {
+		return fmt.Errorf("%d,%d,%d: system not found", coords[0], coords[1], coords[2])
+	}
+	knowledge, err := c.db.ReadKnowledge(ctx, code, empireID)
+	if err != nil {
+		return err
+	}
+
+	switch level {
+	case "system":
+		knowledge.ObserveSystem(system, game.CurrentTurn)
+	case "orbits":
+		knowledge.ObserveOrbits(system, game.CurrentTurn)
+	case "deposits":
+		for _, star := range system.Stars {
+			for orbit := range star.Orbits {
+				knowledge.ObserveDeposits(system, star, orbit, game.CurrentTurn)
+			}
+		}
+	}
+	if err := c.db.SaveKnowledge(ctx, code, empireID, knowledge, game.CurrentTurn); err != nil {
+		return err
+	}
+	log.Printf("reveal: %s: empire %d: system %d,%d,%d: revealed %s\n", code, empireID, system.X, system.Y, system.Z, level)
+	return nil
+}
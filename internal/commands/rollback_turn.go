@@ -0,0 +1,79 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/mdhender/moid/internal/sqlite"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RollbackTurn restores a game to the start of an earlier turn.
+type RollbackTurn struct {
+	db *sqlite.Store
+}
+
+// NewRollbackTurnCommand creates a new instance of the RollbackTurn command
+func NewRollbackTurnCommand(db *sqlite.Store) (*RollbackTurn, error) {
+	c := &RollbackTurn{
+		db: db,
+	}
+	return c, nil
+}
+
+// Run rolls the game back, or lists the turns it can be rolled back to.
+// Later turns are discarded but submitted orders are kept, so the turns
+// can be run again with run-turn. The options are:
+//
+//	--game=code          the code of the game (required)
+//	--turn=n             the turn to roll back to (required unless listing)
+//	--list               list the turns that have snapshots
+func (c *RollbackTurn) Run(ctx context.Context, args []string) error {
+	var code string
+	turn, list := -1, false
+	for _, arg := range args {
+		opt, val, ok := strings.Cut(arg, "=")
+		if opt == "--game" && ok && val != "" {
+			code = val
+		} else if opt == "--turn" && ok {
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 0 {
+				return fmt.Errorf("%q: invalid turn", val)
+			}
+			turn = n
+		} else if opt == "--list" && !ok {
+			list = true
+		} else {
+			return fmt.Errorf("unknown option: %q", arg)
+		}
+	}
+	if code == "" {
+		return fmt.Errorf("missing game")
+	}
+
+	if list {
+		snapshots, err := c.db.ReadSnapshots(ctx, code)
+		if err != nil {
+			return err
+		}
+		for _, snapshot := range snapshots {
+			log.Printf("rollback: %s: turn %d: saved %s\n", code, snapshot.Turn, snapshot.CreatedAt.UTC().Format(time.RFC3339))
+		}
+		if len(snapshots) == 0 {
+			log.Printf("rollback: %s: no snapshots\n", code)
+		}
+		return nil
+	} else if turn < 0 {
+		return fmt.Errorf("missing turn")
+	}
+
+	if err := c.db.RollbackGame(ctx, code, turn); err != nil {
+		return err
+	}
+	log.Printf("rollback: %s: now at turn %d\n", code, turn)
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/mdhender/moid/internal/sqlite"
+	"github.com/mdhender/moid/internal/turns"
+	"log"
+	"strings"
+	"time"
+)
+
+// RunTurn runs the current turn for a game.
+type RunTurn struct {
+	db *sqlite.Store
+}
+
+// NewRunTurnCommand creates a new instance of the RunTurn command
+func NewRunTurnCommand(db *sqlite.Store) (*RunTurn, error) {
+	c := &RunTurn{
+		db: db,
+	}
+	return c, nil
+}
+
+// Run processes the orders for the current turn, advances the game
+// to the next turn, and creates the reports for the new turn.
+// The options are:
+//
+//	--game=code    the code of the game (required)
+//	--dry-run      run the turn but do not save the results
+func (c *RunTurn) Run(ctx context.Context, args []string) error {
+	var code string
+	var dryRun bool
+	for _, arg := range args {
+		opt, val, ok := strings.Cut(arg, "=")
+		if opt == "--game" && ok && val != "" {
+			code = val
+		} else if opt == "--dry-run" {
+			dryRun = val == "" || val == "true" || val == "yes"
+		} else {
+			return fmt.Errorf("unknown option: %q", arg)
+		}
+	}
+	if code == "" {
+		return fmt.Errorf("missing game")
+	}
+
+	game, err := c.db.ReadGame(ctx, code)
+	if err != nil {
+		return err
+	}
+	// claim the turn so that the scheduler doesn't run it too.
+	// a dry run doesn't need the claim since it saves nothing.
+	if !dryRun {
+		if err := c.db.ClaimTurn(ctx, code, game.CurrentTurn, turns.Owner(), time.Now().Add(turns.Lease)); err != nil {
+			return err
+		}
+	}
+	err = turns.Run(ctx, c.db, code, game.CurrentTurn, dryRun)
+	if errors.Is(err, turns.ErrDryRun) {
+		log.Printf("turn: %s: dry run, nothing saved\n", code)
+		return nil
+	}
+	return err
+}
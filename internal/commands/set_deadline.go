@@ -0,0 +1,66 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/mdhender/moid/internal/sqlite"
+	"log"
+	"strings"
+	"time"
+)
+
+// SetDeadline sets the deadline for orders for a game's current turn.
+type SetDeadline struct {
+	db *sqlite.Store
+}
+
+// NewSetDeadlineCommand creates a new instance of the SetDeadline command
+func NewSetDeadlineCommand(db *sqlite.Store) (*SetDeadline, error) {
+	c := &SetDeadline{
+		db: db,
+	}
+	return c, nil
+}
+
+// Run updates the deadline. The options are:
+//
+//	--game=code          the code of the game (required)
+//	--deadline=time      the deadline as RFC 3339, or "none" to remove it (required)
+func (c *SetDeadline) Run(ctx context.Context, args []string) error {
+	var code string
+	var deadline time.Time
+	var haveDeadline bool
+	for _, arg := range args {
+		opt, val, ok := strings.Cut(arg, "=")
+		if opt == "--game" && ok && val != "" {
+			code = val
+		} else if opt == "--deadline" && ok && val == "none" {
+			deadline, haveDeadline = time.Time{}, true
+		} else if opt == "--deadline" && ok {
+			t, err := time.Parse(time.RFC3339, val)
+			if err != nil {
+				return fmt.Errorf("%q: invalid deadline", val)
+			}
+			deadline, haveDeadline = t, true
+		} else {
+			return fmt.Errorf("unknown option: %q", arg)
+		}
+	}
+	if code == "" {
+		return fmt.Errorf("missing game")
+	} else if !haveDeadline {
+		return fmt.Errorf("missing deadline")
+	}
+
+	if err := c.db.SetTurnDeadline(ctx, code, deadline); err != nil {
+		return err
+	}
+	if deadline.IsZero() {
+		log.Printf("deadline: %s: removed\n", code)
+	} else {
+		log.Printf("deadline: %s: set to %s\n", code, deadline.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
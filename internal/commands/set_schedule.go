@@ -0,0 +1,77 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/mdhender/moid/internal/sqlite"
+	"github.com/mdhender/moid/internal/turns"
+	"log"
+	"strings"
+	"time"
+)
+
+// SetSchedule sets when the server runs a game's turns.
+type SetSchedule struct {
+	db *sqlite.Store
+}
+
+// NewSetScheduleCommand creates a new instance of the SetSchedule command
+func NewSetScheduleCommand(db *sqlite.Store) (*SetSchedule, error) {
+	c := &SetSchedule{
+		db: db,
+	}
+	return c, nil
+}
+
+// Run updates the schedule and sets the deadline for the current turn to
+// the next scheduled time. Removing the schedule leaves the deadline alone.
+// The options are:
+//
+//	--game=code          the code of the game (required)
+//	--schedule=text      days, time, and zone, like "mon,thu 18:00 America/Chicago",
+//	                     or "none" to run turns by hand (required)
+func (c *SetSchedule) Run(ctx context.Context, args []string) error {
+	var code string
+	var schedule *turns.Schedule
+	var haveSchedule bool
+	for _, arg := range args {
+		opt, val, ok := strings.Cut(arg, "=")
+		if opt == "--game" && ok && val != "" {
+			code = val
+		} else if opt == "--schedule" && ok && val == "none" {
+			schedule, haveSchedule = nil, true
+		} else if opt == "--schedule" && ok {
+			s, err := turns.ParseSchedule(val)
+			if err != nil {
+				return err
+			}
+			schedule, haveSchedule = s, true
+		} else {
+			return fmt.Errorf("unknown option: %q", arg)
+		}
+	}
+	if code == "" {
+		return fmt.Errorf("missing game")
+	} else if !haveSchedule {
+		return fmt.Errorf("missing schedule")
+	}
+
+	if schedule == nil {
+		if err := c.db.SetSchedule(ctx, code, ""); err != nil {
+			return err
+		}
+		log.Printf("schedule: %s: removed\n", code)
+		return nil
+	}
+	if err := c.db.SetSchedule(ctx, code, schedule.String()); err != nil {
+		return err
+	}
+	next := schedule.Next(time.Now())
+	if err := c.db.SetTurnDeadline(ctx, code, next); err != nil {
+		return err
+	}
+	log.Printf("schedule: %s: set to %q, next turn runs at %s\n", code, schedule.String(), next.Format(time.RFC3339))
+	return nil
+}
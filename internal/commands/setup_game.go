@@ -0,0 +1,93 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/mdhender/moid/internal/reports"
+	"github.com/mdhender/moid/internal/setup"
+	"github.com/mdhender/moid/internal/sqlite"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// SetupGame creates a new game that is ready for turn 0 orders.
+type SetupGame struct {
+	db *sqlite.Store
+}
+
+// NewSetupGameCommand creates a new instance of the SetupGame command
+func NewSetupGameCommand(db *sqlite.Store) (*SetupGame, error) {
+	c := &SetupGame{
+		db: db,
+	}
+	return c, nil
+}
+
+// Run creates the game, its cluster, and an empire for each player, then
+// places the homeworlds and saves the turn 0 reports. The options are:
+//
+//	--code=code              the code for the game (required)
+//	--name=name              the name of the game (default is the code in lower case)
+//	--display-name=name      the display name of the game (default is the name)
+//	--seed=n                 the seed for the cluster and the turn engine (required)
+//	--systems=n              the number of systems to generate (default 100)
+//	--players=a,b,c          the names of the players (required)
+//	--min-habitability=n     the lowest habitability for a homeworld (default 12)
+func (c *SetupGame) Run(ctx context.Context, args []string) error {
+	var cfg setup.Config
+	var seedSet bool
+	for _, arg := range args {
+		opt, val, ok := strings.Cut(arg, "=")
+		if opt == "--code" && ok && val != "" {
+			cfg.Code = val
+		} else if opt == "--name" && ok && val != "" {
+			cfg.Name = val
+		} else if opt == "--display-name" && ok && val != "" {
+			cfg.DisplayName = val
+		} else if opt == "--seed" && ok {
+			n, err := strconv.ParseUint(val, 10, 64)
+			if err != nil {
+				return fmt.Errorf("%q: invalid seed", val)
+			}
+			cfg.Seed, seedSet = n, true
+		} else if opt == "--systems" && ok {
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return fmt.Errorf("%q: invalid number of systems", val)
+			}
+			cfg.Systems = n
+		} else if opt == "--players" && ok && val != "" {
+			cfg.Players = strings.Split(val, ",")
+		} else if opt == "--min-habitability" && ok {
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return fmt.Errorf("%q: invalid habitability", val)
+			}
+			cfg.MinHabitability = n
+		} else {
+			return fmt.Errorf("unknown option: %q", arg)
+		}
+	}
+	if cfg.Code == "" {
+		return fmt.Errorf("missing code")
+	} else if !seedSet {
+		return fmt.Errorf("missing seed")
+	} else if len(cfg.Players) == 0 {
+		return fmt.Errorf("missing players")
+	}
+
+	game, err := setup.NewGame(ctx, c.db, cfg)
+	if err != nil {
+		return err
+	}
+	for _, empire := range game.Empires {
+		for _, colony := range reports.Colonies(game, empire) {
+			log.Printf("setup: %s: empire %d: player %d: homeworld %s: population %d of %d\n",
+				game.Code, empire.ID, empire.PlayerID, colony.Location, colony.Population, colony.Capacity)
+		}
+	}
+	return nil
+}
@@ -44,8 +44,16 @@ type Config struct {
 	} `json:"server,omitempty"`
 
 	// Database configuration. The only supported database is SQLite3.
+	// Connections get foreign keys, WAL journaling, and the busy timeout
+	// when they're opened. Zero values for the pool limits mean no limit.
 	Database struct {
-		Path string `json:"path,omitempty"`
+		Path            string        `json:"path,omitempty"`
+		Create          bool          `json:"create,omitempty"`  // create the database if it doesn't exist
+		Migrate         bool          `json:"migrate,omitempty"` // apply pending migrations on open
+		BusyTimeout     time.Duration `json:"busy-timeout,omitempty"`
+		MaxOpenConns    int           `json:"max-open-conns,omitempty"`
+		MaxIdleConns    int           `json:"max-idle-conns,omitempty"`
+		ConnMaxLifetime time.Duration `json:"conn-max-lifetime,omitempty"`
 	} `json:"database,omitempty"`
 
 	// Assets configuration
@@ -57,6 +65,23 @@ type Config struct {
 	Views struct {
 		Path string `json:"path,omitempty"`
 	} `json:"views,omitempty"`
+
+	// Scheduler configuration. The scheduler runs turns for games that
+	// have a schedule while the server is running.
+	Scheduler struct {
+		Disabled bool          `json:"disabled,omitempty"`
+		Interval time.Duration `json:"interval,omitempty"` // how often to check for deadlines
+	} `json:"scheduler,omitempty"`
+
+	// Backup configuration. The backup job runs while the server is
+	// running if the interval is set. Backups are pruned to keep the
+	// newest from each of the last Daily days and Weekly weeks.
+	Backup struct {
+		Path     string        `json:"path,omitempty"`     // directory for the backups
+		Interval time.Duration `json:"interval,omitempty"` // zero disables the backup job
+		Daily    int           `json:"daily,omitempty"`
+		Weekly   int           `json:"weekly,omitempty"`
+	} `json:"backup,omitempty"`
 }
 
 // Environment is the environment the application is running in.
@@ -111,6 +136,13 @@ func Default(args []string) (*Config, error) {
 	cfg.Server.WriteTimeout = 10 * time.Second
 	cfg.Server.IdleTimeout = 120 * time.Second
 	cfg.Server.MaxHeaderBytes = 1 << 20
+	cfg.Database.BusyTimeout = 5 * time.Second
+	cfg.Database.MaxOpenConns = 8
+	cfg.Database.MaxIdleConns = 8
+	cfg.Scheduler.Interval = time.Minute
+	cfg.Backup.Path = "backups"
+	cfg.Backup.Daily = 7
+	cfg.Backup.Weekly = 4
 
 	// check for values in the environment variables
 	envSet := false
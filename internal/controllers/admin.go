@@ -2,5 +2,140 @@
 
 package controllers
 
+import (
+	"errors"
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+	"github.com/mdhender/moid/internal/setup"
+	"github.com/mdhender/moid/internal/sqlite"
+	"github.com/mdhender/moid/internal/views"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Admin is the controller for the GM pages. The routes only let the
+// GM through.
 type Admin struct {
+	db         *sqlite.Store
+	view       *views.View
+	gameView   *views.View
+	systemView *views.View
+}
+
+// NewAdminController creates a new instance of the Admin controller
+func NewAdminController(db *sqlite.Store, view, gameView, systemView *views.View) (*Admin, error) {
+	c := &Admin{
+		db:         db,
+		view:       view,
+		gameView:   gameView,
+		systemView: systemView,
+	}
+	// add any initialization logic here if needed
+	return c, nil
+}
+
+// AdminPage is the data for the admin template.
+type AdminPage struct {
+	Games   []*models.Game
+	Form    AdminGameForm
+	Message string
+	Error   string
+}
+
+// AdminGameForm is the values from the new game form.
+type AdminGameForm struct {
+	Code            string
+	Name            string
+	DisplayName     string
+	Seed            string
+	Systems         string
+	MinHabitability string
+	Players         string // one name per line
+}
+
+// Show lists the games and the form to create a new game.
+func (c Admin) Show(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s: %s\n", r.Method, r.URL.Path, r.RemoteAddr)
+
+	page := &AdminPage{Form: AdminGameForm{MinHabitability: strconv.Itoa(setup.MinHabitability)}}
+	if !c.load(w, r, page) {
+		return
+	}
+	c.view.Render(w, r, "admin.gohtml", page)
+}
+
+// CreateGame runs the setup for a new game from the form.
+func (c Admin) CreateGame(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s: %s\n", r.Method, r.URL.Path, r.RemoteAddr)
+
+	if err := r.ParseForm(); err != nil {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	page := &AdminPage{Form: AdminGameForm{
+		Code:            r.FormValue("code"),
+		Name:            r.FormValue("name"),
+		DisplayName:     r.FormValue("display_name"),
+		Seed:            r.FormValue("seed"),
+		Systems:         r.FormValue("systems"),
+		MinHabitability: r.FormValue("min_habitability"),
+		Players:         r.FormValue("players"),
+	}}
+
+	cfg, err := page.Form.config()
+	if err == nil {
+		var game *models.Game
+		if game, err = setup.NewGame(r.Context(), c.db, cfg); err == nil {
+			page.Message = fmt.Sprintf("Game %s was created with %d empires.", game.Code, len(game.Empires))
+			page.Form = AdminGameForm{MinHabitability: strconv.Itoa(setup.MinHabitability)}
+		}
+	}
+	if errors.Is(err, sqlite.ErrDuplicateGame) {
+		page.Error = "A game with that code, name, or display name already exists."
+	} else if err != nil {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		page.Error = fmt.Sprintf("The game was not created: %v.", err)
+	}
+
+	if !c.load(w, r, page) {
+		return
+	}
+	c.view.Render(w, r, "admin.gohtml", page)
+}
+
+// load reads the list of games for the page.
+// It writes the error response and returns false on failure.
+func (c Admin) load(w http.ResponseWriter, r *http.Request, page *AdminPage) bool {
+	games, err := c.db.ReadGames(r.Context())
+	if err != nil {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return false
+	}
+	page.Games = games
+	return true
+}
+
+// config returns the setup configuration from the form.
+func (f AdminGameForm) config() (setup.Config, error) {
+	cfg := setup.Config{Code: f.Code, Name: f.Name, DisplayName: f.DisplayName}
+	var err error
+	if cfg.Seed, err = strconv.ParseUint(strings.TrimSpace(f.Seed), 10, 64); err != nil {
+		return cfg, fmt.Errorf("seed: invalid value")
+	}
+	if s := strings.TrimSpace(f.Systems); s != "" {
+		if cfg.Systems, err = strconv.Atoi(s); err != nil || cfg.Systems < 1 {
+			return cfg, fmt.Errorf("systems: invalid value")
+		}
+	}
+	if s := strings.TrimSpace(f.MinHabitability); s != "" {
+		if cfg.MinHabitability, err = strconv.Atoi(s); err != nil || cfg.MinHabitability < 1 {
+			return cfg, fmt.Errorf("minimum habitability: invalid value")
+		}
+	}
+	cfg.Players = strings.Split(strings.ReplaceAll(f.Players, "\r\n", "\n"), "\n")
+	return cfg, nil
 }
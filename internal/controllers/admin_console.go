@@ -0,0 +1,442 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/mdhender/moid/internal/middlewares"
+	"github.com/mdhender/moid/internal/models"
+	"github.com/mdhender/moid/internal/sqlite"
+	"github.com/mdhender/moid/internal/turns"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// The GM console lets the GM browse a game and edit it by hand.
+// Every edit needs a reason and is recorded in the game's audit log
+// with the name of the GM that is logged in.
+
+// AdminGamePage is the data for the admin-game template.
+type AdminGamePage struct {
+	Game     *models.Game
+	Empires  []*AdminEmpire
+	Systems  []*models.System
+	AuditLog []*models.AuditEntry
+	Message  string
+	Error    string
+}
+
+// AdminEmpire is a summary of an empire for the GM.
+type AdminEmpire struct {
+	ID         int
+	Player     string
+	Colonies   int
+	Population int
+	Fleets     int
+	Mines      int
+	Stockpile  map[string]int
+}
+
+// AdminSystemPage is the data for the admin-system template.
+type AdminSystemPage struct {
+	Game    *models.Game
+	System  *models.System
+	Stars   []*AdminStar
+	Orbits  []*AdminOrbit
+	Message string
+	Error   string
+}
+
+// AdminStar is a star in the system.
+type AdminStar struct {
+	ID             int
+	Sequence       int
+	Description    string
+	Class          string // the value of stars.class
+	Size           string // the value of stars.size
+	Luminosity     int
+	HabitableOrbit int
+}
+
+// AdminOrbit is a planet in the system with its deposits.
+type AdminOrbit struct {
+	ID           int
+	Star         int
+	Orbit        int
+	Kind         string // the value of orbits.kind
+	Habitability int
+	Deposits     []*AdminDeposit
+}
+
+// AdminDeposit is a deposit on a planet.
+type AdminDeposit struct {
+	ID       int
+	Number   int
+	Kind     string
+	Quantity int
+	YieldPct int
+}
+
+// Game shows the game with its empires, systems, and audit log.
+func (c Admin) Game(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s: %s\n", r.Method, r.URL.Path, r.RemoteAddr)
+	c.renderGame(w, r, &AdminGamePage{})
+}
+
+// Rename changes the name and display name of the game.
+func (c Admin) Rename(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s: %s\n", r.Method, r.URL.Path, r.RemoteAddr)
+	page, edit, ok := c.gameForm(w, r)
+	if !ok {
+		return
+	}
+	err := c.db.RenameGame(r.Context(), r.PathValue("code"), r.FormValue("name"), r.FormValue("display_name"), edit)
+	page.result(err, "The game was renamed.")
+	c.renderGame(w, r, page)
+}
+
+// Turn runs the game's current turn or rolls the game back to an earlier
+// turn. "advance" runs the turn engine, saves the snapshot, and creates the
+// reports, the same as run-turn. It only runs the turn that was shown on the
+// page, so a second click can't skip a turn. "rollback" is the same as
+// rollback-turn. Both are recorded in the audit log.
+func (c Admin) Turn(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s: %s\n", r.Method, r.URL.Path, r.RemoteAddr)
+	page, edit, ok := c.gameForm(w, r)
+	if !ok {
+		return
+	}
+	code := r.PathValue("code")
+	switch action := r.FormValue("action"); action {
+	case "advance":
+		turn, err := strconv.Atoi(r.FormValue("current"))
+		if err != nil {
+			err = fmt.Errorf("%q: invalid turn", r.FormValue("current"))
+		} else if err = turns.Advance(r.Context(), c.db, code, turn, edit); errors.Is(err, sqlite.ErrTurnChanged) {
+			err = fmt.Errorf("turn %d has already been run", turn)
+		}
+		page.result(err, fmt.Sprintf("Turn %d was run.", turn))
+	case "rollback":
+		turn, err := strconv.Atoi(r.FormValue("turn"))
+		if err != nil {
+			err = fmt.Errorf("%q: invalid turn", r.FormValue("turn"))
+		} else {
+			err = c.db.RollbackGameTurn(r.Context(), code, turn, edit)
+		}
+		page.result(err, fmt.Sprintf("The game was rolled back to turn %d.", turn))
+	default:
+		page.result(fmt.Errorf("%q: unknown action", action), "")
+	}
+	c.renderGame(w, r, page)
+}
+
+// System shows the stars, planets, and deposits in a system.
+func (c Admin) System(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s: %s\n", r.Method, r.URL.Path, r.RemoteAddr)
+	c.renderSystem(w, r, r.PathValue("id"), &AdminSystemPage{})
+}
+
+// SetEmpirePlayer changes the player that controls an empire.
+func (c Admin) SetEmpirePlayer(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s: %s\n", r.Method, r.URL.Path, r.RemoteAddr)
+	page, edit, ok := c.gameForm(w, r)
+	if !ok {
+		return
+	}
+	empireID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	err = c.db.SetEmpirePlayer(r.Context(), r.PathValue("code"), empireID, r.FormValue("player"), edit)
+	page.result(err, fmt.Sprintf("Empire %d was updated.", empireID))
+	c.renderGame(w, r, page)
+}
+
+// SetStockpile changes the quantity of a resource an empire has on hand.
+func (c Admin) SetStockpile(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s: %s\n", r.Method, r.URL.Path, r.RemoteAddr)
+	page, edit, ok := c.gameForm(w, r)
+	if !ok {
+		return
+	}
+	empireID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	kind, err := sqlite.ResourceKind(r.FormValue("kind"))
+	var quantity int
+	if err == nil {
+		if quantity, err = strconv.Atoi(r.FormValue("quantity")); err != nil {
+			err = fmt.Errorf("%q: invalid quantity", r.FormValue("quantity"))
+		}
+	}
+	if err == nil {
+		err = c.db.SetStockpile(r.Context(), r.PathValue("code"), empireID, kind, quantity, edit)
+	}
+	page.result(err, fmt.Sprintf("Empire %d was updated.", empireID))
+	c.renderGame(w, r, page)
+}
+
+// SetStar changes the class, size, and luminosity of a star.
+func (c Admin) SetStar(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s: %s\n", r.Method, r.URL.Path, r.RemoteAddr)
+	page, edit, ok := c.systemForm(w, r)
+	if !ok {
+		return
+	}
+	starID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	var size models.StarSize_e
+	var luminosity int
+	class, err := sqlite.SpectralClass(r.FormValue("class"))
+	if err == nil {
+		size, err = sqlite.StarSize(r.FormValue("size"))
+	}
+	if err == nil {
+		if luminosity, err = strconv.Atoi(r.FormValue("luminosity")); err != nil {
+			err = fmt.Errorf("%q: invalid luminosity", r.FormValue("luminosity"))
+		}
+	}
+	if err == nil {
+		err = c.db.SetStar(r.Context(), r.PathValue("code"), starID, class, size, luminosity, edit)
+	}
+	page.result(err, fmt.Sprintf("Star %d was updated.", starID))
+	c.renderSystem(w, r, r.FormValue("system"), page)
+}
+
+// SetOrbit changes the kind and habitability of a planet.
+func (c Admin) SetOrbit(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s: %s\n", r.Method, r.URL.Path, r.RemoteAddr)
+	page, edit, ok := c.systemForm(w, r)
+	if !ok {
+		return
+	}
+	orbitID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	kind, err := sqlite.PlanetKind(r.FormValue("kind"))
+	var habitability int
+	if err == nil {
+		if habitability, err = strconv.Atoi(r.FormValue("habitability")); err != nil {
+			err = fmt.Errorf("%q: invalid habitability", r.FormValue("habitability"))
+		}
+	}
+	if err == nil {
+		err = c.db.SetOrbit(r.Context(), r.PathValue("code"), orbitID, kind, habitability, edit)
+	}
+	page.result(err, fmt.Sprintf("Orbit %d was updated.", orbitID))
+	c.renderSystem(w, r, r.FormValue("system"), page)
+}
+
+// SetDeposit changes the quantity and yield of a deposit.
+func (c Admin) SetDeposit(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s: %s\n", r.Method, r.URL.Path, r.RemoteAddr)
+	page, edit, ok := c.systemForm(w, r)
+	if !ok {
+		return
+	}
+	depositID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	var quantity, yieldPct int
+	if quantity, err = strconv.Atoi(r.FormValue("quantity")); err != nil {
+		err = fmt.Errorf("%q: invalid quantity", r.FormValue("quantity"))
+	} else if yieldPct, err = strconv.Atoi(r.FormValue("yield_pct")); err != nil {
+		err = fmt.Errorf("%q: invalid yield", r.FormValue("yield_pct"))
+	}
+	if err == nil {
+		err = c.db.SetDeposit(r.Context(), r.PathValue("code"), depositID, quantity, yieldPct, edit)
+	}
+	page.result(err, fmt.Sprintf("Deposit %d was updated.", depositID))
+	c.renderSystem(w, r, r.FormValue("system"), page)
+}
+
+// gameForm parses the form and returns the page and the edit. The
+// editor is the GM that is logged in.
+// It writes the error response and returns false on failure.
+func (c Admin) gameForm(w http.ResponseWriter, r *http.Request) (*AdminGamePage, models.Edit, bool) {
+	if err := r.ParseForm(); err != nil {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return nil, models.Edit{}, false
+	}
+	edit := models.Edit{Editor: middlewares.Player(r).Name, Reason: r.FormValue("reason")}
+	return &AdminGamePage{}, edit, true
+}
+
+// systemForm parses the form and returns the page and the edit. The
+// editor is the GM that is logged in.
+// It writes the error response and returns false on failure.
+func (c Admin) systemForm(w http.ResponseWriter, r *http.Request) (*AdminSystemPage, models.Edit, bool) {
+	if err := r.ParseForm(); err != nil {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return nil, models.Edit{}, false
+	}
+	edit := models.Edit{Editor: middlewares.Player(r).Name, Reason: r.FormValue("reason")}
+	return &AdminSystemPage{}, edit, true
+}
+
+func (p *AdminGamePage) result(err error, message string) {
+	p.Message, p.Error = editResult(err, message)
+}
+
+func (p *AdminSystemPage) result(err error, message string) {
+	p.Message, p.Error = editResult(err, message)
+}
+
+// editResult returns the message or the error to show after an edit.
+func editResult(err error, message string) (string, string) {
+	if err != nil {
+		return "", fmt.Sprintf("Nothing was changed: %v.", err)
+	}
+	return message, ""
+}
+
+// renderGame loads the game for the page and renders it.
+func (c Admin) renderGame(w http.ResponseWriter, r *http.Request, page *AdminGamePage) {
+	code := r.PathValue("code")
+	game, err := c.db.ReadGameState(r.Context(), code)
+	if errors.Is(err, sqlite.ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	} else if err != nil {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	players, err := c.db.ReadPlayerNames(r.Context(), code)
+	if err != nil {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if page.AuditLog, err = c.db.ReadAuditLog(r.Context(), code); err != nil {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	page.Game, page.Systems = game, game.Cluster.Systems
+	empires := map[int]*AdminEmpire{}
+	for _, empire := range game.Empires {
+		e := &AdminEmpire{ID: empire.ID, Player: players[empire.ID], Stockpile: map[string]int{}}
+		for kind, quantity := range empire.Stockpile {
+			e.Stockpile[kind.String()] = quantity
+		}
+		empires[empire.ID] = e
+		page.Empires = append(page.Empires, e)
+	}
+	for _, colony := range game.Colonies {
+		if e, ok := empires[colony.EmpireID]; ok {
+			e.Colonies, e.Population = e.Colonies+1, e.Population+colony.Population
+		}
+	}
+	for _, fleet := range game.Fleets {
+		if e, ok := empires[fleet.EmpireID]; ok {
+			e.Fleets++
+		}
+	}
+	for _, mine := range game.Mines {
+		if e, ok := empires[mine.EmpireID]; ok {
+			e.Mines++
+		}
+	}
+
+	c.gameView.Render(w, r, "admin-game.gohtml", page)
+}
+
+// renderSystem loads the system for the page and renders it.
+func (c Admin) renderSystem(w http.ResponseWriter, r *http.Request, id string, page *AdminSystemPage) {
+	code := r.PathValue("code")
+	systemID, err := strconv.Atoi(id)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	if page.Game, err = c.db.ReadGame(r.Context(), code); errors.Is(err, sqlite.ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	} else if err != nil {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	cluster, err := c.db.ReadCluster(r.Context(), code)
+	if err != nil {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	for _, system := range cluster.Systems {
+		if system.ID == systemID {
+			page.System = system
+			break
+		}
+	}
+	if page.System == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	for _, star := range page.System.Stars {
+		class, err := sqlite.SpectralClassText(star.Class)
+		if err != nil {
+			log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		size, err := sqlite.StarSizeText(star.Size)
+		if err != nil {
+			log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		page.Stars = append(page.Stars, &AdminStar{
+			ID:             star.ID,
+			Sequence:       star.Sequence,
+			Description:    star.String(),
+			Class:          class,
+			Size:           size,
+			Luminosity:     star.Luminosity,
+			HabitableOrbit: star.HabitableOrbit(),
+		})
+		for orbit, planet := range star.Orbits {
+			if planet == nil {
+				continue
+			}
+			kind, err := sqlite.PlanetKindText(planet.Kind)
+			if err != nil {
+				log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+			o := &AdminOrbit{ID: planet.ID, Star: star.Sequence, Orbit: orbit, Kind: kind, Habitability: planet.Habitability}
+			for n, deposit := range planet.NaturalResources {
+				if deposit != nil {
+					o.Deposits = append(o.Deposits, &AdminDeposit{
+						ID:       deposit.ID,
+						Number:   n,
+						Kind:     deposit.Kind.String(),
+						Quantity: deposit.Quantity,
+						YieldPct: deposit.YieldPct,
+					})
+				}
+			}
+			page.Orbits = append(page.Orbits, o)
+		}
+	}
+
+	c.systemView.Render(w, r, "admin-system.gohtml", page)
+}
@@ -2,5 +2,106 @@
 
 package controllers
 
+import (
+	"errors"
+	"github.com/mdhender/moid/internal/middlewares"
+	"github.com/mdhender/moid/internal/sqlite"
+	"github.com/mdhender/moid/internal/views"
+	"log"
+	"net/http"
+	"time"
+)
+
+// sessionTTL is how long a player stays logged in.
+const sessionTTL = 30 * 24 * time.Hour
+
+// Auth is the controller for logging players in and out.
+// Access keys are issued from the command line with issue-key.
 type Auth struct {
+	db     *sqlite.Store
+	view   *views.View
+	secure bool // true if the cookie should only be sent over HTTPS
+}
+
+// NewAuthController creates a new instance of the Auth controller
+func NewAuthController(db *sqlite.Store, view *views.View, secure bool) (*Auth, error) {
+	c := &Auth{
+		db:     db,
+		view:   view,
+		secure: secure,
+	}
+	return c, nil
+}
+
+// LoginPage is the data for the login template.
+type LoginPage struct {
+	Name  string
+	Error string
+}
+
+// Show renders the login form.
+func (c Auth) Show(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s: %s\n", r.Method, r.URL.Path, r.RemoteAddr)
+	c.view.Render(w, r, "login.gohtml", &LoginPage{})
+}
+
+// Login checks the name and access key and starts a session.
+func (c Auth) Login(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s: %s\n", r.Method, r.URL.Path, r.RemoteAddr)
+
+	if err := r.ParseForm(); err != nil {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	page := &LoginPage{Name: r.FormValue("name")}
+	expires := time.Now().Add(sessionTTL)
+	token, player, err := c.db.CreateSession(r.Context(), page.Name, r.FormValue("key"), expires)
+	if errors.Is(err, sqlite.ErrInvalidLogin) {
+		log.Printf("%s %s: %q: %v\n", r.Method, r.URL.Path, page.Name, err)
+		page.Error = "The name or access key is not valid."
+		c.view.Render(w, r, "login.gohtml", page)
+		return
+	} else if err != nil {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	log.Printf("%s %s: player %q: logged in\n", r.Method, r.URL.Path, player.Name)
+	http.SetCookie(w, &http.Cookie{
+		Name:     middlewares.SessionCookie,
+		Value:    token,
+		Path:     "/",
+		Expires:  expires,
+		HttpOnly: true,
+		Secure:   c.secure,
+		SameSite: http.SameSiteLaxMode,
+	})
+	if player.GM {
+		http.Redirect(w, r, "/admin", http.StatusSeeOther)
+		return
+	}
+	http.Redirect(w, r, "/reports", http.StatusSeeOther)
+}
+
+// Logout ends the session and clears the cookie.
+func (c Auth) Logout(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s: %s\n", r.Method, r.URL.Path, r.RemoteAddr)
+
+	if cookie, err := r.Cookie(middlewares.SessionCookie); err == nil {
+		if err := c.db.DeleteSession(r.Context(), cookie.Value); err != nil {
+			log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     middlewares.SessionCookie,
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   c.secure,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, "/home", http.StatusSeeOther)
 }
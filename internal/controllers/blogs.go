@@ -3,37 +3,48 @@
 package controllers
 
 import (
+	"github.com/mdhender/moid/internal/actions"
 	"github.com/mdhender/moid/internal/flash"
-	"github.com/mdhender/moid/internal/sqlite"
+	"github.com/mdhender/moid/internal/models"
 	"github.com/mdhender/moid/internal/views"
 	"log"
 	"net/http"
 )
 
 type Blogs struct {
-	db   *sqlite.Store
-	view *views.View
+	articles *actions.ArticlesFacade
+	view     *views.View
 }
 
 // NewBlogsController creates a new instance of the Blogs controller
-func NewBlogsController(db *sqlite.Store, view *views.View) (*Blogs, error) {
+func NewBlogsController(articles *actions.ArticlesFacade, view *views.View) (*Blogs, error) {
 	c := &Blogs{
-		db:   db,
-		view: view,
+		articles: articles,
+		view:     view,
 	}
 	// add any initialization logic here if needed
 	return c, nil
 }
 
+// Show lists the published articles, newest first.
 func (c Blogs) Show(w http.ResponseWriter, r *http.Request) {
 	log.Printf("%s %s: %s\n", r.Method, r.URL.Path, r.RemoteAddr)
 
 	store := flash.GetStore(r)
 
+	articles, err := c.articles.GetPublishedArticles(r.Context())
+	if err != nil {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
 	// - Render the template
 	c.view.Render(w, r, "blogs.gohtml", struct {
-		Error string
+		Error    string
+		Articles []*models.Article
 	}{
-		Error: store.Get("error"),
+		Error:    store.Get("error"),
+		Articles: articles,
 	})
 }
@@ -0,0 +1,81 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/mdhender/moid/internal/models"
+	"github.com/mdhender/moid/internal/reports"
+	"github.com/mdhender/moid/internal/sqlite"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+type Empires struct {
+	db *sqlite.Store
+}
+
+// NewEmpiresController creates a new instance of the Empires controller
+func NewEmpiresController(db *sqlite.Store) (*Empires, error) {
+	c := &Empires{
+		db: db,
+	}
+	// add any initialization logic here if needed
+	return c, nil
+}
+
+// EmpireStatus is the JSON response for an empire's status.
+type EmpireStatus struct {
+	Game      string            `json:"game"`
+	Empire    int               `json:"empire"`
+	Turn      int               `json:"turn"`
+	Stockpile map[string]int    `json:"stockpile"`
+	Colonies  []*reports.Colony `json:"colonies"`
+	Fleets    []*reports.Fleet  `json:"fleets"`
+}
+
+// Status returns the empire's current stockpile, colonies, and fleets as JSON.
+// The routes only let the empire's player and the GM through.
+func (c Empires) Status(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s: %s\n", r.Method, r.URL.Path, r.RemoteAddr)
+
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	game, err := c.db.ReadGameState(r.Context(), r.PathValue("code"))
+	if errors.Is(err, sqlite.ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	} else if err != nil {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	var empire *models.Empire
+	for _, e := range game.Empires {
+		if e.ID == id {
+			empire = e
+			break
+		}
+	}
+	if empire == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(EmpireStatus{
+		Game:      game.Code,
+		Empire:    empire.ID,
+		Turn:      game.CurrentTurn,
+		Stockpile: reports.Stockpile(empire),
+		Colonies:  reports.Colonies(game, empire),
+		Fleets:    reports.Fleets(game, empire),
+	}); err != nil {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+	}
+}
@@ -47,7 +47,6 @@ func (c Home) Show(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// TODO: Implement home page logic
-	// - Fetch required data from database
 	// - Process any markdown content
 	// - Handle any necessary encryption/decryption
 
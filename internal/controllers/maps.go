@@ -0,0 +1,483 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+	"github.com/mdhender/moid/internal/navigation"
+	"github.com/mdhender/moid/internal/sqlite"
+	"github.com/mdhender/moid/internal/views"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+type Maps struct {
+	db   *sqlite.Store
+	view *views.View
+}
+
+// NewMapsController creates a new instance of the Maps controller
+func NewMapsController(db *sqlite.Store, view *views.View) (*Maps, error) {
+	c := &Maps{
+		db:   db,
+		view: view,
+	}
+	// add any initialization logic here if needed
+	return c, nil
+}
+
+// MapSystem is the data the map script needs to draw a system.
+type MapSystem struct {
+	ID    int        `json:"id"`
+	X     int        `json:"x"`
+	Y     int        `json:"y"`
+	Z     int        `json:"z"`
+	Size  float64    `json:"size"`
+	Color [3]float64 `json:"color"`
+	Kind  string     `json:"kind"`
+	Warps [][3]int   `json:"warps"`
+	Seen  *int       `json:"seen,omitempty"` // turn the empire last observed the system; nil on the full map
+}
+
+// Show renders the 3D map of the entire cluster for the GM.
+// Players see the cluster through Empire.
+func (c Maps) Show(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s: %s\n", r.Method, r.URL.Path, r.RemoteAddr)
+
+	code := r.PathValue("code")
+	game, err := c.db.ReadGame(r.Context(), code)
+	if errors.Is(err, sqlite.ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	} else if err != nil {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	cluster, err := c.db.ReadClusterSystems(r.Context(), code)
+	if err != nil {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	c.render(w, r, game, 0, cluster, nil)
+}
+
+// Empire renders the 3D map of the systems that the empire has observed,
+// as it observed them. The cluster is filtered here rather than in the
+// script so that the page never contains systems the empire hasn't seen.
+func (c Maps) Empire(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s: %s\n", r.Method, r.URL.Path, r.RemoteAddr)
+
+	code := r.PathValue("code")
+	game, err := c.db.ReadGame(r.Context(), code)
+	if errors.Is(err, sqlite.ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	} else if err != nil {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	empireID, knowledge, ok := c.knowledge(w, r)
+	if !ok {
+		return
+	}
+	cluster, err := c.db.ReadClusterSystems(r.Context(), code)
+	if err != nil {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	c.render(w, r, game, empireID, cluster, knowledge)
+}
+
+// render renders the map of the cluster. If knowledge is not nil, only
+// the systems the empire has observed are shown, along with the turn
+// each was last observed.
+func (c Maps) render(w http.ResponseWriter, r *http.Request, game *models.Game, empireID int, cluster *models.Cluster, knowledge *models.Knowledge) {
+	if knowledge != nil {
+		cluster = knowledge.Cluster(cluster)
+	}
+
+	data := struct {
+		Game    *models.Game
+		Empire  int // zero for the full map
+		Systems []MapSystem
+	}{
+		Game:    game,
+		Empire:  empireID,
+		Systems: []MapSystem{},
+	}
+	// the script draws the warps from each end, so both systems list the warp.
+	warps := map[*models.System][][3]int{}
+	for _, warp := range cluster.Warps {
+		warps[warp.From] = append(warps[warp.From], [3]int{warp.To.X, warp.To.Y, warp.To.Z})
+		warps[warp.To] = append(warps[warp.To], [3]int{warp.From.X, warp.From.Y, warp.From.Z})
+	}
+	for _, system := range cluster.Systems {
+		ms := mapSystem(system)
+		if len(warps[system]) != 0 {
+			ms.Warps = warps[system]
+		}
+		if knowledge != nil {
+			if seen, ok := knowledge.Systems[system.ID]; ok {
+				turn := seen.Turn
+				ms.Seen = &turn
+			}
+		}
+		data.Systems = append(data.Systems, ms)
+	}
+
+	// - Render the template
+	c.view.Render(w, r, "cluster-map.gohtml", data)
+}
+
+// Sector is the JSON response for a sector query.
+// The bounds and radius are computed the same way as the map script.
+type Sector struct {
+	Origin struct {
+		X int `json:"x"`
+		Y int `json:"y"`
+		Z int `json:"z"`
+	} `json:"origin"`
+	Metric  string         `json:"metric"`
+	Range   int            `json:"range"`
+	Systems []SectorSystem `json:"systems"`
+	MinX    int            `json:"minX"`
+	MaxX    int            `json:"maxX"`
+	MinY    int            `json:"minY"`
+	MaxY    int            `json:"maxY"`
+	MinZ    int            `json:"minZ"`
+	MaxZ    int            `json:"maxZ"`
+	Radius  float64        `json:"radius"`
+}
+
+type SectorSystem struct {
+	ID    int          `json:"id"`
+	X     int          `json:"x"`
+	Y     int          `json:"y"`
+	Z     int          `json:"z"`
+	Stars []SectorStar `json:"stars"`
+}
+
+type SectorStar struct {
+	Sequence   int           `json:"sequence"`
+	Class      string        `json:"class"`
+	Size       string        `json:"size"`
+	Luminosity int           `json:"luminosity"`
+	Orbits     []SectorOrbit `json:"orbits"`
+}
+
+// SectorOrbit is the summary of a non-empty orbit.
+type SectorOrbit struct {
+	Orbit        int    `json:"orbit"`
+	Kind         string `json:"kind"`
+	Habitability int    `json:"habitability"`
+}
+
+// Sector returns the systems within a radius of a point as JSON.
+// It searches the entire cluster and is for the GM.
+func (c Maps) Sector(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s: %s\n", r.Method, r.URL.Path, r.RemoteAddr)
+	c.sector(w, r, nil)
+}
+
+// EmpireSector returns the systems within a radius of a point as JSON.
+// Only the systems the empire has observed are returned, as it observed them.
+func (c Maps) EmpireSector(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s: %s\n", r.Method, r.URL.Path, r.RemoteAddr)
+	if _, knowledge, ok := c.knowledge(w, r); ok {
+		c.sector(w, r, knowledge)
+	}
+}
+
+// sector writes the sector query as JSON. The query parameters are x, y, z
+// (default 0), radius (default 5), and metric ("chebyshev", the default,
+// or "euclidean"). If knowledge is not nil, the systems are filtered by it.
+func (c Maps) sector(w http.ResponseWriter, r *http.Request, knowledge *models.Knowledge) {
+	var sector Sector
+	var err error
+	query := r.URL.Query()
+	sector.Range = 5
+	for _, param := range []struct {
+		name string
+		val  *int
+	}{
+		{"x", &sector.Origin.X},
+		{"y", &sector.Origin.Y},
+		{"z", &sector.Origin.Z},
+		{"radius", &sector.Range},
+	} {
+		if s := query.Get(param.name); s != "" {
+			if *param.val, err = strconv.Atoi(s); err != nil {
+				http.Error(w, fmt.Sprintf("%s: invalid value", param.name), http.StatusBadRequest)
+				return
+			}
+		}
+	}
+	if sector.Range < 0 {
+		http.Error(w, "radius: invalid value", http.StatusBadRequest)
+		return
+	}
+	metric := sqlite.Chebyshev
+	switch sector.Metric = query.Get("metric"); sector.Metric {
+	case "", "chebyshev":
+		sector.Metric, metric = "chebyshev", sqlite.Chebyshev
+	case "euclidean":
+		metric = sqlite.Euclidean
+	default:
+		http.Error(w, "metric: invalid value", http.StatusBadRequest)
+		return
+	}
+
+	systems, err := c.db.ReadSector(r.Context(), r.PathValue("code"), sector.Origin.X, sector.Origin.Y, sector.Origin.Z, sector.Range, metric)
+	if errors.Is(err, sqlite.ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	} else if err != nil {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if knowledge != nil {
+		var seen []*models.System
+		for _, system := range systems {
+			if view := knowledge.View(system); view != nil {
+				seen = append(seen, view)
+			}
+		}
+		systems = seen
+	}
+
+	sector.Systems = []SectorSystem{}
+	sector.MinX, sector.MaxX = sector.Origin.X, sector.Origin.X
+	sector.MinY, sector.MaxY = sector.Origin.Y, sector.Origin.Y
+	sector.MinZ, sector.MaxZ = sector.Origin.Z, sector.Origin.Z
+	for n, system := range systems {
+		if n == 0 {
+			sector.MinX, sector.MaxX = system.X, system.X
+			sector.MinY, sector.MaxY = system.Y, system.Y
+			sector.MinZ, sector.MaxZ = system.Z, system.Z
+		}
+		sector.MinX, sector.MaxX = min(sector.MinX, system.X), max(sector.MaxX, system.X)
+		sector.MinY, sector.MaxY = min(sector.MinY, system.Y), max(sector.MaxY, system.Y)
+		sector.MinZ, sector.MaxZ = min(sector.MinZ, system.Z), max(sector.MaxZ, system.Z)
+
+		ss := SectorSystem{ID: system.ID, X: system.X, Y: system.Y, Z: system.Z, Stars: []SectorStar{}}
+		for _, star := range system.Stars {
+			st := SectorStar{
+				Sequence:   star.Sequence,
+				Class:      star.Class.String(),
+				Size:       star.Size.String(),
+				Luminosity: star.Luminosity,
+				Orbits:     []SectorOrbit{},
+			}
+			for orbit, planet := range star.Orbits {
+				if planet == nil {
+					continue
+				}
+				kind, err := sqlite.PlanetKindText(planet.Kind)
+				if err != nil {
+					log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+					http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+					return
+				}
+				st.Orbits = append(st.Orbits, SectorOrbit{Orbit: orbit, Kind: kind, Habitability: planet.Habitability})
+			}
+			ss.Stars = append(ss.Stars, st)
+		}
+		sector.Systems = append(sector.Systems, ss)
+	}
+	sector.Radius = float64(max(sector.MaxX-sector.MinX, sector.MaxY-sector.MinY, sector.MaxZ-sector.MinZ))/2 + 1
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(sector); err != nil {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+	}
+}
+
+// RouteResponse is the JSON response for a route query.
+type RouteResponse struct {
+	From     int           `json:"from"`
+	To       int           `json:"to"`
+	By       string        `json:"by"`
+	Jumps    int           `json:"jumps"`
+	Distance float64       `json:"distance"`
+	Systems  []RouteSystem `json:"systems"`
+}
+
+type RouteSystem struct {
+	ID int `json:"id"`
+	X  int `json:"x"`
+	Y  int `json:"y"`
+	Z  int `json:"z"`
+}
+
+// Route returns the shortest route between two systems as JSON.
+// It can use every warp in the cluster and is for the GM.
+func (c Maps) Route(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s: %s\n", r.Method, r.URL.Path, r.RemoteAddr)
+	c.route(w, r, nil)
+}
+
+// EmpireRoute returns the shortest route between two systems as JSON.
+// It only uses warps between systems the empire has observed.
+func (c Maps) EmpireRoute(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s: %s\n", r.Method, r.URL.Path, r.RemoteAddr)
+	if _, knowledge, ok := c.knowledge(w, r); ok {
+		c.route(w, r, knowledge)
+	}
+}
+
+// route writes the route query as JSON. The query parameters are from
+// and to (system IDs, required) and by ("jumps", the default, or
+// "distance"). If knowledge is not nil, the cluster is filtered by it.
+func (c Maps) route(w http.ResponseWriter, r *http.Request, knowledge *models.Knowledge) {
+	query := r.URL.Query()
+	var response RouteResponse
+	var err error
+	if response.From, err = strconv.Atoi(query.Get("from")); err != nil {
+		http.Error(w, "from: invalid value", http.StatusBadRequest)
+		return
+	} else if response.To, err = strconv.Atoi(query.Get("to")); err != nil {
+		http.Error(w, "to: invalid value", http.StatusBadRequest)
+		return
+	}
+	switch response.By = query.Get("by"); response.By {
+	case "", "jumps":
+		response.By = "jumps"
+	case "distance":
+	default:
+		http.Error(w, "by: invalid value", http.StatusBadRequest)
+		return
+	}
+
+	cluster, err := c.db.ReadClusterSystems(r.Context(), r.PathValue("code"))
+	if errors.Is(err, sqlite.ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	} else if err != nil {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if knowledge != nil {
+		cluster = knowledge.Cluster(cluster)
+	}
+	planner := navigation.NewPlanner(cluster)
+	from, to := planner.System(response.From), planner.System(response.To)
+	if from == nil {
+		http.Error(w, "from: system not found", http.StatusNotFound)
+		return
+	} else if to == nil {
+		http.Error(w, "to: system not found", http.StatusNotFound)
+		return
+	}
+
+	var route *navigation.Route
+	if response.By == "distance" {
+		route, err = planner.ShortestByDistance(from, to)
+	} else {
+		route, err = planner.ShortestByJumps(from, to)
+	}
+	if errors.Is(err, navigation.ErrNoRoute) {
+		http.Error(w, "no route between the systems", http.StatusNotFound)
+		return
+	} else if err != nil {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	response.Jumps, response.Distance = route.Jumps, route.Distance
+	for _, system := range route.Systems {
+		response.Systems = append(response.Systems, RouteSystem{ID: system.ID, X: system.X, Y: system.Y, Z: system.Z})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+	}
+}
+
+// knowledge returns what the empire in the path has observed.
+// If it returns false, the response has been written.
+func (c Maps) knowledge(w http.ResponseWriter, r *http.Request) (int, *models.Knowledge, bool) {
+	empireID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return 0, nil, false
+	}
+	knowledge, err := c.db.ReadKnowledge(r.Context(), r.PathValue("code"), empireID)
+	if errors.Is(err, sqlite.ErrNotFound) {
+		http.NotFound(w, r)
+		return 0, nil, false
+	} else if err != nil {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return 0, nil, false
+	}
+	return empireID, knowledge, true
+}
+
+// starColors are the colors used to draw stars of each spectral class.
+var starColors = map[models.Spectral_e][3]float64{
+	models.CLASS_O: {0.6, 0.7, 1},
+	models.CLASS_B: {0.7, 0.8, 1},
+	models.CLASS_A: {0.9, 0.9, 1},
+	models.CLASS_F: {1, 1, 0.8},
+	models.CLASS_G: {1, 1, 0},
+	models.CLASS_K: {1, 0.6, 0.2},
+	models.CLASS_M: {1, 0.2, 0.1},
+}
+
+// starSizes are the diameters used to draw stars of each size.
+var starSizes = map[models.StarSize_e]float64{
+	models.MAIN_SEQUENCE: 0.375,
+	models.GIANT:         0.625,
+	models.SUPERGIANT:    0.875,
+}
+
+// mapSystem derives the size, color, and label of a system from its stars.
+// The system is drawn in the color of its brightest star; companions
+// make it a little larger.
+func mapSystem(system *models.System) MapSystem {
+	ms := MapSystem{
+		ID:    system.ID,
+		X:     system.X,
+		Y:     system.Y,
+		Z:     system.Z,
+		Size:  0.25 + 0.125*float64(len(system.Stars)),
+		Color: [3]float64{1, 1, 1},
+		Kind:  "Unknown",
+		Warps: [][3]int{},
+	}
+	var primary *models.Star
+	for _, star := range system.Stars {
+		if primary == nil || star.Luminosity > primary.Luminosity {
+			primary = star
+		}
+	}
+	if primary != nil {
+		if color, ok := starColors[primary.Class]; ok {
+			ms.Color = color
+		}
+		if size, ok := starSizes[primary.Size]; ok {
+			ms.Size = size + 0.125*float64(len(system.Stars)-1)
+		}
+		ms.Kind = primary.String()
+	}
+	if n := len(system.Stars); n == 2 {
+		ms.Kind += ", binary"
+	} else if n == 3 {
+		ms.Kind += ", trinary"
+	} else if n > 3 {
+		ms.Kind += ", multiple"
+	}
+	return ms
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+	"github.com/mdhender/moid/internal/orders"
+	"github.com/mdhender/moid/internal/sqlite"
+	"github.com/mdhender/moid/internal/views"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxOrdersSize is the largest orders file that we will accept.
+const maxOrdersSize = 64 * 1024
+
+type Orders struct {
+	db   *sqlite.Store
+	view *views.View
+}
+
+// NewOrdersController creates a new instance of the Orders controller
+func NewOrdersController(db *sqlite.Store, view *views.View) (*Orders, error) {
+	c := &Orders{
+		db:   db,
+		view: view,
+	}
+	// add any initialization logic here if needed
+	return c, nil
+}
+
+// OrdersPage is the data for the orders page.
+type OrdersPage struct {
+	Game        *models.Game
+	EmpireID    int
+	Locked      bool            // true if the deadline has passed
+	Text        string          // the text in the form
+	Accepted    *models.Orders  // last accepted revision, may be nil
+	Latest      *models.Orders  // last revision, may be nil
+	Diagnostics []*orders.Error // errors in the text in the form
+	Message     string
+	Error       string
+}
+
+// Show renders the orders page for an empire. The routes only let the
+// empire's player and the GM through.
+func (c Orders) Show(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s: %s\n", r.Method, r.URL.Path, r.RemoteAddr)
+
+	page, ok := c.load(w, r)
+	if !ok {
+		return
+	}
+	if page.Accepted != nil {
+		page.Text = page.Accepted.Text
+	}
+	if page.Latest != nil && !page.Latest.Accepted {
+		// show the errors from the last submission so the player can fix them.
+		page.Text = page.Latest.Text
+		page.Diagnostics = diagnostics(page.Latest.Text)
+		page.Error = fmt.Sprintf("Revision %d has errors and was not accepted.", page.Latest.Revision)
+	}
+	c.view.Render(w, r, "orders.gohtml", page)
+}
+
+// Submit accepts pasted or uploaded orders for an empire. The orders are
+// saved as a new revision even if they have errors; only revisions without
+// errors are accepted for the turn.
+func (c Orders) Submit(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s: %s\n", r.Method, r.URL.Path, r.RemoteAddr)
+
+	r.Body = http.MaxBytesReader(w, r.Body, 2*maxOrdersSize)
+	if err := r.ParseMultipartForm(maxOrdersSize); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+
+	page, ok := c.load(w, r)
+	if !ok {
+		return
+	}
+
+	text := r.FormValue("orders")
+	if file, _, err := r.FormFile("file"); err == nil {
+		defer file.Close()
+		data, err := io.ReadAll(io.LimitReader(file, maxOrdersSize+1))
+		if err != nil {
+			log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+			return
+		} else if len(data) > maxOrdersSize {
+			http.Error(w, "orders file is too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		text = string(data)
+	} else if !errors.Is(err, http.ErrMissingFile) && !errors.Is(err, http.ErrNotMultipart) {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	page.Text = text
+	if strings.TrimSpace(text) == "" {
+		page.Error = "No orders were submitted."
+		c.view.Render(w, r, "orders.gohtml", page)
+		return
+	}
+
+	page.Diagnostics = diagnostics(text)
+	var lines []string
+	for _, e := range page.Diagnostics {
+		lines = append(lines, e.Error())
+	}
+
+	saved, err := c.db.SubmitOrders(r.Context(), page.Game.Code, page.EmpireID, text, strings.Join(lines, "\n"), time.Now())
+	if errors.Is(err, sqlite.ErrOrdersLocked) {
+		page.Locked = true
+		page.Error = fmt.Sprintf("Orders for turn %d closed at %s. Your orders were not saved.", page.Game.CurrentTurn, page.Game.Deadline.UTC().Format(time.RFC1123))
+		c.view.Render(w, r, "orders.gohtml", page)
+		return
+	} else if err != nil {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	page.Latest = saved
+	if saved.Accepted {
+		page.Accepted = saved
+		page.Message = fmt.Sprintf("Revision %d was accepted for turn %d.", saved.Revision, saved.Turn)
+	} else {
+		page.Error = fmt.Sprintf("Revision %d has errors and was not accepted.", saved.Revision)
+	}
+	c.view.Render(w, r, "orders.gohtml", page)
+}
+
+// load returns the page with the game, the empire from the path, and
+// the saved orders. If it returns false, the response has been written.
+func (c Orders) load(w http.ResponseWriter, r *http.Request) (*OrdersPage, bool) {
+	code := r.PathValue("code")
+	game, err := c.db.ReadGame(r.Context(), code)
+	if errors.Is(err, sqlite.ErrNotFound) {
+		http.NotFound(w, r)
+		return nil, false
+	} else if err != nil {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return nil, false
+	}
+	empireID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return nil, false
+	} else if _, err := c.db.ReadEmpire(r.Context(), code, empireID); errors.Is(err, sqlite.ErrNotFound) {
+		http.NotFound(w, r)
+		return nil, false
+	} else if err != nil {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return nil, false
+	}
+
+	page := &OrdersPage{
+		Game:     game,
+		EmpireID: empireID,
+		Locked:   !game.Deadline.IsZero() && !time.Now().Before(game.Deadline),
+	}
+	page.Latest, page.Accepted, err = c.db.ReadOrders(r.Context(), empireID, game.CurrentTurn)
+	if err != nil {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return nil, false
+	}
+	return page, true
+}
+
+// diagnostics returns the syntax errors in the orders.
+func diagnostics(text string) []*orders.Error {
+	var list orders.ErrorList
+	if _, err := orders.Parse(text); errors.As(err, &list) {
+		return list
+	}
+	return nil
+}
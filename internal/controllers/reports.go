@@ -3,37 +3,175 @@
 package controllers
 
 import (
+	"errors"
+	"fmt"
 	"github.com/mdhender/moid/internal/flash"
+	"github.com/mdhender/moid/internal/middlewares"
+	"github.com/mdhender/moid/internal/models"
 	"github.com/mdhender/moid/internal/sqlite"
 	"github.com/mdhender/moid/internal/views"
 	"log"
 	"net/http"
+	"slices"
+	"strconv"
 )
 
 type Reports struct {
-	db   *sqlite.Store
-	view *views.View
+	db         *sqlite.Store
+	view       *views.View
+	reportView *views.View
 }
 
 // NewReportsController creates a new instance of the Reports controller
-func NewReportsController(db *sqlite.Store, view *views.View) (*Reports, error) {
+func NewReportsController(db *sqlite.Store, view, reportView *views.View) (*Reports, error) {
 	c := &Reports{
-		db:   db,
-		view: view,
+		db:         db,
+		view:       view,
+		reportView: reportView,
 	}
 	// add any initialization logic here if needed
 	return c, nil
 }
 
+// ReportsIndex is the list of reports available for one game.
+type ReportsIndex struct {
+	Game    *models.Game
+	Empires []*ReportsIndexEmpire
+}
+
+type ReportsIndexEmpire struct {
+	ID    int
+	Turns []int // newest first
+}
+
+// Show lists the reports that are available for each game.
+// Players only see the reports for their own empires; the GM sees all of them.
 func (c Reports) Show(w http.ResponseWriter, r *http.Request) {
 	log.Printf("%s %s: %s\n", r.Method, r.URL.Path, r.RemoteAddr)
 
 	store := flash.GetStore(r)
+	player := middlewares.Player(r)
+
+	games, err := c.db.ReadGames(r.Context())
+	if err != nil {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	// owned is the set of empires the player controls, keyed by game code.
+	// It is nil for the GM.
+	var owned map[string][]int
+	if !player.GM {
+		owned, err = c.db.ReadPlayerEmpires(r.Context(), player.ID)
+		if err != nil {
+			log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+	}
+	var index []*ReportsIndex
+	for _, game := range games {
+		if owned != nil && len(owned[game.Code]) == 0 {
+			continue
+		}
+		list, err := c.db.ReadReportIndex(r.Context(), game.Code)
+		if err != nil {
+			log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		gi := &ReportsIndex{Game: game}
+		var empire *ReportsIndexEmpire
+		for _, report := range list {
+			if owned != nil && !slices.Contains(owned[game.Code], report.EmpireID) {
+				continue
+			}
+			if empire == nil || empire.ID != report.EmpireID {
+				empire = &ReportsIndexEmpire{ID: report.EmpireID}
+				gi.Empires = append(gi.Empires, empire)
+			}
+			empire.Turns = append([]int{report.Turn}, empire.Turns...)
+		}
+		index = append(index, gi)
+	}
 
 	// - Render the template
 	c.view.Render(w, r, "reports.gohtml", struct {
 		Error string
+		Games []*ReportsIndex
 	}{
 		Error: store.Get("error"),
+		Games: index,
 	})
 }
+
+// View renders an empire's report for a turn.
+func (c Reports) View(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s: %s\n", r.Method, r.URL.Path, r.RemoteAddr)
+
+	game, report, ok := c.load(w, r)
+	if !ok {
+		return
+	}
+	c.reportView.Render(w, r, "report.gohtml", struct {
+		Game   *models.Game
+		Report *models.Report
+	}{
+		Game:   game,
+		Report: report,
+	})
+}
+
+// Download sends an empire's report for a turn as a file.
+// The format is "text" (the default) or "json".
+func (c Reports) Download(w http.ResponseWriter, r *http.Request) {
+	log.Printf("%s %s: %s\n", r.Method, r.URL.Path, r.RemoteAddr)
+
+	game, report, ok := c.load(w, r)
+	if !ok {
+		return
+	}
+	name := fmt.Sprintf("%s-%04d-%04d", game.Code, report.EmpireID, report.Turn)
+	switch format := r.URL.Query().Get("format"); format {
+	case "", "text":
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+".txt"))
+		_, _ = w.Write([]byte(report.Text))
+	case "json":
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+".json"))
+		_, _ = w.Write([]byte(report.JSON))
+	default:
+		http.Error(w, fmt.Sprintf("%q: unknown format", format), http.StatusBadRequest)
+	}
+}
+
+// load returns the game and report from the path.
+// If it returns false, the response has been written.
+func (c Reports) load(w http.ResponseWriter, r *http.Request) (*models.Game, *models.Report, bool) {
+	code := r.PathValue("code")
+	empireID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return nil, nil, false
+	}
+	turn, err := strconv.Atoi(r.PathValue("turn"))
+	if err != nil {
+		http.NotFound(w, r)
+		return nil, nil, false
+	}
+	game, err := c.db.ReadGame(r.Context(), code)
+	if err == nil {
+		var report *models.Report
+		if report, err = c.db.ReadReport(r.Context(), code, empireID, turn); err == nil {
+			return game, report, true
+		}
+	}
+	if errors.Is(err, sqlite.ErrNotFound) {
+		http.NotFound(w, r)
+	} else {
+		log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+	return nil, nil, false
+}
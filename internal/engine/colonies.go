@@ -0,0 +1,175 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package engine
+
+import (
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+	"github.com/mdhender/moid/internal/orders"
+	"sort"
+)
+
+// GrowthPct is the percentage a colony's population grows each turn
+// until it reaches the colony's capacity.
+const GrowthPct = 5
+
+// colonization settles new colonies, surveys planets, and grows
+// the population of every colony. Colonize and survey orders are
+// applied together, in the order they were written, so a player can
+// survey a planet with a fleet before or after landing its colonists.
+func colonization(t *Turn) error {
+	if err := each(t, func(empire *models.Empire, o orders.Order) error {
+		switch o := o.(type) {
+		case *orders.Colonize:
+			t.colonize(empire, o)
+		case *orders.Survey:
+			t.survey(empire, o)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	return growth(t)
+}
+
+// colonize lands the fleet's colonists on the planet, founding
+// the colony if the empire doesn't already have one there.
+func (t *Turn) colonize(empire *models.Empire, o *orders.Colonize) {
+	fleet, p, reason := t.arrived(empire, o.Fleet, o.At)
+	if fleet == nil {
+		t.Rejectf(empire, o.Line, "%s: %s", o, reason)
+		return
+	}
+	colonists := fleet.Cargo["colonists"]
+	if colonists == 0 {
+		t.Rejectf(empire, o.Line, "%s: fleet %d: no colonists", o, o.Fleet)
+		return
+	}
+	kind := models.OPEN
+	if o.Kind == "enclosed" {
+		kind = models.ENCLOSED
+	}
+	colony := t.colony(empire.ID, p.planet.ID, kind)
+	if colony == nil {
+		colony = &models.Colony{EmpireID: empire.ID, OrbitID: p.planet.ID, Kind: kind, Founded: t.Game.CurrentTurn + 1}
+		if kind == models.OPEN && p.planet.Habitability == 0 {
+			t.Rejectf(empire, o.Line, "%s: planet is not habitable", o)
+			return
+		} else if kind == models.ENCLOSED {
+			// the fleet must carry the materials for the first enclosure.
+			cost := Costs["enclosure"]
+			for _, resource := range kinds(cost) {
+				if n := cost[resource]; fleet.Cargo[resource.String()] < n {
+					t.Rejectf(empire, o.Line, "%s: fleet %d: needs %d %s for an enclosure", o, o.Fleet, n, resource)
+					return
+				}
+			}
+			for resource, n := range cost {
+				fleet.Cargo[resource.String()] -= n
+			}
+			colony.Enclosures = 1
+		}
+		t.Game.Colonies = append(t.Game.Colonies, colony)
+	}
+	colony.Population += colonists
+	fleet.Cargo["colonists"] = 0
+	fleet.OrbitID = p.planet.ID
+	empire.Knowledge.ObserveDeposits(p.system, p.star, p.orbit, t.Game.CurrentTurn+1)
+	t.Eventf(empire.ID, o.Line, "%s: landed %d colonists", o, colonists)
+}
+
+// survey reports the orbits in the fleet's system and the deposits
+// on the planet.
+func (t *Turn) survey(empire *models.Empire, o *orders.Survey) {
+	fleet, p, reason := t.arrived(empire, o.Fleet, o.At)
+	if fleet == nil {
+		t.Rejectf(empire, o.Line, "%s: %s", o, reason)
+		return
+	}
+	fleet.OrbitID = p.planet.ID
+	empire.Knowledge.ObserveOrbits(p.system, t.Game.CurrentTurn+1)
+	empire.Knowledge.ObserveDeposits(p.system, p.star, p.orbit, t.Game.CurrentTurn+1)
+	t.Eventf(empire.ID, o.Line, "%s: surveyed", o)
+}
+
+// arrived returns the empire's fleet and the planet if the fleet is in
+// the planet's system. Otherwise, it returns nil and the reason.
+func (t *Turn) arrived(empire *models.Empire, id int, at orders.Location) (*models.Fleet, *place, string) {
+	fleet := t.fleet(empire.ID, id)
+	if fleet == nil {
+		return nil, nil, fmt.Sprintf("fleet %d: no such fleet", id)
+	} else if fleet.InTransit() {
+		return nil, nil, fmt.Sprintf("fleet %d: in transit", id)
+	}
+	p, reason := t.locate(at)
+	if p == nil {
+		return nil, nil, reason
+	} else if fleet.SystemID != p.system.ID {
+		return nil, nil, fmt.Sprintf("fleet %d: not at %s", id, at.Coords)
+	}
+	if empire.Knowledge == nil {
+		empire.Knowledge = models.NewKnowledge()
+	}
+	return fleet, p, ""
+}
+
+// growth grows the population of every colony. Colonies over capacity
+// lose the excess colonists.
+func growth(t *Turn) error {
+	// colonies grow in id order so the events are always the same.
+	colonies := make([]*models.Colony, len(t.Game.Colonies))
+	copy(colonies, t.Game.Colonies)
+	sort.SliceStable(colonies, func(i, j int) bool {
+		return colonies[i].ID < colonies[j].ID
+	})
+	for _, colony := range colonies {
+		empire, ok := t.empires[colony.EmpireID]
+		if !ok || colony.Population == 0 || colony.Founded > t.Game.CurrentTurn {
+			// colonies founded this turn start growing next turn.
+			continue
+		}
+		p, ok := t.planets[colony.OrbitID]
+		if !ok {
+			t.Eventf(empire.ID, 0, "colony %d: planet %d: not found", colony.ID, colony.OrbitID)
+			continue
+		}
+		capacity := colony.Capacity(p.planet.Habitability)
+		if colony.Population > capacity {
+			lost := colony.Population - capacity
+			colony.Population = capacity
+			t.Eventf(empire.ID, 0, "colony %d: %s: %d colonists died, population %d of %d",
+				colony.ID, p, lost, colony.Population, capacity)
+			continue
+		}
+		if colony.Population == capacity {
+			t.Eventf(empire.ID, 0, "colony %d: %s: population %d of %d, at capacity",
+				colony.ID, p, colony.Population, capacity)
+			continue
+		}
+		born := min(max(colony.Population*GrowthPct/100, 1), capacity-colony.Population)
+		colony.Population += born
+		t.Eventf(empire.ID, 0, "colony %d: %s: %d colonists born, population %d of %d",
+			colony.ID, p, born, colony.Population, capacity)
+	}
+	return nil
+}
+
+// colony returns the empire's colony of the given kind on the planet, or nil.
+func (t *Turn) colony(empireID, planetID int, kind models.Colony_e) *models.Colony {
+	for _, c := range t.Game.Colonies {
+		if c.EmpireID == empireID && c.OrbitID == planetID && c.Kind == kind {
+			return c
+		}
+	}
+	return nil
+}
+
+// colonyByID returns the empire's colony with the id, or nil.
+func (t *Turn) colonyByID(empireID, id int) *models.Colony {
+	for _, c := range t.Game.Colonies {
+		if c.EmpireID == empireID && c.ID == id {
+			return c
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package engine
+
+import (
+	"fmt"
+	"github.com/mdhender/moid/internal/combat"
+	"github.com/mdhender/moid/internal/models"
+	"sort"
+)
+
+// battles fights a battle in every system where fleets from more than
+// one empire meet. Every empire is hostile to every other empire.
+// Fleets in transit can't fight or be attacked.
+func battles(t *Turn) error {
+	bySystem := map[int][]*models.Fleet{}
+	for _, fleet := range t.Game.Fleets {
+		if !fleet.InTransit() && len(fleet.Classes()) != 0 {
+			bySystem[fleet.SystemID] = append(bySystem[fleet.SystemID], fleet)
+		}
+	}
+	var systems []int
+	for id := range bySystem {
+		systems = append(systems, id)
+	}
+	sort.Ints(systems)
+
+	for _, id := range systems {
+		fleets := bySystem[id]
+		sort.SliceStable(fleets, func(i, j int) bool {
+			if fleets[i].EmpireID != fleets[j].EmpireID {
+				return fleets[i].EmpireID < fleets[j].EmpireID
+			}
+			return fleets[i].ID < fleets[j].ID
+		})
+		// each empire's ships fight as a single force.
+		var forces []*combat.Force
+		var empires []int
+		byEmpire := map[int][]*models.Fleet{}
+		for _, fleet := range fleets {
+			if _, ok := byEmpire[fleet.EmpireID]; !ok {
+				empires = append(empires, fleet.EmpireID)
+			}
+			byEmpire[fleet.EmpireID] = append(byEmpire[fleet.EmpireID], fleet)
+		}
+		if len(empires) < 2 {
+			continue
+		}
+		for _, empireID := range empires {
+			ships := map[string]int{}
+			for _, fleet := range byEmpire[empireID] {
+				for _, class := range fleet.Classes() {
+					ships[class] += fleet.Ships[class]
+				}
+			}
+			force := &combat.Force{Name: fmt.Sprintf("empire %d", empireID)}
+			for _, name := range sortedClasses(ships) {
+				class, ok := models.ShipClasses[name]
+				if !ok {
+					return fmt.Errorf("%s: unknown ship class", name)
+				}
+				force.Units = append(force.Units, &combat.Unit{
+					Name:      name,
+					Count:     ships[name],
+					Attack:    class.Attack,
+					Defense:   class.Defense,
+					HitPoints: class.HitPoints,
+				})
+			}
+			forces = append(forces, force)
+		}
+
+		result, err := combat.Resolve(t.Rand, forces, 0)
+		if err != nil {
+			return err
+		} else if len(result.Rounds) == 0 {
+			continue // no one could fire
+		}
+		// every empire in the battle gets its own copy of the log,
+		// which is saved with the turn and shown in its next report.
+		system := t.byID[id]
+		for _, empireID := range empires {
+			empire := t.empires[empireID]
+			empire.Battles = append(empire.Battles, &models.Battle{
+				EmpireID: empireID,
+				SystemID: system.ID,
+				Turn:     t.Game.CurrentTurn,
+				Log:      result.Log(),
+			})
+			t.Eventf(empireID, 0, "battle at %d,%d,%d: %d rounds", system.X, system.Y, system.Z, len(result.Rounds))
+		}
+
+		// losses are taken from the empire's fleets in id order.
+		casualties := result.Casualties()
+		for _, empireID := range empires {
+			lost := casualties[fmt.Sprintf("empire %d", empireID)]
+			for _, fleet := range byEmpire[empireID] {
+				for _, class := range fleet.Classes() {
+					if n := min(lost[class], fleet.Ships[class]); n > 0 {
+						fleet.Ships[class] -= n
+						lost[class] -= n
+					}
+				}
+				if len(fleet.Classes()) == 0 {
+					t.Eventf(empireID, 0, "fleet %d: destroyed", fleet.ID)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// sortedClasses returns the names of the ship classes in order.
+func sortedClasses(ships map[string]int) []string {
+	var list []string
+	for name := range ships {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return list
+}
@@ -0,0 +1,207 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+// Package engine implements the turn engine.
+//
+// A turn runs in phases: movement, production, mining, combat, and
+// colonization. Each phase sees the changes made by the earlier phases.
+// Within a phase, orders are applied in empire order and then in the
+// order they were written, so the same game, seed, and orders always
+// produce the same results.
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+	"github.com/mdhender/moid/internal/orders"
+	"math/rand/v2"
+	"sort"
+)
+
+// Event is something that happened during a turn.
+type Event struct {
+	EmpireID int    // zero if the event isn't for a single empire
+	Phase    string // name of the phase that created the event
+	Line     int    // line of the order in the empire's orders, or zero
+	Text     string
+}
+
+func (e *Event) String() string {
+	if e.EmpireID == 0 {
+		return fmt.Sprintf("%s: %s", e.Phase, e.Text)
+	} else if e.Line == 0 {
+		return fmt.Sprintf("%s: empire %d: %s", e.Phase, e.EmpireID, e.Text)
+	}
+	return fmt.Sprintf("%s: empire %d: line %d: %s", e.Phase, e.EmpireID, e.Line, e.Text)
+}
+
+// Turn is the state of a game while its turn is being run.
+type Turn struct {
+	Game   *models.Game
+	Rand   *rand.Rand // the only source of randomness for the turn
+	Events []*Event
+
+	orders  []*order // every order, in the order they should be applied
+	phase   string
+	empires map[int]*models.Empire
+	planets map[int]*place // keyed by planet id
+	systems map[orders.Coords]*models.System
+	byID    map[int]*models.System // systems keyed by id
+}
+
+// place is where a planet is in the cluster.
+type place struct {
+	system *models.System
+	star   *models.Star
+	orbit  int
+	planet *models.Planet
+}
+
+func (p *place) String() string {
+	return fmt.Sprintf("%d,%d,%d/%d/%d", p.system.X, p.system.Y, p.system.Z, p.star.Sequence, p.orbit)
+}
+
+// order is a parsed order and the empire that gave it.
+type order struct {
+	empire *models.Empire
+	orders.Order
+}
+
+// Phase is a single step of the turn.
+type Phase struct {
+	Name string
+	Run  func(t *Turn) error
+}
+
+// Phases are the steps of the turn, in the order they run.
+var Phases = []Phase{
+	{Name: "movement", Run: movement},
+	{Name: "production", Run: production},
+	{Name: "mining", Run: mining},
+	{Name: "combat", Run: battles},
+	{Name: "colonization", Run: colonization},
+}
+
+// New returns the turn for the game. The game must have its cluster
+// and empires loaded. Orders for empires that aren't in the game are
+// an error. Orders that don't parse are rejected and ignored.
+func New(game *models.Game, submitted []*models.Orders) (*Turn, error) {
+	if game == nil || game.Cluster == nil {
+		return nil, errors.New("missing game state")
+	}
+	t := &Turn{
+		Game: game,
+		Rand: rand.New(rand.NewPCG(game.Seed, uint64(game.CurrentTurn))),
+	}
+
+	t.empires = map[int]*models.Empire{}
+	for _, empire := range game.Empires {
+		t.empires[empire.ID] = empire
+	}
+	t.planets = map[int]*place{}
+	t.systems = map[orders.Coords]*models.System{}
+	t.byID = map[int]*models.System{}
+	for _, system := range game.Cluster.Systems {
+		t.systems[orders.Coords{X: system.X, Y: system.Y, Z: system.Z}] = system
+		t.byID[system.ID] = system
+		for _, star := range system.Stars {
+			for orbit, planet := range star.Orbits {
+				if planet != nil {
+					t.planets[planet.ID] = &place{system: system, star: star, orbit: orbit, planet: planet}
+				}
+			}
+		}
+	}
+	sorted := make([]*models.Orders, len(submitted))
+	copy(sorted, submitted)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].EmpireID < sorted[j].EmpireID
+	})
+	t.phase = "orders"
+	for _, o := range sorted {
+		empire, ok := t.empires[o.EmpireID]
+		if !ok {
+			return nil, fmt.Errorf("orders %d: empire %d: not in game", o.ID, o.EmpireID)
+		} else if o.Turn != game.CurrentTurn {
+			return nil, fmt.Errorf("orders %d: turn %d: not the current turn", o.ID, o.Turn)
+		}
+		list, err := orders.Parse(o.Text)
+		var errs orders.ErrorList
+		if errors.As(err, &errs) {
+			for _, e := range errs {
+				t.Rejectf(empire, e.Line, "ignored: %s", e.Msg)
+			}
+		} else if err != nil {
+			return nil, err
+		}
+		for _, item := range list {
+			t.orders = append(t.orders, &order{empire: empire, Order: item})
+		}
+	}
+
+	return t, nil
+}
+
+// Run runs every phase of the turn. It doesn't change the turn number;
+// the caller does that when it saves the game.
+func (t *Turn) Run() error {
+	for _, phase := range Phases {
+		t.phase = phase.Name
+		if err := phase.Run(t); err != nil {
+			return fmt.Errorf("turn %d: %s: %w", t.Game.CurrentTurn, phase.Name, err)
+		}
+	}
+	return nil
+}
+
+// Eventf records an event for the empire in the current phase.
+// The empire and line may be zero.
+func (t *Turn) Eventf(empireID, line int, format string, args ...any) {
+	t.Events = append(t.Events, &Event{
+		EmpireID: empireID,
+		Phase:    t.phase,
+		Line:     line,
+		Text:     fmt.Sprintf(format, args...),
+	})
+}
+
+// Rejectf records an event for an order that the engine ignored and
+// adds it to the empire's rejections so that it is in the empire's report.
+func (t *Turn) Rejectf(empire *models.Empire, line int, format string, args ...any) {
+	t.Eventf(empire.ID, line, format, args...)
+	empire.Rejections = append(empire.Rejections, &models.Rejection{
+		EmpireID: empire.ID,
+		Turn:     t.Game.CurrentTurn,
+		Line:     line,
+		Reason:   t.Events[len(t.Events)-1].Text,
+	})
+}
+
+// locate returns the place for the location in the orders. If the
+// location isn't in the cluster or the orbit is empty, it returns
+// nil and the reason.
+func (t *Turn) locate(loc orders.Location) (*place, string) {
+	system, ok := t.systems[loc.Coords]
+	if !ok {
+		return nil, fmt.Sprintf("%s: no system", loc.Coords)
+	} else if loc.Star > len(system.Stars) {
+		return nil, fmt.Sprintf("%s: no star %d", loc.Coords, loc.Star)
+	}
+	star := system.Stars[loc.Star-1]
+	if loc.Orbit >= len(star.Orbits) || star.Orbits[loc.Orbit] == nil {
+		return nil, fmt.Sprintf("%s: orbit is empty", loc)
+	}
+	return t.planets[star.Orbits[loc.Orbit].ID], ""
+}
+
+// each calls fn for every order of type T, in the order they should be applied.
+func each[T orders.Order](t *Turn, fn func(empire *models.Empire, o T) error) error {
+	for _, item := range t.orders {
+		if o, ok := item.Order.(T); ok {
+			if err := fn(item.empire, o); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package engine
+
+import (
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+	"github.com/mdhender/moid/internal/orders"
+	"math"
+	"sort"
+)
+
+// movement sends fleets toward their destinations and then lands the
+// fleets that have arrived. Fleets travel in a straight line through
+// the cluster, burning fuel for the whole trip when they leave.
+func movement(t *Turn) error {
+	if err := each(t, func(empire *models.Empire, o *orders.Move) error {
+		fleet := t.fleet(empire.ID, o.Fleet)
+		if fleet == nil {
+			t.Rejectf(empire, o.Line, "%s: fleet %d: no such fleet", o, o.Fleet)
+			return nil
+		} else if fleet.InTransit() {
+			t.Rejectf(empire, o.Line, "%s: fleet %d: already in transit", o, o.Fleet)
+			return nil
+		}
+		from, ok := t.byID[fleet.SystemID]
+		if !ok {
+			return fmt.Errorf("fleet %d: system %d: not found", fleet.ID, fleet.SystemID)
+		}
+		to, ok := t.systems[o.To]
+		if !ok {
+			t.Rejectf(empire, o.Line, "%s: %s: no system", o, o.To)
+			return nil
+		} else if to == from {
+			t.Rejectf(empire, o.Line, "%s: fleet %d: already at %s", o, o.Fleet, o.To)
+			return nil
+		}
+		speed := fleet.Speed()
+		if speed == 0 {
+			t.Rejectf(empire, o.Line, "%s: fleet %d: no ships", o, o.Fleet)
+			return nil
+		}
+		distance := from.EuclideanDistance(to.X, to.Y, to.Z)
+		fuel := int(math.Ceil(distance)) * fleet.FuelPerDistance()
+		if fuel > fleet.Fuel {
+			t.Rejectf(empire, o.Line, "%s: fleet %d: needs %d fuel, has %d (TRANSFER fuel from a colony to refuel)", o, o.Fleet, fuel, fleet.Fuel)
+			return nil
+		}
+		turns := int(math.Ceil(distance / float64(speed)))
+		fleet.Fuel -= fuel
+		fleet.OrbitID = 0
+		fleet.DestinationID = to.ID
+		fleet.Arrives = t.Game.CurrentTurn + turns
+		t.Eventf(empire.ID, o.Line, "%s: departed, burned %d fuel, arrives turn %d", o, fuel, fleet.Arrives)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// fleets arrive in id order so the events are always the same.
+	fleets := make([]*models.Fleet, len(t.Game.Fleets))
+	copy(fleets, t.Game.Fleets)
+	sort.SliceStable(fleets, func(i, j int) bool {
+		return fleets[i].ID < fleets[j].ID
+	})
+	for _, fleet := range fleets {
+		empire, ok := t.empires[fleet.EmpireID]
+		if !ok || !fleet.InTransit() || fleet.Arrives > t.Game.CurrentTurn+1 {
+			continue
+		}
+		system, ok := t.byID[fleet.DestinationID]
+		if !ok {
+			return fmt.Errorf("fleet %d: system %d: not found", fleet.ID, fleet.DestinationID)
+		}
+		fleet.SystemID, fleet.DestinationID, fleet.Arrives = system.ID, 0, 0
+		if empire.Knowledge == nil {
+			empire.Knowledge = models.NewKnowledge()
+		}
+		empire.Knowledge.ObserveSystem(system, t.Game.CurrentTurn+1)
+		t.Eventf(empire.ID, 0, "fleet %d: arrived at %d,%d,%d", fleet.ID, system.X, system.Y, system.Z)
+	}
+	return nil
+}
+
+// fleet returns the empire's fleet with the id, or nil. Fleets that
+// lost all their ships in combat are ignored.
+func (t *Turn) fleet(empireID, id int) *models.Fleet {
+	for _, f := range t.Game.Fleets {
+		if f.EmpireID == empireID && f.ID == id && len(f.Classes()) != 0 {
+			return f
+		}
+	}
+	return nil
+}
+
+// transfer moves cargo between the holders. It returns the reason
+// if the transfer can't be made.
+func (t *Turn) transfer(empire *models.Empire, o *orders.Transfer) string {
+	from, reason := t.hold(empire, o.From, o.Cargo)
+	if from == nil {
+		return reason
+	}
+	to, reason := t.hold(empire, o.To, o.Cargo)
+	if to == nil {
+		return reason
+	}
+	if from.colony || to.colony {
+		if from.orbitID != to.orbitID {
+			return fmt.Sprintf("%s and %s are not at the same planet", o.From, o.To)
+		}
+	} else if from.systemID != to.systemID {
+		return fmt.Sprintf("%s and %s are not in the same system", o.From, o.To)
+	}
+	if have := from.get(); have < o.Quantity {
+		return fmt.Sprintf("%s: only has %d %s", o.From, have, o.Cargo)
+	} else if to.room >= 0 && to.room < o.Quantity {
+		return fmt.Sprintf("%s: only has room for %d %s", o.To, to.room, o.Cargo)
+	}
+	from.set(from.get() - o.Quantity)
+	to.set(to.get() + o.Quantity)
+	return ""
+}
+
+// hold is one kind of cargo in a fleet or colony.
+type hold struct {
+	get      func() int
+	set      func(n int)
+	room     int // space left, or -1 if there is no limit
+	colony   bool
+	systemID int
+	orbitID  int
+}
+
+// hold returns the cargo in the empire's fleet or colony. Colonists
+// at a colony are its population and everything else is taken from
+// the empire's stockpile. Fuel in a fleet is in its tanks.
+func (t *Turn) hold(empire *models.Empire, holder orders.Holder, cargo string) (*hold, string) {
+	if holder.Kind == "colony" {
+		colony := t.colonyByID(empire.ID, holder.ID)
+		if colony == nil {
+			return nil, fmt.Sprintf("%s: no such colony", holder)
+		}
+		p, ok := t.planets[colony.OrbitID]
+		if !ok {
+			return nil, fmt.Sprintf("%s: planet %d: not found", holder, colony.OrbitID)
+		}
+		h := &hold{room: -1, colony: true, systemID: p.system.ID, orbitID: colony.OrbitID}
+		if cargo == "colonists" {
+			h.get = func() int { return colony.Population }
+			h.set = func(n int) { colony.Population = n }
+			return h, ""
+		}
+		kind := resource(cargo)
+		if empire.Stockpile == nil {
+			empire.Stockpile = map[models.Resource_e]int{}
+		}
+		h.get = func() int { return empire.Stockpile[kind] }
+		h.set = func(n int) { empire.Stockpile[kind] = n }
+		return h, ""
+	}
+
+	fleet := t.fleet(empire.ID, holder.ID)
+	if fleet == nil {
+		return nil, fmt.Sprintf("%s: no such fleet", holder)
+	} else if fleet.InTransit() {
+		return nil, fmt.Sprintf("%s: in transit", holder)
+	}
+	h := &hold{systemID: fleet.SystemID, orbitID: fleet.OrbitID}
+	if cargo == "fuel" {
+		h.get = func() int { return fleet.Fuel }
+		h.set = func(n int) { fleet.Fuel = n }
+		h.room = fleet.FuelCapacity() - fleet.Fuel
+		return h, ""
+	}
+	h.get = func() int { return fleet.Cargo[cargo] }
+	h.set = func(n int) { fleet.Cargo[cargo] = n }
+	h.room = fleet.CargoCapacity() - fleet.CargoLoaded()
+	return h, ""
+}
+
+// resource returns the kind of resource for the cargo.
+func resource(cargo string) models.Resource_e {
+	for _, kind := range []models.Resource_e{models.FUEL, models.GOLD, models.METALLICS, models.NONMETALLICS} {
+		if kind.String() == cargo {
+			return kind
+		}
+	}
+	return 0
+}
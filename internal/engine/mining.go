@@ -0,0 +1,91 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package engine
+
+import (
+	"github.com/mdhender/moid/internal/models"
+	"github.com/mdhender/moid/internal/orders"
+	"sort"
+)
+
+// OrePerMiningUnit is the quantity one mining unit removes from a
+// deposit each turn. The deposit's yield is applied afterward.
+const OrePerMiningUnit = 1_000
+
+// mining applies the mining orders and then works every assigned deposit.
+func mining(t *Turn) error {
+	if err := each(t, func(empire *models.Empire, o *orders.Mine) error {
+		p, reason := t.locate(o.At)
+		if p == nil {
+			t.Rejectf(empire, o.Line, "%s: %s", o, reason)
+			return nil
+		}
+		mine := t.mine(empire.ID, p.planet.ID)
+		if mine == nil {
+			t.Rejectf(empire, o.Line, "%s: no mines at %s", o, o.At)
+			return nil
+		}
+		if o.Deposit >= len(p.planet.NaturalResources) || p.planet.NaturalResources[o.Deposit] == nil {
+			t.Rejectf(empire, o.Line, "%s: no deposit %d", o, o.Deposit)
+			return nil
+		}
+		deposit := p.planet.NaturalResources[o.Deposit]
+		available := mine.Units - mine.Assigned() + mine.Assignments[deposit.ID]
+		if o.Units > available {
+			t.Rejectf(empire, o.Line, "%s: only %d units available", o, available)
+			return nil
+		}
+		mine.Assignments[deposit.ID] = o.Units
+		t.Eventf(empire.ID, o.Line, "%s: assigned", o)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// mines are worked in id order and deposits in number order.
+	mines := make([]*models.Mine, len(t.Game.Mines))
+	copy(mines, t.Game.Mines)
+	sort.SliceStable(mines, func(i, j int) bool {
+		return mines[i].ID < mines[j].ID
+	})
+	for _, mine := range mines {
+		empire, ok := t.empires[mine.EmpireID]
+		if !ok {
+			continue
+		}
+		p, ok := t.planets[mine.OrbitID]
+		if !ok {
+			t.Eventf(empire.ID, 0, "mine %d: planet %d: not found", mine.ID, mine.OrbitID)
+			continue
+		}
+		for n, deposit := range p.planet.NaturalResources {
+			if deposit == nil || mine.Assignments[deposit.ID] == 0 {
+				continue
+			}
+			units := mine.Assignments[deposit.ID]
+			mined := min(units*OrePerMiningUnit, deposit.Quantity)
+			refined := mined * deposit.YieldPct / 100
+			deposit.Quantity -= mined
+			if empire.Stockpile == nil {
+				empire.Stockpile = map[models.Resource_e]int{}
+			}
+			empire.Stockpile[deposit.Kind] += refined
+			mine.Extractions = append(mine.Extractions, &models.Extraction{
+				DepositID: deposit.ID,
+				Turn:      t.Game.CurrentTurn,
+				Units:     units,
+				Mined:     mined,
+				Refined:   refined,
+				Remaining: deposit.Quantity,
+			})
+			t.Eventf(empire.ID, 0, "mine %d: %d,%d,%d/%d/%d: deposit %d: mined %d, refined %d %s, %d remaining",
+				mine.ID, p.system.X, p.system.Y, p.system.Z, p.star.Sequence, p.orbit, n, mined, refined, deposit.Kind, deposit.Quantity)
+			if deposit.Quantity == 0 {
+				// the units are idle until they are assigned to another deposit.
+				delete(mine.Assignments, deposit.ID)
+				t.Eventf(empire.ID, 0, "mine %d: deposit %d: exhausted", mine.ID, n)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package engine
+
+import (
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+	"github.com/mdhender/moid/internal/orders"
+	"sort"
+	"strings"
+)
+
+// Costs are the refined resources needed to build one of each unit.
+// Units that aren't listed can't be built yet.
+var Costs = map[string]map[models.Resource_e]int{
+	"enclosure": {models.METALLICS: 200, models.NONMETALLICS: 100},
+	"mine":      {models.METALLICS: 100, models.NONMETALLICS: 50},
+	"scout":     {models.METALLICS: 50, models.NONMETALLICS: 25},
+	"transport": {models.METALLICS: 200, models.NONMETALLICS: 100},
+	"warship":   {models.METALLICS: 500, models.NONMETALLICS: 250},
+}
+
+// production builds units and transfers cargo.
+func production(t *Turn) error {
+	if err := each(t, func(empire *models.Empire, o *orders.Build) error {
+		p, reason := t.locate(o.At)
+		if p == nil {
+			t.Rejectf(empire, o.Line, "%s: %s", o, reason)
+			return nil
+		}
+		open, enclosed := t.colony(empire.ID, p.planet.ID, models.OPEN), t.colony(empire.ID, p.planet.ID, models.ENCLOSED)
+		if open == nil && enclosed == nil {
+			t.Rejectf(empire, o.Line, "%s: no colony at %s", o, o.At)
+			return nil
+		}
+		cost, ok := Costs[o.Unit]
+		if !ok {
+			t.Rejectf(empire, o.Line, "%s: %s can't be built yet", o, o.Unit)
+			return nil
+		} else if o.Unit == "enclosure" && enclosed == nil {
+			t.Rejectf(empire, o.Line, "%s: no enclosed colony at %s", o, o.At)
+			return nil
+		}
+		if short := shortfall(empire, cost, o.Quantity); short != "" {
+			t.Rejectf(empire, o.Line, "%s: not enough %s", o, short)
+			return nil
+		}
+		for kind, n := range cost {
+			empire.Stockpile[kind] -= n * o.Quantity
+		}
+		switch o.Unit {
+		case "enclosure":
+			enclosed.Enclosures += o.Quantity
+		case "mine":
+			mine := t.mine(empire.ID, p.planet.ID)
+			if mine == nil {
+				mine = &models.Mine{EmpireID: empire.ID, OrbitID: p.planet.ID, Assignments: map[int]int{}}
+				t.Game.Mines = append(t.Game.Mines, mine)
+			}
+			mine.Units += o.Quantity
+		case "scout", "transport", "warship":
+			// new ships are put in a new fleet orbiting the colony.
+			// their tanks are filled from the empire's stockpile, as
+			// far as the stockpile goes.
+			fleet := &models.Fleet{
+				EmpireID: empire.ID,
+				SystemID: p.system.ID,
+				OrbitID:  p.planet.ID,
+				Ships:    map[string]int{o.Unit: o.Quantity},
+				Cargo:    map[string]int{},
+			}
+			fleet.Fuel = min(fleet.FuelCapacity(), empire.Stockpile[models.FUEL])
+			empire.Stockpile[models.FUEL] -= fleet.Fuel
+			t.Game.Fleets = append(t.Game.Fleets, fleet)
+			t.Eventf(empire.ID, o.Line, "%s: built, fueled %d of %d", o, fleet.Fuel, fleet.FuelCapacity())
+			return nil
+		}
+		t.Eventf(empire.ID, o.Line, "%s: built", o)
+		return nil
+	}); err != nil {
+		return err
+	}
+	return each(t, func(empire *models.Empire, o *orders.Transfer) error {
+		if reason := t.transfer(empire, o); reason != "" {
+			t.Rejectf(empire, o.Line, "%s: %s", o, reason)
+			return nil
+		}
+		t.Eventf(empire.ID, o.Line, "%s: transferred", o)
+		return nil
+	})
+}
+
+// shortfall returns the resources the empire doesn't have enough of to
+// pay the cost for the quantity, or an empty string if it can pay.
+func shortfall(empire *models.Empire, cost map[models.Resource_e]int, quantity int) string {
+	var short []string
+	for _, kind := range kinds(cost) {
+		if need := cost[kind] * quantity; empire.Stockpile[kind] < need {
+			short = append(short, fmt.Sprintf("%s (need %d, have %d)", kind, need, empire.Stockpile[kind]))
+		}
+	}
+	return strings.Join(short, ", ")
+}
+
+// kinds returns the resources in the cost, in order.
+func kinds(cost map[models.Resource_e]int) []models.Resource_e {
+	var list []models.Resource_e
+	for kind := range cost {
+		list = append(list, kind)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i] < list[j] })
+	return list
+}
+
+// mine returns the empire's mine on the planet, or nil.
+func (t *Turn) mine(empireID, planetID int) *models.Mine {
+	for _, m := range t.Game.Mines {
+		if m.EmpireID == empireID && m.OrbitID == planetID {
+			return m
+		}
+	}
+	return nil
+}
@@ -0,0 +1,354 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+// Package cluster implements a seeded generator for the systems, stars,
+// orbits, and deposits in a game's cluster.
+//
+// The generator only uses its own random number generator, so the same
+// seed and options will always produce the same cluster.
+package cluster
+
+import (
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+	"math/rand/v2"
+)
+
+// limits from the CHECK constraints in the initial migration.
+const (
+	MinCoordinate   = -15
+	MaxCoordinate   = 15
+	MaxStars        = 4
+	MaxOrbits       = 10
+	MaxHabitability = 25
+	MaxDeposits     = 35
+	MaxQuantity     = 99_000_000
+	MaxYieldPct     = 100
+)
+
+// Generator creates a cluster from a seed.
+type Generator struct {
+	seed    uint64
+	systems int
+	warps   WarpRules
+}
+
+type Option func(*Generator) error
+
+// WithSystems sets the number of systems in the cluster.
+func WithSystems(n int) Option {
+	return func(g *Generator) error {
+		// keep the cluster sparse enough that placement always terminates.
+		const side = MaxCoordinate - MinCoordinate + 1
+		if n < 1 || n > side*side*side/8 {
+			return fmt.Errorf("%d: invalid number of systems", n)
+		}
+		g.systems = n
+		return nil
+	}
+}
+
+// New returns a generator for the given seed.
+// The default cluster has 100 systems connected by the default warp rules.
+func New(seed uint64, options ...Option) (*Generator, error) {
+	g := &Generator{seed: seed, systems: 100, warps: DefaultWarpRules()}
+	for _, option := range options {
+		if err := option(g); err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+// Generate returns a new cluster. Every call starts from the seed,
+// so repeated calls return identical clusters.
+func (g *Generator) Generate() (*models.Cluster, error) {
+	rng := rand.New(rand.NewPCG(g.seed, g.seed^0x9e3779b97f4a7c15))
+
+	cluster := &models.Cluster{}
+	taken := map[[3]int]bool{}
+	for len(cluster.Systems) < g.systems {
+		x, y, z := coordinate(rng), coordinate(rng), coordinate(rng)
+		if taken[[3]int{x, y, z}] {
+			continue
+		}
+		taken[[3]int{x, y, z}] = true
+		system := &models.System{X: x, Y: y, Z: z}
+		for sequence, n := 1, starCount(rng); sequence <= n; sequence++ {
+			system.Stars = append(system.Stars, genStar(rng, sequence))
+		}
+		cluster.Systems = append(cluster.Systems, system)
+	}
+	cluster.Warps = ConnectWarps(cluster.Systems, g.warps)
+
+	if err := Validate(cluster); err != nil {
+		return nil, err
+	}
+	return cluster, nil
+}
+
+func coordinate(rng *rand.Rand) int {
+	return MinCoordinate + rng.IntN(MaxCoordinate-MinCoordinate+1)
+}
+
+// starCount returns 1 to 4 stars, with multiple star systems being rare.
+func starCount(rng *rand.Rand) int {
+	switch n := rng.IntN(100); {
+	case n < 50:
+		return 1
+	case n < 80:
+		return 2
+	case n < 95:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// classWeights are the chances of each spectral class, from O to M.
+// Cool, dim stars are far more common than hot, bright ones.
+var classWeights = []int{1, 2, 5, 10, 20, 27, 35}
+
+// sizeWeights are the chances of main sequence, giant, and supergiant stars.
+var sizeWeights = []int{85, 12, 3}
+
+// classLuminosity is the typical luminosity of a main sequence star
+// in each spectral class, as a percentage of Sol's.
+var classLuminosity = map[models.Spectral_e]int{
+	models.CLASS_O: 100_000,
+	models.CLASS_B: 10_000,
+	models.CLASS_A: 1_000,
+	models.CLASS_F: 300,
+	models.CLASS_G: 100,
+	models.CLASS_K: 30,
+	models.CLASS_M: 5,
+}
+
+// sizeLuminosity multiplies the luminosity of giant and supergiant stars.
+var sizeLuminosity = map[models.StarSize_e]int{
+	models.MAIN_SEQUENCE: 1,
+	models.GIANT:         50,
+	models.SUPERGIANT:    1_000,
+}
+
+func genStar(rng *rand.Rand, sequence int) *models.Star {
+	star := &models.Star{
+		Sequence: sequence,
+		Class:    models.Spectral_e(1 + weighted(rng, classWeights)),
+		Size:     models.StarSize_e(1 + weighted(rng, sizeWeights)),
+	}
+	// vary the luminosity by up to 25% either way so that stars
+	// of the same class and size aren't identical.
+	luminosity := classLuminosity[star.Class] * sizeLuminosity[star.Size]
+	star.Luminosity = max(1, luminosity*(75+rng.IntN(51))/100)
+	for orbit := 1; orbit <= MaxOrbits; orbit++ {
+		star.Orbits[orbit] = genPlanet(rng, star, orbit)
+	}
+	return star
+}
+
+// orbitWeights are the chances of empty, asteroid belt, gas giant,
+// and terrestrial planets for each orbit.
+var orbitWeights = [MaxOrbits + 1][4]int{
+	1:  {40, 15, 5, 40},
+	2:  {40, 15, 5, 40},
+	3:  {30, 15, 10, 45},
+	4:  {30, 15, 10, 45},
+	5:  {30, 15, 10, 45},
+	6:  {35, 20, 30, 15},
+	7:  {35, 20, 30, 15},
+	8:  {45, 20, 30, 5},
+	9:  {45, 20, 30, 5},
+	10: {45, 20, 30, 5},
+}
+
+// gasGiantFactor multiplies the chance of a gas giant around larger stars.
+var gasGiantFactor = map[models.StarSize_e]int{
+	models.MAIN_SEQUENCE: 1,
+	models.GIANT:         2,
+	models.SUPERGIANT:    3,
+}
+
+// genPlanet returns the planet in the orbit, or nil if the orbit is empty.
+// Only terrestrial planets in the star's habitable zone can be rated above 5.
+func genPlanet(rng *rand.Rand, star *models.Star, orbit int) *models.Planet {
+	weights := orbitWeights[orbit]
+	weights[2] *= gasGiantFactor[star.Size]
+
+	var kind models.Planet_e
+	switch weighted(rng, weights[:]) {
+	case 0:
+		return nil
+	case 1:
+		kind = models.ASTEROID_BELT
+	case 2:
+		kind = models.GAS_GIANT
+	case 3:
+		kind = models.TERRESTRIAL
+	}
+
+	planet := &models.Planet{Kind: kind}
+	if kind == models.TERRESTRIAL {
+		if star.IsHabitableOrbit(orbit) {
+			planet.Habitability = rng.IntN(MaxHabitability + 1)
+		} else {
+			planet.Habitability = rng.IntN(6)
+		}
+	}
+
+	var deposits int
+	switch kind {
+	case models.ASTEROID_BELT:
+		deposits = 1 + rng.IntN(MaxDeposits)
+	case models.GAS_GIANT:
+		deposits = 1 + rng.IntN(15)
+	case models.TERRESTRIAL:
+		deposits = 1 + rng.IntN(MaxDeposits)
+	}
+	for n := 1; n <= deposits; n++ {
+		planet.NaturalResources[n] = genDeposit(rng, kind)
+	}
+
+	return planet
+}
+
+// depositWeights are the chances of fuel, gold, metallic, and non-metallic
+// deposits for each kind of planet.
+var depositWeights = map[models.Planet_e][]int{
+	models.ASTEROID_BELT: {10, 5, 50, 35},
+	models.GAS_GIANT:     {80, 0, 10, 10},
+	models.TERRESTRIAL:   {20, 5, 40, 35},
+}
+
+func genDeposit(rng *rand.Rand, kind models.Planet_e) *models.Deposit {
+	deposit := &models.Deposit{}
+	switch weighted(rng, depositWeights[kind]) {
+	case 0:
+		deposit.Kind = models.FUEL
+	case 1:
+		deposit.Kind = models.GOLD
+	case 2:
+		deposit.Kind = models.METALLICS
+	case 3:
+		deposit.Kind = models.NONMETALLICS
+	}
+	if deposit.Kind == models.GOLD {
+		deposit.Quantity = 100_000 + rng.IntN(4_900_001)
+		deposit.YieldPct = 1 + rng.IntN(10)
+	} else {
+		deposit.Quantity = 1_000_000 + rng.IntN(MaxQuantity-1_000_000+1)
+		deposit.YieldPct = 10 + rng.IntN(81)
+	}
+	return deposit
+}
+
+// weighted returns the index of the weight that was selected.
+func weighted(rng *rand.Rand, weights []int) int {
+	total := 0
+	for _, w := range weights {
+		total += w
+	}
+	n := rng.IntN(total)
+	for i, w := range weights {
+		if n < w {
+			return i
+		}
+		n -= w
+	}
+	panic("assert(weights are not negative)")
+}
+
+// Validate returns an error if the cluster would violate any of the
+// constraints on the systems, stars, orbits, or natural_resources tables.
+func Validate(cluster *models.Cluster) error {
+	if cluster == nil {
+		return fmt.Errorf("missing cluster")
+	}
+	taken := map[[3]int]bool{}
+	for _, system := range cluster.Systems {
+		if !inRange(system.X, MinCoordinate, MaxCoordinate) || !inRange(system.Y, MinCoordinate, MaxCoordinate) || !inRange(system.Z, MinCoordinate, MaxCoordinate) {
+			return fmt.Errorf("system %d %d %d: coordinates out of range", system.X, system.Y, system.Z)
+		} else if taken[[3]int{system.X, system.Y, system.Z}] {
+			return fmt.Errorf("system %d %d %d: duplicate coordinates", system.X, system.Y, system.Z)
+		}
+		taken[[3]int{system.X, system.Y, system.Z}] = true
+		if len(system.Stars) < 1 || len(system.Stars) > MaxStars {
+			return fmt.Errorf("system %d %d %d: %d stars", system.X, system.Y, system.Z, len(system.Stars))
+		}
+		for n, star := range system.Stars {
+			if star.Sequence != n+1 {
+				return fmt.Errorf("system %d %d %d: star %d: invalid sequence %d", system.X, system.Y, system.Z, n+1, star.Sequence)
+			} else if err := validateStar(star); err != nil {
+				return fmt.Errorf("system %d %d %d: star %d: %w", system.X, system.Y, system.Z, star.Sequence, err)
+			} else if star.Orbits[0] != nil {
+				return fmt.Errorf("system %d %d %d: star %d: orbit 0 is not empty", system.X, system.Y, system.Z, star.Sequence)
+			}
+			for orbit, planet := range star.Orbits {
+				if planet == nil {
+					continue
+				} else if err := validatePlanet(planet); err != nil {
+					return fmt.Errorf("system %d %d %d: star %d: orbit %d: %w", system.X, system.Y, system.Z, star.Sequence, orbit, err)
+				}
+			}
+		}
+	}
+	for _, warp := range cluster.Warps {
+		if warp.From == nil || warp.To == nil {
+			return fmt.Errorf("warp: missing system")
+		} else if warp.From == warp.To {
+			return fmt.Errorf("warp %d %d %d: connects system to itself", warp.From.X, warp.From.Y, warp.From.Z)
+		} else if !taken[[3]int{warp.From.X, warp.From.Y, warp.From.Z}] || !taken[[3]int{warp.To.X, warp.To.Y, warp.To.Z}] {
+			return fmt.Errorf("warp %d %d %d: system not in cluster", warp.From.X, warp.From.Y, warp.From.Z)
+		}
+	}
+	return nil
+}
+
+func validateStar(star *models.Star) error {
+	switch star.Class {
+	case models.CLASS_O, models.CLASS_B, models.CLASS_A, models.CLASS_F, models.CLASS_G, models.CLASS_K, models.CLASS_M:
+	default:
+		return fmt.Errorf("invalid class %d", star.Class)
+	}
+	switch star.Size {
+	case models.MAIN_SEQUENCE, models.GIANT, models.SUPERGIANT:
+	default:
+		return fmt.Errorf("invalid size %d", star.Size)
+	}
+	if star.Luminosity < 1 {
+		return fmt.Errorf("invalid luminosity %d", star.Luminosity)
+	}
+	return nil
+}
+
+func validatePlanet(planet *models.Planet) error {
+	switch planet.Kind {
+	case models.ASTEROID_BELT, models.GAS_GIANT, models.TERRESTRIAL:
+	default:
+		return fmt.Errorf("invalid kind %d", planet.Kind)
+	}
+	if !inRange(planet.Habitability, 0, MaxHabitability) {
+		return fmt.Errorf("invalid habitability %d", planet.Habitability)
+	} else if planet.NaturalResources[0] != nil {
+		return fmt.Errorf("deposit 0 is not empty")
+	}
+	for n, deposit := range planet.NaturalResources {
+		if deposit == nil {
+			continue
+		}
+		switch deposit.Kind {
+		case models.FUEL, models.GOLD, models.METALLICS, models.NONMETALLICS:
+		default:
+			return fmt.Errorf("deposit %d: invalid kind %d", n, deposit.Kind)
+		}
+		if !inRange(deposit.Quantity, 0, MaxQuantity) {
+			return fmt.Errorf("deposit %d: invalid quantity %d", n, deposit.Quantity)
+		} else if !inRange(deposit.YieldPct, 0, MaxYieldPct) {
+			return fmt.Errorf("deposit %d: invalid yield %d", n, deposit.YieldPct)
+		}
+	}
+	return nil
+}
+
+func inRange(n, lo, hi int) bool {
+	return lo <= n && n <= hi
+}
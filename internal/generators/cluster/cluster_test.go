@@ -0,0 +1,164 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package cluster
+
+import (
+	"github.com/mdhender/moid/internal/models"
+	"reflect"
+	"testing"
+)
+
+func generate(t *testing.T, seed uint64, options ...Option) *models.Cluster {
+	t.Helper()
+	g, err := New(seed, options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cluster, err := g.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cluster
+}
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	for _, seed := range []uint64{0, 1, 42, 1978, 0xdeadbeef} {
+		first, second := generate(t, seed), generate(t, seed)
+		if !reflect.DeepEqual(first, second) {
+			t.Errorf("seed %d: same seed gave different clusters", seed)
+		}
+
+		// calling Generate again on the same generator must also agree
+		g, err := New(seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := g.Generate(); err != nil {
+			t.Fatal(err)
+		}
+		again, err := g.Generate()
+		if err != nil {
+			t.Fatal(err)
+		} else if !reflect.DeepEqual(first, again) {
+			t.Errorf("seed %d: second call to Generate gave a different cluster", seed)
+		}
+	}
+
+	if reflect.DeepEqual(generate(t, 1), generate(t, 2)) {
+		t.Errorf("seeds 1 and 2 gave the same cluster")
+	}
+}
+
+// TestGenerateLimits checks the generated values against the CHECK
+// constraints on the systems, stars, orbits, and natural_resources
+// tables. The limits are written out here rather than taken from the
+// package constants so that a change to either side is caught.
+func TestGenerateLimits(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		seed    uint64
+		systems int
+	}{
+		{"default", 1, 100},
+		{"another seed", 1978, 100},
+		{"small", 7, 1},
+		{"large", 11, 1000},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := generate(t, tc.seed, WithSystems(tc.systems))
+			if len(cluster.Systems) != tc.systems {
+				t.Fatalf("got %d systems, want %d", len(cluster.Systems), tc.systems)
+			}
+			taken := map[[3]int]bool{}
+			for _, system := range cluster.Systems {
+				xyz := [3]int{system.X, system.Y, system.Z}
+				for _, c := range xyz {
+					if c < -15 || c > 15 {
+						t.Fatalf("system %v: coordinate %d out of range", xyz, c)
+					}
+				}
+				if taken[xyz] {
+					t.Fatalf("system %v: duplicate coordinates", xyz)
+				}
+				taken[xyz] = true
+				if n := len(system.Stars); n < 1 || n > 4 {
+					t.Fatalf("system %v: %d stars", xyz, n)
+				}
+				for i, star := range system.Stars {
+					if star.Sequence != i+1 {
+						t.Fatalf("system %v: star %d: sequence %d", xyz, i+1, star.Sequence)
+					} else if star.Class < models.CLASS_O || star.Class > models.CLASS_M {
+						t.Fatalf("system %v: star %d: class %d", xyz, star.Sequence, star.Class)
+					} else if star.Size < models.MAIN_SEQUENCE || star.Size > models.SUPERGIANT {
+						t.Fatalf("system %v: star %d: size %d", xyz, star.Sequence, star.Size)
+					} else if star.Luminosity < 1 {
+						t.Fatalf("system %v: star %d: luminosity %d", xyz, star.Sequence, star.Luminosity)
+					}
+					for orbit, planet := range star.Orbits {
+						if planet == nil {
+							continue
+						} else if orbit < 1 || orbit > 10 {
+							t.Fatalf("system %v: star %d: planet in orbit %d", xyz, star.Sequence, orbit)
+						}
+						checkPlanet(t, planet, xyz, star.Sequence, orbit)
+					}
+				}
+			}
+		})
+	}
+}
+
+func checkPlanet(t *testing.T, planet *models.Planet, xyz [3]int, star, orbit int) {
+	t.Helper()
+	switch planet.Kind {
+	case models.ASTEROID_BELT, models.GAS_GIANT:
+		if planet.Habitability != 0 {
+			t.Fatalf("system %v: star %d: orbit %d: %s with habitability %d", xyz, star, orbit, planet.Kind, planet.Habitability)
+		}
+	case models.TERRESTRIAL:
+		if planet.Habitability < 0 || planet.Habitability > 25 {
+			t.Fatalf("system %v: star %d: orbit %d: habitability %d", xyz, star, orbit, planet.Habitability)
+		}
+	default:
+		t.Fatalf("system %v: star %d: orbit %d: kind %d", xyz, star, orbit, planet.Kind)
+	}
+	deposits := 0
+	for n, deposit := range planet.NaturalResources {
+		if deposit == nil {
+			continue
+		}
+		deposits++
+		if n != deposits {
+			t.Fatalf("system %v: star %d: orbit %d: deposit %d out of sequence", xyz, star, orbit, n)
+		} else if n < 1 || n > 35 {
+			t.Fatalf("system %v: star %d: orbit %d: deposit number %d", xyz, star, orbit, n)
+		} else if deposit.Kind < models.FUEL || deposit.Kind > models.NONMETALLICS {
+			t.Fatalf("system %v: star %d: orbit %d: deposit %d: kind %d", xyz, star, orbit, n, deposit.Kind)
+		} else if deposit.Quantity < 0 || deposit.Quantity > 99_000_000 {
+			t.Fatalf("system %v: star %d: orbit %d: deposit %d: quantity %d", xyz, star, orbit, n, deposit.Quantity)
+		} else if deposit.YieldPct < 0 || deposit.YieldPct > 100 {
+			t.Fatalf("system %v: star %d: orbit %d: deposit %d: yield %d", xyz, star, orbit, n, deposit.YieldPct)
+		}
+	}
+	if deposits == 0 {
+		t.Fatalf("system %v: star %d: orbit %d: no deposits", xyz, star, orbit)
+	}
+}
+
+func TestWithSystems(t *testing.T) {
+	for _, tc := range []struct {
+		systems int
+		ok      bool
+	}{
+		{-1, false},
+		{0, false},
+		{1, true},
+		{3723, true},
+		{3724, false},
+	} {
+		_, err := New(1, WithSystems(tc.systems))
+		if ok := err == nil; ok != tc.ok {
+			t.Errorf("WithSystems(%d): got error %v, want ok %v", tc.systems, err, tc.ok)
+		}
+	}
+}
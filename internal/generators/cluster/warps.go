@@ -0,0 +1,107 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package cluster
+
+import (
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+	"sort"
+)
+
+// WarpRules control how systems are connected by warp lines.
+type WarpRules struct {
+	// MaxDistance is the longest warp line that may be added, measured
+	// as the straight line distance between the systems.
+	MaxDistance float64
+	// MaxWarps is the most warp lines that may be added to a system.
+	// Warps added to connect the cluster ignore this limit.
+	MaxWarps int
+	// Connected, if set, adds the shortest warp lines needed to let
+	// every system reach every other system. These warps ignore
+	// MaxDistance and MaxWarps.
+	Connected bool
+}
+
+// DefaultWarpRules returns the rules used when generating a cluster.
+func DefaultWarpRules() WarpRules {
+	return WarpRules{MaxDistance: 5, MaxWarps: 4, Connected: true}
+}
+
+// WithWarpRules sets the rules used to connect the systems in the cluster.
+func WithWarpRules(rules WarpRules) Option {
+	return func(g *Generator) error {
+		if rules.MaxDistance < 0 {
+			return fmt.Errorf("%g: invalid warp distance", rules.MaxDistance)
+		} else if rules.MaxWarps < 0 {
+			return fmt.Errorf("%d: invalid number of warps", rules.MaxWarps)
+		}
+		g.warps = rules
+		return nil
+	}
+}
+
+// ConnectWarps returns warp lines between the systems in the cluster.
+// It doesn't use any randomness; the same systems in the same order
+// will always produce the same warps.
+//
+// Candidate warps are considered from shortest to longest, with ties
+// broken by the order of the systems in the cluster.
+func ConnectWarps(systems []*models.System, rules WarpRules) []*models.Warp {
+	type edge struct {
+		from, to int // indexes into systems
+		distance float64
+	}
+	var edges []edge
+	for i, from := range systems {
+		for j := i + 1; j < len(systems); j++ {
+			to := systems[j]
+			edges = append(edges, edge{from: i, to: j, distance: from.EuclideanDistance(to.X, to.Y, to.Z)})
+		}
+	}
+	sort.SliceStable(edges, func(i, j int) bool {
+		return edges[i].distance < edges[j].distance
+	})
+
+	added := map[[2]int]bool{}
+	count := make([]int, len(systems))
+	var warps []*models.Warp
+	add := func(e edge) {
+		added[[2]int{e.from, e.to}] = true
+		count[e.from], count[e.to] = count[e.from]+1, count[e.to]+1
+		warps = append(warps, &models.Warp{From: systems[e.from], To: systems[e.to]})
+	}
+
+	if rules.Connected {
+		// Kruskal's algorithm gives the shortest set of connecting warps.
+		parent := make([]int, len(systems))
+		for i := range parent {
+			parent[i] = i
+		}
+		var find func(int) int
+		find = func(i int) int {
+			if parent[i] != i {
+				parent[i] = find(parent[i])
+			}
+			return parent[i]
+		}
+		for _, e := range edges {
+			if a, b := find(e.from), find(e.to); a != b {
+				parent[a] = b
+				add(e)
+			}
+		}
+	}
+
+	for _, e := range edges {
+		if e.distance > rules.MaxDistance {
+			break
+		} else if added[[2]int{e.from, e.to}] {
+			continue
+		} else if count[e.from] >= rules.MaxWarps || count[e.to] >= rules.MaxWarps {
+			continue
+		}
+		add(e)
+	}
+
+	return warps
+}
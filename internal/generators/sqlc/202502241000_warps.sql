@@ -0,0 +1,20 @@
+--  Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+-- foreign keys must be enabled with every database connection
+PRAGMA foreign_keys = ON;
+
+-- update the migrations table
+INSERT INTO meta_migrations (version, comment, script)
+VALUES (202502241000, 'warp lines', '202502241000_warps.sql');
+
+-- warps are two-way connections between systems in the same game.
+-- each connection is stored once, from the lower to the higher system id.
+CREATE TABLE warps
+(
+    id             INTEGER PRIMARY KEY AUTOINCREMENT,
+    game_id        INTEGER NOT NULL REFERENCES games (id),
+    from_system_id INTEGER NOT NULL REFERENCES systems (id),
+    to_system_id   INTEGER NOT NULL REFERENCES systems (id),
+    CHECK (from_system_id < to_system_id),
+    UNIQUE (from_system_id, to_system_id)
+);
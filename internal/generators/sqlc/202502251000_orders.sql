@@ -0,0 +1,28 @@
+--  Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+-- foreign keys must be enabled with every database connection
+PRAGMA foreign_keys = ON;
+
+-- update the migrations table
+INSERT INTO meta_migrations (version, comment, script)
+VALUES (202502251000, 'order submission', '202502251000_orders.sql');
+
+-- orders for the current turn are locked once the deadline passes.
+-- a null deadline means that orders are never locked.
+ALTER TABLE games
+    ADD COLUMN turn_deadline DATETIME;
+
+-- every submission is kept as a new revision, even if it had errors.
+-- the turn engine uses the latest accepted revision for each empire.
+CREATE TABLE orders
+(
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    empire_id   INTEGER  NOT NULL REFERENCES empires (id),
+    turn        INTEGER  NOT NULL CHECK (turn >= 0),
+    revision    INTEGER  NOT NULL CHECK (revision >= 1),
+    text        TEXT     NOT NULL,
+    accepted    INTEGER  NOT NULL CHECK (accepted IN (0, 1)),
+    diagnostics TEXT     NOT NULL,
+    created_at  DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (empire_id, turn, revision)
+);
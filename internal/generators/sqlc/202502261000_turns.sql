@@ -0,0 +1,24 @@
+--  Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+-- foreign keys must be enabled with every database connection
+PRAGMA foreign_keys = ON;
+
+-- update the migrations table
+INSERT INTO meta_migrations (version, comment, script)
+VALUES (202502261000, 'turn processing', '202502261000_turns.sql');
+
+-- the seed for the turn engine. it is stored as a signed integer,
+-- so seeds above 2^63 are negative in the database.
+ALTER TABLE games
+    ADD COLUMN seed INTEGER NOT NULL DEFAULT 0;
+
+-- one row for every turn that has been processed.
+-- the unique constraint keeps a turn from being processed twice.
+CREATE TABLE turns
+(
+    id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    game_id      INTEGER  NOT NULL REFERENCES games (id),
+    turn         INTEGER  NOT NULL CHECK (turn >= 0),
+    processed_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (game_id, turn)
+);
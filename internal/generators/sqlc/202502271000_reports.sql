@@ -0,0 +1,21 @@
+--  Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+-- foreign keys must be enabled with every database connection
+PRAGMA foreign_keys = ON;
+
+-- update the migrations table
+INSERT INTO meta_migrations (version, comment, script)
+VALUES (202502271000, 'turn reports', '202502271000_reports.sql');
+
+-- each empire gets one report per turn, stored as plain text and as JSON.
+-- regenerating a report replaces it.
+CREATE TABLE reports
+(
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    empire_id   INTEGER  NOT NULL REFERENCES empires (id),
+    turn        INTEGER  NOT NULL CHECK (turn >= 0),
+    text_report TEXT     NOT NULL,
+    json_report TEXT     NOT NULL,
+    created_at  DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (empire_id, turn)
+);
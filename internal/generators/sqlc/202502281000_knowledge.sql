@@ -0,0 +1,45 @@
+--  Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+-- foreign keys must be enabled with every database connection
+PRAGMA foreign_keys = ON;
+
+-- update the migrations table
+INSERT INTO meta_migrations (version, comment, script)
+VALUES (202502281000, 'fog of war', '202502281000_knowledge.sql');
+
+-- what each empire has observed. the columns hold the values as they were
+-- when last observed, which may not match the current values. the turn is
+-- the last turn the empire observed the system, orbit, or deposit.
+
+CREATE TABLE seen_systems
+(
+    id        INTEGER PRIMARY KEY AUTOINCREMENT,
+    empire_id INTEGER NOT NULL REFERENCES empires (id),
+    system_id INTEGER NOT NULL REFERENCES systems (id),
+    stars     INTEGER NOT NULL CHECK (stars BETWEEN 1 AND 4),
+    turn      INTEGER NOT NULL CHECK (turn >= 0),
+    UNIQUE (empire_id, system_id)
+);
+
+CREATE TABLE seen_orbits
+(
+    id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    empire_id    INTEGER NOT NULL REFERENCES empires (id),
+    orbit_id     INTEGER NOT NULL REFERENCES orbits (id),
+    kind         TEXT    NOT NULL CHECK (kind IN ('asteroid', 'empty', 'gas-giant', 'terrestrial')),
+    habitability INTEGER NOT NULL CHECK (habitability BETWEEN 0 AND 25),
+    turn         INTEGER NOT NULL CHECK (turn >= 0),
+    UNIQUE (empire_id, orbit_id)
+);
+
+CREATE TABLE seen_deposits
+(
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    empire_id  INTEGER NOT NULL REFERENCES empires (id),
+    deposit_id INTEGER NOT NULL REFERENCES natural_resources (id),
+    kind       TEXT    NOT NULL CHECK (kind IN ('fuel', 'gold', 'metallic', 'non-metallic')),
+    quantity   INTEGER NOT NULL CHECK (quantity BETWEEN 0 AND 99000000),
+    yield_pct  INTEGER NOT NULL CHECK (yield_pct BETWEEN 0 AND 100),
+    turn       INTEGER NOT NULL CHECK (turn >= 0),
+    UNIQUE (empire_id, deposit_id)
+);
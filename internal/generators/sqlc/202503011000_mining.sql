@@ -0,0 +1,54 @@
+--  Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+-- foreign keys must be enabled with every database connection
+PRAGMA foreign_keys = ON;
+
+-- update the migrations table
+INSERT INTO meta_migrations (version, comment, script)
+VALUES (202503011000, 'mining and refining', '202503011000_mining.sql');
+
+-- mines are the mining units an empire has on a planet.
+CREATE TABLE mines
+(
+    id        INTEGER PRIMARY KEY AUTOINCREMENT,
+    empire_id INTEGER NOT NULL REFERENCES empires (id),
+    orbit_id  INTEGER NOT NULL REFERENCES orbits (id),
+    units     INTEGER NOT NULL CHECK (units >= 0),
+    UNIQUE (empire_id, orbit_id)
+);
+
+-- mine_assignments are the units of a mine working each deposit.
+CREATE TABLE mine_assignments
+(
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    mine_id    INTEGER NOT NULL REFERENCES mines (id),
+    deposit_id INTEGER NOT NULL REFERENCES natural_resources (id),
+    units      INTEGER NOT NULL CHECK (units >= 1),
+    UNIQUE (mine_id, deposit_id)
+);
+
+-- stockpiles are the refined resources an empire has on hand.
+CREATE TABLE stockpiles
+(
+    id        INTEGER PRIMARY KEY AUTOINCREMENT,
+    empire_id INTEGER NOT NULL REFERENCES empires (id),
+    kind      TEXT    NOT NULL CHECK (kind IN ('fuel', 'gold', 'metallic', 'non-metallic')),
+    quantity  INTEGER NOT NULL CHECK (quantity >= 0),
+    UNIQUE (empire_id, kind)
+);
+
+-- extractions is the audit trail for mining. there is one row for every
+-- deposit worked on every turn.
+CREATE TABLE extractions
+(
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    empire_id  INTEGER  NOT NULL REFERENCES empires (id),
+    mine_id    INTEGER  NOT NULL REFERENCES mines (id),
+    deposit_id INTEGER  NOT NULL REFERENCES natural_resources (id),
+    turn       INTEGER  NOT NULL CHECK (turn >= 0),
+    units      INTEGER  NOT NULL CHECK (units >= 1),
+    mined      INTEGER  NOT NULL CHECK (mined >= 0),
+    refined    INTEGER  NOT NULL CHECK (refined >= 0),
+    remaining  INTEGER  NOT NULL CHECK (remaining >= 0),
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
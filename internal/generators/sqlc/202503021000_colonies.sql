@@ -0,0 +1,23 @@
+--  Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+-- foreign keys must be enabled with every database connection
+PRAGMA foreign_keys = ON;
+
+-- update the migrations table
+INSERT INTO meta_migrations (version, comment, script)
+VALUES (202503021000, 'colonies', '202503021000_colonies.sql');
+
+-- colonies are the settlements of an empire on a planet. open colonies
+-- live on the surface and are limited by the planet's habitability.
+-- enclosed colonies are limited by the number of enclosures.
+CREATE TABLE colonies
+(
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    empire_id  INTEGER NOT NULL REFERENCES empires (id),
+    orbit_id   INTEGER NOT NULL REFERENCES orbits (id),
+    kind       TEXT    NOT NULL CHECK (kind IN ('enclosed', 'open')),
+    population INTEGER NOT NULL CHECK (population >= 0),
+    enclosures INTEGER NOT NULL CHECK (enclosures >= 0),
+    founded    INTEGER NOT NULL CHECK (founded >= 0),
+    UNIQUE (empire_id, orbit_id, kind)
+);
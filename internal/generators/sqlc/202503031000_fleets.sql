@@ -0,0 +1,43 @@
+--  Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+-- foreign keys must be enabled with every database connection
+PRAGMA foreign_keys = ON;
+
+-- update the migrations table
+INSERT INTO meta_migrations (version, comment, script)
+VALUES (202503031000, 'fleets', '202503031000_fleets.sql');
+
+-- fleets are groups of ships that move together. a fleet is either in
+-- a system (and possibly orbiting a planet) or in transit, in which case
+-- system_id is where it left from and destination_id is where it is going.
+CREATE TABLE fleets
+(
+    id             INTEGER PRIMARY KEY AUTOINCREMENT,
+    empire_id      INTEGER NOT NULL REFERENCES empires (id),
+    system_id      INTEGER NOT NULL REFERENCES systems (id),
+    orbit_id       INTEGER REFERENCES orbits (id),
+    destination_id INTEGER REFERENCES systems (id),
+    arrives        INTEGER,
+    fuel           INTEGER NOT NULL CHECK (fuel >= 0),
+    CHECK ((destination_id IS NULL) = (arrives IS NULL)),
+    CHECK (destination_id IS NULL OR orbit_id IS NULL)
+);
+
+-- fleet_ships is the number of ships of each class in a fleet.
+CREATE TABLE fleet_ships
+(
+    fleet_id INTEGER NOT NULL REFERENCES fleets (id) ON DELETE CASCADE,
+    class    TEXT    NOT NULL CHECK (class IN ('scout', 'transport', 'warship')),
+    quantity INTEGER NOT NULL CHECK (quantity >= 1),
+    UNIQUE (fleet_id, class)
+);
+
+-- fleet_cargo is the cargo carried by a fleet. fuel is not cargo;
+-- it is in the fleet's tanks.
+CREATE TABLE fleet_cargo
+(
+    fleet_id INTEGER NOT NULL REFERENCES fleets (id) ON DELETE CASCADE,
+    kind     TEXT    NOT NULL CHECK (kind IN ('colonists', 'gold', 'metallics', 'non-metallics')),
+    quantity INTEGER NOT NULL CHECK (quantity >= 1),
+    UNIQUE (fleet_id, kind)
+);
@@ -0,0 +1,21 @@
+--  Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+-- foreign keys must be enabled with every database connection
+PRAGMA foreign_keys = ON;
+
+-- update the migrations table
+INSERT INTO meta_migrations (version, comment, script)
+VALUES (202503041000, 'turn snapshots', '202503041000_snapshots.sql');
+
+-- snapshots hold the state of a game at the start of a turn so that the
+-- GM can roll the game back. the state is gzipped JSON; it is only read
+-- by the store, so the format can change as long as old snapshots load.
+CREATE TABLE snapshots
+(
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    game_id    INTEGER  NOT NULL REFERENCES games (id),
+    turn       INTEGER  NOT NULL CHECK (turn >= 0),
+    state      BLOB     NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (game_id, turn)
+);
@@ -0,0 +1,28 @@
+--  Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+-- foreign keys must be enabled with every database connection
+PRAGMA foreign_keys = ON;
+
+-- update the migrations table
+INSERT INTO meta_migrations (version, comment, script)
+VALUES (202503051000, 'turn scheduler', '202503051000_schedules.sql');
+
+-- schedules are when the server runs a game's turns, such as
+-- "mon,thu 18:00 America/Chicago". games without a schedule are run by hand.
+CREATE TABLE schedules
+(
+    game_id  INTEGER NOT NULL PRIMARY KEY REFERENCES games (id),
+    schedule TEXT    NOT NULL
+);
+
+-- turn_locks are claimed by a server before it runs a turn so that two
+-- servers sharing the database never process the same turn. a claim
+-- expires so that a turn can be retried if the server dies while running it.
+CREATE TABLE turn_locks
+(
+    game_id    INTEGER  NOT NULL REFERENCES games (id),
+    turn       INTEGER  NOT NULL CHECK (turn >= 0),
+    owner      TEXT     NOT NULL,
+    expires_at DATETIME NOT NULL,
+    UNIQUE (game_id, turn)
+);
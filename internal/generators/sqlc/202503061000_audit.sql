@@ -0,0 +1,21 @@
+--  Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+-- foreign keys must be enabled with every database connection
+PRAGMA foreign_keys = ON;
+
+-- update the migrations table
+INSERT INTO meta_migrations (version, comment, script)
+VALUES (202503061000, 'game master audit log', '202503061000_audit.sql');
+
+-- audit_log records every manual edit the GM makes to a game: who made
+-- it, what was changed (as "old -> new" text), and why.
+CREATE TABLE audit_log
+(
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    game_id    INTEGER  NOT NULL REFERENCES games (id),
+    editor     TEXT     NOT NULL CHECK (editor != ''),
+    target     TEXT     NOT NULL,
+    change     TEXT     NOT NULL,
+    reason     TEXT     NOT NULL CHECK (reason != ''),
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
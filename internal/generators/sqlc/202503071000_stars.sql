@@ -0,0 +1,18 @@
+--  Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+-- foreign keys must be enabled with every database connection
+PRAGMA foreign_keys = ON;
+
+-- update the migrations table
+INSERT INTO meta_migrations (version, comment, script)
+VALUES (202503071000, 'stellar classification', '202503071000_stars.sql');
+
+-- class is the spectral class of the star, size is the luminosity class,
+-- and luminosity is the brightness as a percentage of Sol's. Stars created
+-- before classification are treated as yellow main sequence stars like Sol.
+ALTER TABLE stars
+    ADD COLUMN class TEXT NOT NULL DEFAULT 'G' CHECK (class IN ('O', 'B', 'A', 'F', 'G', 'K', 'M'));
+ALTER TABLE stars
+    ADD COLUMN size TEXT NOT NULL DEFAULT 'main-sequence' CHECK (size IN ('main-sequence', 'giant', 'supergiant'));
+ALTER TABLE stars
+    ADD COLUMN luminosity INTEGER NOT NULL DEFAULT 100 CHECK (luminosity >= 1);
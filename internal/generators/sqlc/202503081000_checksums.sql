@@ -0,0 +1,14 @@
+--  Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+-- foreign keys must be enabled with every database connection
+PRAGMA foreign_keys = ON;
+
+-- update the migrations table
+INSERT INTO meta_migrations (version, comment, script)
+VALUES (202503081000, 'migration checksums', '202503081000_checksums.sql');
+
+-- checksum is the SHA-256 of the script when it was applied. The migration
+-- runner refuses to run if an applied script has since been changed.
+-- Scripts applied before this column existed are recorded by the runner.
+ALTER TABLE meta_migrations
+    ADD COLUMN checksum TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,23 @@
+--  Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+-- foreign keys must be enabled with every database connection
+PRAGMA foreign_keys = ON;
+
+-- update the migrations table
+INSERT INTO meta_migrations (version, comment, script)
+VALUES (202503091000, 'blog articles', '202503091000_articles.sql');
+
+-- articles are the posts on the blog. drafts have no publication date;
+-- it is set when the article is published and kept if it is unpublished
+-- so that republishing doesn't move the post.
+CREATE TABLE articles
+(
+    id             INTEGER PRIMARY KEY AUTOINCREMENT,
+    title          TEXT     NOT NULL CHECK (title != ''),
+    slug           TEXT     NOT NULL UNIQUE CHECK (slug != ''),
+    published      INTEGER  NOT NULL DEFAULT 0 CHECK (published IN (0, 1)),
+    date_published DATETIME,
+    date_updated   DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX articles_published ON articles (published, date_published);
@@ -0,0 +1,23 @@
+--  Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+-- foreign keys must be enabled with every database connection
+PRAGMA foreign_keys = ON;
+
+-- update the migrations table
+INSERT INTO meta_migrations (version, comment, script)
+VALUES (202503101000, 'battle logs', '202503101000_battles.sql');
+
+-- battles are the logs of the battles fought on each turn. every empire
+-- in a battle gets its own copy so that reports only read their own rows.
+-- the log is the battle log from the combat package, one line per entry.
+CREATE TABLE battles
+(
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    empire_id  INTEGER  NOT NULL REFERENCES empires (id),
+    system_id  INTEGER  NOT NULL REFERENCES systems (id),
+    turn       INTEGER  NOT NULL CHECK (turn >= 0),
+    log        TEXT     NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX battles_empire_turn ON battles (empire_id, turn);
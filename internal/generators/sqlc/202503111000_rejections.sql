@@ -0,0 +1,22 @@
+--  Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+-- foreign keys must be enabled with every database connection
+PRAGMA foreign_keys = ON;
+
+-- update the migrations table
+INSERT INTO meta_migrations (version, comment, script)
+VALUES (202503111000, 'rejected orders', '202503111000_rejections.sql');
+
+-- rejections are the orders the turn engine ignored on each turn, with
+-- the line of the order in the empire's orders and the reason.
+CREATE TABLE rejections
+(
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    empire_id  INTEGER  NOT NULL REFERENCES empires (id),
+    turn       INTEGER  NOT NULL CHECK (turn >= 0),
+    line       INTEGER  NOT NULL CHECK (line >= 0),
+    reason     TEXT     NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX rejections_empire_turn ON rejections (empire_id, turn);
@@ -0,0 +1,27 @@
+--  Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+-- foreign keys must be enabled with every database connection
+PRAGMA foreign_keys = ON;
+
+-- update the migrations table
+INSERT INTO meta_migrations (version, comment, script)
+VALUES (202503121000, 'player logins and sessions', '202503121000_sessions.sql');
+
+-- players log in with their name and an access key issued from the
+-- command line. only the SHA-256 hash of the key is kept; an empty
+-- hash means the player can't log in. the GM can use the admin pages.
+ALTER TABLE players
+    ADD COLUMN key_hash TEXT NOT NULL DEFAULT '';
+ALTER TABLE players
+    ADD COLUMN is_gm INTEGER NOT NULL DEFAULT 0 CHECK (is_gm IN (0, 1));
+
+-- sessions are the logins of players. the token is sent in a cookie
+-- and only its SHA-256 hash is kept.
+CREATE TABLE sessions
+(
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    player_id  INTEGER  NOT NULL REFERENCES players (id),
+    token_hash TEXT     NOT NULL UNIQUE,
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
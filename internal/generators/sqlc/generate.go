@@ -2,7 +2,7 @@
 
 // Package sqlc contains the SQLC generators, schemas, and DDL.
 //
-// WARNING: the generated files are written to the db package.
+// WARNING: the generated files are written to the sqlite package.
 package sqlc
 
 //go:generate sqlc generate --file sqlc.yaml
@@ -0,0 +1,12 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package sqlc
+
+import "embed"
+
+// Migrations holds the DDL scripts that build the database. Scripts are
+// named by version, like "202502110915_initial.sql", and must be applied
+// in that order.
+//
+//go:embed 20*.sql
+var Migrations embed.FS
@@ -8,6 +8,69 @@ INSERT INTO players (name)
 VALUES (:name)
 RETURNING id;
 
+-- GetPlayerByName returns the id of the player with the name.
+--
+-- name: GetPlayerByName :one
+SELECT id
+FROM players
+WHERE name = :name;
+
+-- GetPlayerLogin returns what is needed to log a player in.
+--
+-- name: GetPlayerLogin :one
+SELECT id, name, key_hash, is_gm
+FROM players
+WHERE name = :name;
+
+-- UpdatePlayerKey sets the hash of a player's access key and whether
+-- the player is the GM.
+--
+-- name: UpdatePlayerKey :exec
+UPDATE players
+SET key_hash = :key_hash,
+    is_gm    = :is_gm
+WHERE id = :player_id;
+
+-- CreateSession creates a session for a player.
+--
+-- name: CreateSession :exec
+INSERT INTO sessions (player_id, token_hash, expires_at)
+VALUES (:player_id, :token_hash, :expires_at);
+
+-- GetSessionPlayer returns the player for a session and when the session expires.
+--
+-- name: GetSessionPlayer :one
+SELECT players.id, players.name, players.is_gm, sessions.expires_at
+FROM sessions,
+     players
+WHERE sessions.token_hash = :token_hash
+  AND players.id = sessions.player_id;
+
+-- DeleteSession deletes a session.
+--
+-- name: DeleteSession :exec
+DELETE
+FROM sessions
+WHERE token_hash = :token_hash;
+
+-- DeletePlayerSessions deletes every session for a player.
+--
+-- name: DeletePlayerSessions :exec
+DELETE
+FROM sessions
+WHERE player_id = :player_id;
+
+-- ListPlayerEmpires returns the empires a player controls, with the
+-- code of each game, ordered by game and empire.
+--
+-- name: ListPlayerEmpires :many
+SELECT empires.id, games.code
+FROM empires,
+     games
+WHERE empires.player_id = :player_id
+  AND games.id = empires.game_id
+ORDER BY games.code, empires.id;
+
 -- CreateGame creates a new game.
 --
 -- name: CreateGame :one
@@ -46,6 +109,986 @@ RETURNING id;
 -- CreateStar creates a new star.
 --
 -- name: CreateStar :one
-INSERT INTO stars (system_id, sequence)
-VALUES (:system_id, :sequence)
-RETURNING id;
\ No newline at end of file
+INSERT INTO stars (system_id, sequence, class, size, luminosity)
+VALUES (:system_id, :sequence, :class, :size, :luminosity)
+RETURNING id;
+
+-- GetGameByCode returns the game with the given code.
+--
+-- name: GetGameByCode :one
+SELECT id, code, name, display_name, current_turn, turn_deadline, seed
+FROM games
+WHERE code = :code;
+
+-- CountGameSystems returns the number of systems in a game.
+--
+-- name: CountGameSystems :one
+SELECT COUNT(*)
+FROM systems
+WHERE game_id = :game_id;
+
+-- CreateOrbit creates a new orbit.
+--
+-- name: CreateOrbit :one
+INSERT INTO orbits (star_id, orbit, kind, habitability)
+VALUES (:star_id, :orbit, :kind, :habitability)
+RETURNING id;
+
+-- CreateNaturalResource creates a new natural resource (deposit).
+--
+-- name: CreateNaturalResource :one
+INSERT INTO natural_resources (orbit_id, deposit_no, kind, quantity, yield_pct)
+VALUES (:orbit_id, :deposit_no, :kind, :quantity, :yield_pct)
+RETURNING id;
+
+-- UpdateSystem updates the coordinates of a system.
+--
+-- name: UpdateSystem :exec
+UPDATE systems
+SET x = :x,
+    y = :y,
+    z = :z
+WHERE id = :system_id;
+
+-- UpsertStar creates or updates a star.
+--
+-- name: UpsertStar :one
+INSERT INTO stars (system_id, sequence, class, size, luminosity)
+VALUES (:system_id, :sequence, :class, :size, :luminosity)
+ON CONFLICT (system_id, sequence) DO UPDATE SET class      = excluded.class,
+                                                size       = excluded.size,
+                                                luminosity = excluded.luminosity
+RETURNING id;
+
+-- UpsertOrbit creates or updates an orbit.
+--
+-- name: UpsertOrbit :one
+INSERT INTO orbits (star_id, orbit, kind, habitability)
+VALUES (:star_id, :orbit, :kind, :habitability)
+ON CONFLICT (star_id, orbit) DO UPDATE SET kind         = excluded.kind,
+                                           habitability = excluded.habitability
+RETURNING id;
+
+-- UpsertNaturalResource creates or updates a natural resource (deposit).
+--
+-- name: UpsertNaturalResource :one
+INSERT INTO natural_resources (orbit_id, deposit_no, kind, quantity, yield_pct)
+VALUES (:orbit_id, :deposit_no, :kind, :quantity, :yield_pct)
+ON CONFLICT (orbit_id, deposit_no) DO UPDATE SET kind      = excluded.kind,
+                                                 quantity  = excluded.quantity,
+                                                 yield_pct = excluded.yield_pct
+RETURNING id;
+
+-- DeleteOrbitNaturalResources deletes all the natural resources in an orbit.
+--
+-- name: DeleteOrbitNaturalResources :exec
+DELETE
+FROM natural_resources
+WHERE orbit_id = :orbit_id;
+
+-- ListGameSystems returns all the systems in a game.
+--
+-- name: ListGameSystems :many
+SELECT id, game_id, x, y, z
+FROM systems
+WHERE game_id = :game_id
+ORDER BY id;
+
+-- ListGameStars returns all the stars in a game.
+--
+-- name: ListGameStars :many
+SELECT stars.id, stars.system_id, stars.sequence, stars.class, stars.size, stars.luminosity
+FROM stars,
+     systems
+WHERE systems.game_id = :game_id
+  AND stars.system_id = systems.id
+ORDER BY stars.system_id, stars.sequence;
+
+-- ListGameOrbits returns all the orbits in a game.
+--
+-- name: ListGameOrbits :many
+SELECT orbits.id, orbits.star_id, orbits.orbit, orbits.kind, orbits.habitability
+FROM orbits,
+     stars,
+     systems
+WHERE systems.game_id = :game_id
+  AND stars.system_id = systems.id
+  AND orbits.star_id = stars.id
+ORDER BY orbits.star_id, orbits.orbit;
+
+-- ListGameNaturalResources returns all the natural resources in a game.
+--
+-- name: ListGameNaturalResources :many
+SELECT natural_resources.id,
+       natural_resources.orbit_id,
+       natural_resources.deposit_no,
+       natural_resources.kind,
+       natural_resources.quantity,
+       natural_resources.yield_pct
+FROM natural_resources,
+     orbits,
+     stars,
+     systems
+WHERE systems.game_id = :game_id
+  AND stars.system_id = systems.id
+  AND orbits.star_id = stars.id
+  AND natural_resources.orbit_id = orbits.id
+ORDER BY natural_resources.orbit_id, natural_resources.deposit_no;
+
+-- ListGames returns all the games.
+--
+-- name: ListGames :many
+SELECT id, code, name, display_name, current_turn, turn_deadline, seed
+FROM games
+ORDER BY code;
+
+-- ListGameSystemsInBox returns the systems in a game that are inside a box.
+--
+-- name: ListGameSystemsInBox :many
+SELECT id, game_id, x, y, z
+FROM systems
+WHERE game_id = :game_id
+  AND x BETWEEN :min_x AND :max_x
+  AND y BETWEEN :min_y AND :max_y
+  AND z BETWEEN :min_z AND :max_z
+ORDER BY id;
+
+-- ListGameStarsInBox returns the stars in a game that are inside a box.
+--
+-- name: ListGameStarsInBox :many
+SELECT stars.id, stars.system_id, stars.sequence, stars.class, stars.size, stars.luminosity
+FROM stars,
+     systems
+WHERE systems.game_id = :game_id
+  AND systems.x BETWEEN :min_x AND :max_x
+  AND systems.y BETWEEN :min_y AND :max_y
+  AND systems.z BETWEEN :min_z AND :max_z
+  AND stars.system_id = systems.id
+ORDER BY stars.system_id, stars.sequence;
+
+-- ListGameOrbitsInBox returns the non-empty orbits in a game that are inside a box.
+--
+-- name: ListGameOrbitsInBox :many
+SELECT orbits.id, orbits.star_id, orbits.orbit, orbits.kind, orbits.habitability
+FROM orbits,
+     stars,
+     systems
+WHERE systems.game_id = :game_id
+  AND systems.x BETWEEN :min_x AND :max_x
+  AND systems.y BETWEEN :min_y AND :max_y
+  AND systems.z BETWEEN :min_z AND :max_z
+  AND stars.system_id = systems.id
+  AND orbits.star_id = stars.id
+  AND orbits.kind != 'empty'
+ORDER BY orbits.star_id, orbits.orbit;
+
+-- CreateWarp creates a new warp between two systems.
+-- The lower system id must be first.
+--
+-- name: CreateWarp :one
+INSERT INTO warps (game_id, from_system_id, to_system_id)
+VALUES (:game_id, :from_system_id, :to_system_id)
+RETURNING id;
+
+-- DeleteGameWarps deletes all the warps in a game.
+--
+-- name: DeleteGameWarps :exec
+DELETE
+FROM warps
+WHERE game_id = :game_id;
+
+-- ListGameWarps returns all the warps in a game.
+--
+-- name: ListGameWarps :many
+SELECT id, game_id, from_system_id, to_system_id
+FROM warps
+WHERE game_id = :game_id
+ORDER BY from_system_id, to_system_id;
+
+-- UpdateGameTurnDeadline sets the deadline for orders for the current turn.
+--
+-- name: UpdateGameTurnDeadline :exec
+UPDATE games
+SET turn_deadline = :turn_deadline
+WHERE id = :game_id;
+
+-- GetEmpire returns the empire with the given id.
+--
+-- name: GetEmpire :one
+SELECT id, game_id, player_id
+FROM empires
+WHERE id = :empire_id;
+
+-- CreateOrders creates a new revision of an empire's orders.
+--
+-- name: CreateOrders :one
+INSERT INTO orders (empire_id, turn, revision, text, accepted, diagnostics)
+VALUES (:empire_id, :turn, :revision, :text, :accepted, :diagnostics)
+RETURNING id;
+
+-- GetLatestOrders returns the latest revision of an empire's orders for a turn.
+--
+-- name: GetLatestOrders :one
+SELECT id, empire_id, turn, revision, text, accepted, diagnostics, created_at
+FROM orders
+WHERE empire_id = :empire_id
+  AND turn = :turn
+ORDER BY revision DESC
+LIMIT 1;
+
+-- GetLatestAcceptedOrders returns the latest revision of an empire's orders
+-- for a turn that had no errors.
+--
+-- name: GetLatestAcceptedOrders :one
+SELECT id, empire_id, turn, revision, text, accepted, diagnostics, created_at
+FROM orders
+WHERE empire_id = :empire_id
+  AND turn = :turn
+  AND accepted = 1
+ORDER BY revision DESC
+LIMIT 1;
+
+-- UpdateGameSeed sets the seed for the turn engine.
+--
+-- name: UpdateGameSeed :exec
+UPDATE games
+SET seed = :seed
+WHERE id = :game_id;
+
+-- ListGameEmpires returns the empires in a game, ordered by id.
+--
+-- name: ListGameEmpires :many
+SELECT id, game_id, player_id
+FROM empires
+WHERE game_id = :game_id
+ORDER BY id;
+
+-- ListGameAcceptedOrders returns the latest accepted revision of each
+-- empire's orders for a turn, ordered by empire.
+--
+-- name: ListGameAcceptedOrders :many
+SELECT orders.id, orders.empire_id, orders.turn, orders.revision, orders.text, orders.accepted, orders.diagnostics, orders.created_at
+FROM orders,
+     empires
+WHERE empires.game_id = :game_id
+  AND orders.empire_id = empires.id
+  AND orders.turn = :turn
+  AND orders.accepted = 1
+  AND orders.revision = (SELECT MAX(latest.revision)
+                         FROM orders latest
+                         WHERE latest.empire_id = orders.empire_id
+                           AND latest.turn = orders.turn
+                           AND latest.accepted = 1)
+ORDER BY orders.empire_id;
+
+-- CreateTurn records that a turn has been processed.
+--
+-- name: CreateTurn :exec
+INSERT INTO turns (game_id, turn)
+VALUES (:game_id, :turn);
+
+-- UpsertReport creates or replaces an empire's report for a turn.
+--
+-- name: UpsertReport :exec
+INSERT INTO reports (empire_id, turn, text_report, json_report)
+VALUES (:empire_id, :turn, :text_report, :json_report)
+ON CONFLICT (empire_id, turn) DO UPDATE SET text_report = excluded.text_report,
+                                            json_report = excluded.json_report,
+                                            created_at  = CURRENT_TIMESTAMP;
+
+-- GetReport returns an empire's report for a turn.
+--
+-- name: GetReport :one
+SELECT id, empire_id, turn, text_report, json_report, created_at
+FROM reports
+WHERE empire_id = :empire_id
+  AND turn = :turn;
+
+-- ListGameReports returns the reports available for a game, without the
+-- report text, ordered by empire and turn.
+--
+-- name: ListGameReports :many
+SELECT reports.empire_id, reports.turn, reports.created_at
+FROM reports,
+     empires
+WHERE empires.game_id = :game_id
+  AND reports.empire_id = empires.id
+ORDER BY reports.empire_id, reports.turn;
+
+-- UpsertSeenSystem records that an empire observed a system.
+--
+-- name: UpsertSeenSystem :exec
+INSERT INTO seen_systems (empire_id, system_id, stars, turn)
+VALUES (:empire_id, :system_id, :stars, :turn)
+ON CONFLICT (empire_id, system_id) DO UPDATE SET stars = excluded.stars,
+                                                 turn  = excluded.turn;
+
+-- UpsertSeenOrbit records that an empire observed an orbit.
+--
+-- name: UpsertSeenOrbit :exec
+INSERT INTO seen_orbits (empire_id, orbit_id, kind, habitability, turn)
+VALUES (:empire_id, :orbit_id, :kind, :habitability, :turn)
+ON CONFLICT (empire_id, orbit_id) DO UPDATE SET kind         = excluded.kind,
+                                                habitability = excluded.habitability,
+                                                turn         = excluded.turn;
+
+-- UpsertSeenDeposit records that an empire observed a deposit.
+--
+-- name: UpsertSeenDeposit :exec
+INSERT INTO seen_deposits (empire_id, deposit_id, kind, quantity, yield_pct, turn)
+VALUES (:empire_id, :deposit_id, :kind, :quantity, :yield_pct, :turn)
+ON CONFLICT (empire_id, deposit_id) DO UPDATE SET kind      = excluded.kind,
+                                                  quantity  = excluded.quantity,
+                                                  yield_pct = excluded.yield_pct,
+                                                  turn      = excluded.turn;
+
+-- ListEmpireSeenSystems returns the systems an empire has observed.
+--
+-- name: ListEmpireSeenSystems :many
+SELECT seen_systems.system_id, systems.x, systems.y, systems.z, seen_systems.stars, seen_systems.turn
+FROM seen_systems,
+     systems
+WHERE seen_systems.empire_id = :empire_id
+  AND systems.id = seen_systems.system_id
+ORDER BY seen_systems.system_id;
+
+-- ListEmpireSeenOrbits returns the orbits an empire has observed.
+--
+-- name: ListEmpireSeenOrbits :many
+SELECT seen_orbits.orbit_id,
+       stars.system_id,
+       stars.sequence,
+       orbits.orbit,
+       seen_orbits.kind,
+       seen_orbits.habitability,
+       seen_orbits.turn
+FROM seen_orbits,
+     orbits,
+     stars
+WHERE seen_orbits.empire_id = :empire_id
+  AND orbits.id = seen_orbits.orbit_id
+  AND stars.id = orbits.star_id
+ORDER BY stars.system_id, stars.sequence, orbits.orbit;
+
+-- ListEmpireSeenDeposits returns the deposits an empire has observed.
+--
+-- name: ListEmpireSeenDeposits :many
+SELECT seen_deposits.deposit_id,
+       natural_resources.orbit_id,
+       natural_resources.deposit_no,
+       seen_deposits.kind,
+       seen_deposits.quantity,
+       seen_deposits.yield_pct,
+       seen_deposits.turn
+FROM seen_deposits,
+     natural_resources
+WHERE seen_deposits.empire_id = :empire_id
+  AND natural_resources.id = seen_deposits.deposit_id
+ORDER BY natural_resources.orbit_id, natural_resources.deposit_no;
+
+-- UpsertMine creates or updates an empire's mine on an orbit.
+--
+-- name: UpsertMine :one
+INSERT INTO mines (empire_id, orbit_id, units)
+VALUES (:empire_id, :orbit_id, :units)
+ON CONFLICT (empire_id, orbit_id) DO UPDATE SET units = excluded.units
+RETURNING id;
+
+-- ListGameMines returns the mines in a game, ordered by id.
+--
+-- name: ListGameMines :many
+SELECT mines.id, mines.empire_id, mines.orbit_id, mines.units
+FROM mines,
+     empires
+WHERE empires.game_id = :game_id
+  AND mines.empire_id = empires.id
+ORDER BY mines.id;
+
+-- ListGameMineAssignments returns the deposits worked by the mines in a game.
+--
+-- name: ListGameMineAssignments :many
+SELECT mine_assignments.mine_id, mine_assignments.deposit_id, mine_assignments.units
+FROM mine_assignments,
+     mines,
+     empires
+WHERE empires.game_id = :game_id
+  AND mines.empire_id = empires.id
+  AND mine_assignments.mine_id = mines.id
+ORDER BY mine_assignments.mine_id, mine_assignments.deposit_id;
+
+-- DeleteMineAssignments removes all the assignments for a mine.
+--
+-- name: DeleteMineAssignments :exec
+DELETE
+FROM mine_assignments
+WHERE mine_id = :mine_id;
+
+-- CreateMineAssignment assigns units of a mine to a deposit.
+--
+-- name: CreateMineAssignment :exec
+INSERT INTO mine_assignments (mine_id, deposit_id, units)
+VALUES (:mine_id, :deposit_id, :units);
+
+-- ListGameStockpiles returns the stockpiles of the empires in a game.
+--
+-- name: ListGameStockpiles :many
+SELECT stockpiles.empire_id, stockpiles.kind, stockpiles.quantity
+FROM stockpiles,
+     empires
+WHERE empires.game_id = :game_id
+  AND stockpiles.empire_id = empires.id
+ORDER BY stockpiles.empire_id, stockpiles.kind;
+
+-- UpsertStockpile sets the quantity of a resource an empire has on hand.
+--
+-- name: UpsertStockpile :exec
+INSERT INTO stockpiles (empire_id, kind, quantity)
+VALUES (:empire_id, :kind, :quantity)
+ON CONFLICT (empire_id, kind) DO UPDATE SET quantity = excluded.quantity;
+
+-- CreateExtraction records the results of mining a deposit for a turn.
+--
+-- name: CreateExtraction :exec
+INSERT INTO extractions (empire_id, mine_id, deposit_id, turn, units, mined, refined, remaining)
+VALUES (:empire_id, :mine_id, :deposit_id, :turn, :units, :mined, :refined, :remaining);
+
+-- CreateBattle records an empire's copy of the log of a battle.
+--
+-- name: CreateBattle :exec
+INSERT INTO battles (empire_id, system_id, turn, log)
+VALUES (:empire_id, :system_id, :turn, :log);
+
+-- ListGameBattles returns the battles fought in a game on a turn,
+-- ordered by empire and then by the order they were fought.
+--
+-- name: ListGameBattles :many
+SELECT battles.id, battles.empire_id, battles.system_id, battles.turn, battles.log, battles.created_at
+FROM battles,
+     empires
+WHERE empires.game_id = :game_id
+  AND battles.empire_id = empires.id
+  AND battles.turn = :turn
+ORDER BY battles.empire_id, battles.id;
+
+-- CreateRejection records an order that the turn engine rejected.
+--
+-- name: CreateRejection :exec
+INSERT INTO rejections (empire_id, turn, line, reason)
+VALUES (:empire_id, :turn, :line, :reason);
+
+-- ListGameRejections returns the orders rejected in a game on a turn,
+-- ordered by empire and then by the order they were rejected.
+--
+-- name: ListGameRejections :many
+SELECT rejections.id, rejections.empire_id, rejections.turn, rejections.line, rejections.reason, rejections.created_at
+FROM rejections,
+     empires
+WHERE empires.game_id = :game_id
+  AND rejections.empire_id = empires.id
+  AND rejections.turn = :turn
+ORDER BY rejections.empire_id, rejections.id;
+
+-- UpsertColony creates or updates an empire's colony on an orbit.
+--
+-- name: UpsertColony :one
+INSERT INTO colonies (empire_id, orbit_id, kind, population, enclosures, founded)
+VALUES (:empire_id, :orbit_id, :kind, :population, :enclosures, :founded)
+ON CONFLICT (empire_id, orbit_id, kind) DO UPDATE SET population = excluded.population,
+                                                      enclosures = excluded.enclosures
+RETURNING id;
+
+-- ListGameColonies returns the colonies in a game, ordered by id.
+--
+-- name: ListGameColonies :many
+SELECT colonies.id,
+       colonies.empire_id,
+       colonies.orbit_id,
+       colonies.kind,
+       colonies.population,
+       colonies.enclosures,
+       colonies.founded
+FROM colonies,
+     empires
+WHERE empires.game_id = :game_id
+  AND colonies.empire_id = empires.id
+ORDER BY colonies.id;
+
+-- CreateFleet creates a new fleet and returns its id.
+--
+-- name: CreateFleet :one
+INSERT INTO fleets (empire_id, system_id, orbit_id, destination_id, arrives, fuel)
+VALUES (:empire_id, :system_id, :orbit_id, :destination_id, :arrives, :fuel)
+RETURNING id;
+
+-- UpdateFleet updates the location and fuel of a fleet.
+--
+-- name: UpdateFleet :exec
+UPDATE fleets
+SET system_id      = :system_id,
+    orbit_id       = :orbit_id,
+    destination_id = :destination_id,
+    arrives        = :arrives,
+    fuel           = :fuel
+WHERE id = :fleet_id;
+
+-- DeleteFleet deletes a fleet. Its ships and cargo must be deleted first.
+--
+-- name: DeleteFleet :exec
+DELETE
+FROM fleets
+WHERE id = :fleet_id;
+
+-- ListGameFleets returns the fleets in a game, ordered by id.
+--
+-- name: ListGameFleets :many
+SELECT fleets.id,
+       fleets.empire_id,
+       fleets.system_id,
+       fleets.orbit_id,
+       fleets.destination_id,
+       fleets.arrives,
+       fleets.fuel
+FROM fleets,
+     empires
+WHERE empires.game_id = :game_id
+  AND fleets.empire_id = empires.id
+ORDER BY fleets.id;
+
+-- DeleteFleetShips deletes the ships in a fleet.
+--
+-- name: DeleteFleetShips :exec
+DELETE
+FROM fleet_ships
+WHERE fleet_id = :fleet_id;
+
+-- CreateFleetShips adds ships of a class to a fleet.
+--
+-- name: CreateFleetShips :exec
+INSERT INTO fleet_ships (fleet_id, class, quantity)
+VALUES (:fleet_id, :class, :quantity);
+
+-- ListGameFleetShips returns the ships in every fleet in a game.
+--
+-- name: ListGameFleetShips :many
+SELECT fleet_ships.fleet_id, fleet_ships.class, fleet_ships.quantity
+FROM fleet_ships,
+     fleets,
+     empires
+WHERE empires.game_id = :game_id
+  AND fleets.empire_id = empires.id
+  AND fleet_ships.fleet_id = fleets.id
+ORDER BY fleet_ships.fleet_id, fleet_ships.class;
+
+-- DeleteFleetCargo deletes the cargo in a fleet.
+--
+-- name: DeleteFleetCargo :exec
+DELETE
+FROM fleet_cargo
+WHERE fleet_id = :fleet_id;
+
+-- CreateFleetCargo adds cargo to a fleet.
+--
+-- name: CreateFleetCargo :exec
+INSERT INTO fleet_cargo (fleet_id, kind, quantity)
+VALUES (:fleet_id, :kind, :quantity);
+
+-- ListGameFleetCargo returns the cargo in every fleet in a game.
+--
+-- name: ListGameFleetCargo :many
+SELECT fleet_cargo.fleet_id, fleet_cargo.kind, fleet_cargo.quantity
+FROM fleet_cargo,
+     fleets,
+     empires
+WHERE empires.game_id = :game_id
+  AND fleets.empire_id = empires.id
+  AND fleet_cargo.fleet_id = fleets.id
+ORDER BY fleet_cargo.fleet_id, fleet_cargo.kind;
+
+-- UpsertSnapshot creates or replaces the snapshot of a game for a turn.
+--
+-- name: UpsertSnapshot :exec
+INSERT INTO snapshots (game_id, turn, state)
+VALUES (:game_id, :turn, :state)
+ON CONFLICT (game_id, turn) DO UPDATE SET state      = excluded.state,
+                                          created_at = CURRENT_TIMESTAMP;
+
+-- GetSnapshot returns the state of a game at the start of a turn.
+--
+-- name: GetSnapshot :one
+SELECT state
+FROM snapshots
+WHERE game_id = :game_id
+  AND turn = :turn;
+
+-- ListGameSnapshots returns the snapshots for a game, without the state.
+--
+-- name: ListGameSnapshots :many
+SELECT turn, created_at
+FROM snapshots
+WHERE game_id = :game_id
+ORDER BY turn;
+
+-- DeleteGameSnapshotsAfter deletes the snapshots for turns after the given turn.
+--
+-- name: DeleteGameSnapshotsAfter :exec
+DELETE
+FROM snapshots
+WHERE game_id = :game_id
+  AND turn > :turn;
+
+-- DeleteGameTurnsFrom deletes the processing records for the given turn and later.
+--
+-- name: DeleteGameTurnsFrom :exec
+DELETE
+FROM turns
+WHERE game_id = :game_id
+  AND turn >= :turn;
+
+-- DeleteGameReportsAfter deletes the reports for turns after the given turn.
+--
+-- name: DeleteGameReportsAfter :exec
+DELETE
+FROM reports
+WHERE turn > :turn
+  AND empire_id IN (SELECT id FROM empires WHERE game_id = :game_id);
+
+-- DeleteGameExtractionsFrom deletes the extractions for the given turn and later.
+--
+-- name: DeleteGameExtractionsFrom :exec
+DELETE
+FROM extractions
+WHERE turn >= :turn
+  AND empire_id IN (SELECT id FROM empires WHERE game_id = :game_id);
+
+-- DeleteGameBattlesFrom deletes the battles for the given turn and later.
+--
+-- name: DeleteGameBattlesFrom :exec
+DELETE
+FROM battles
+WHERE turn >= :turn
+  AND empire_id IN (SELECT id FROM empires WHERE game_id = :game_id);
+
+-- DeleteGameRejectionsFrom deletes the rejected orders for the given turn and later.
+--
+-- name: DeleteGameRejectionsFrom :exec
+DELETE
+FROM rejections
+WHERE turn >= :turn
+  AND empire_id IN (SELECT id FROM empires WHERE game_id = :game_id);
+
+-- DeleteEmpireSeenDeposits deletes the deposits an empire has observed.
+--
+-- name: DeleteEmpireSeenDeposits :exec
+DELETE
+FROM seen_deposits
+WHERE empire_id = :empire_id;
+
+-- DeleteEmpireSeenOrbits deletes the orbits an empire has observed.
+--
+-- name: DeleteEmpireSeenOrbits :exec
+DELETE
+FROM seen_orbits
+WHERE empire_id = :empire_id;
+
+-- DeleteEmpireSeenSystems deletes the systems an empire has observed.
+-- The orbits and deposits must be deleted first.
+--
+-- name: DeleteEmpireSeenSystems :exec
+DELETE
+FROM seen_systems
+WHERE empire_id = :empire_id;
+
+-- DeleteEmpireStockpile deletes every resource an empire has on hand.
+--
+-- name: DeleteEmpireStockpile :exec
+DELETE
+FROM stockpiles
+WHERE empire_id = :empire_id;
+
+-- RestoreColony creates or updates a colony, keeping its original id.
+--
+-- name: RestoreColony :exec
+INSERT INTO colonies (id, empire_id, orbit_id, kind, population, enclosures, founded)
+VALUES (:colony_id, :empire_id, :orbit_id, :kind, :population, :enclosures, :founded)
+ON CONFLICT (id) DO UPDATE SET population = excluded.population,
+                               enclosures = excluded.enclosures,
+                               founded    = excluded.founded;
+
+-- DeleteColony deletes a colony.
+--
+-- name: DeleteColony :exec
+DELETE
+FROM colonies
+WHERE id = :colony_id;
+
+-- RestoreFleet creates a fleet with its original id if it no longer exists.
+--
+-- name: RestoreFleet :exec
+INSERT INTO fleets (id, empire_id, system_id, orbit_id, destination_id, arrives, fuel)
+VALUES (:fleet_id, :empire_id, :system_id, :orbit_id, :destination_id, :arrives, :fuel)
+ON CONFLICT (id) DO NOTHING;
+
+-- RestoreMine creates a mine with its original id if it no longer exists.
+--
+-- name: RestoreMine :exec
+INSERT INTO mines (id, empire_id, orbit_id, units)
+VALUES (:mine_id, :empire_id, :orbit_id, :units)
+ON CONFLICT (id) DO NOTHING;
+
+-- DeleteMine deletes a mine. Its assignments must be deleted first.
+--
+-- name: DeleteMine :exec
+DELETE
+FROM mines
+WHERE id = :mine_id;
+
+-- UpsertSchedule sets when the server runs a game's turns.
+--
+-- name: UpsertSchedule :exec
+INSERT INTO schedules (game_id, schedule)
+VALUES (:game_id, :schedule)
+ON CONFLICT (game_id) DO UPDATE SET schedule = excluded.schedule;
+
+-- DeleteSchedule removes a game's schedule.
+--
+-- name: DeleteSchedule :exec
+DELETE
+FROM schedules
+WHERE game_id = :game_id;
+
+-- ListSchedules returns the schedules for all the games.
+--
+-- name: ListSchedules :many
+SELECT game_id, schedule
+FROM schedules
+ORDER BY game_id;
+
+-- GetTurnLock returns the claim on a game's turn.
+--
+-- name: GetTurnLock :one
+SELECT owner, expires_at
+FROM turn_locks
+WHERE game_id = :game_id
+  AND turn = :turn;
+
+-- UpsertTurnLock claims a game's turn.
+--
+-- name: UpsertTurnLock :exec
+INSERT INTO turn_locks (game_id, turn, owner, expires_at)
+VALUES (:game_id, :turn, :owner, :expires_at)
+ON CONFLICT (game_id, turn) DO UPDATE SET owner      = excluded.owner,
+                                          expires_at = excluded.expires_at;
+
+-- DeleteGameTurnLocksFrom deletes the claims on the given turn and later.
+--
+-- name: DeleteGameTurnLocksFrom :exec
+DELETE
+FROM turn_locks
+WHERE game_id = :game_id
+  AND turn >= :turn;
+
+-- CreateAuditEntry records a manual edit to a game.
+--
+-- name: CreateAuditEntry :exec
+INSERT INTO audit_log (game_id, editor, target, change, reason)
+VALUES (:game_id, :editor, :target, :change, :reason);
+
+-- ListGameAuditLog returns the manual edits to a game, newest first.
+--
+-- name: ListGameAuditLog :many
+SELECT id, game_id, editor, target, change, reason, created_at
+FROM audit_log
+WHERE game_id = :game_id
+ORDER BY id DESC;
+
+-- UpdateGameNames renames a game.
+--
+-- name: UpdateGameNames :exec
+UPDATE games
+SET name         = :name,
+    display_name = :display_name
+WHERE id = :game_id;
+
+-- ListGamePlayers returns the name of the player for each empire in a game.
+--
+-- name: ListGamePlayers :many
+SELECT empires.id, players.name
+FROM empires,
+     players
+WHERE empires.game_id = :game_id
+  AND players.id = empires.player_id
+ORDER BY empires.id;
+
+-- GetGameOrbit returns an orbit in a game.
+--
+-- name: GetGameOrbit :one
+SELECT orbits.id, orbits.kind, orbits.habitability
+FROM orbits,
+     stars,
+     systems
+WHERE orbits.id = :orbit_id
+  AND stars.id = orbits.star_id
+  AND systems.id = stars.system_id
+  AND systems.game_id = :game_id;
+
+-- UpdateOrbit changes the kind and habitability of an orbit.
+--
+-- name: UpdateOrbit :exec
+UPDATE orbits
+SET kind         = :kind,
+    habitability = :habitability
+WHERE id = :orbit_id;
+
+-- GetGameNaturalResource returns a deposit in a game.
+--
+-- name: GetGameNaturalResource :one
+SELECT natural_resources.id, natural_resources.kind, natural_resources.quantity, natural_resources.yield_pct
+FROM natural_resources,
+     orbits,
+     stars,
+     systems
+WHERE natural_resources.id = :deposit_id
+  AND orbits.id = natural_resources.orbit_id
+  AND stars.id = orbits.star_id
+  AND systems.id = stars.system_id
+  AND systems.game_id = :game_id;
+
+-- UpdateNaturalResource changes the quantity and yield of a deposit.
+--
+-- name: UpdateNaturalResource :exec
+UPDATE natural_resources
+SET quantity  = :quantity,
+    yield_pct = :yield_pct
+WHERE id = :deposit_id;
+
+-- CreateArticle creates a new article and returns its id.
+--
+-- name: CreateArticle :one
+INSERT INTO articles (title, slug, published, date_published, date_updated)
+VALUES (:title, :slug, :published, :date_published, :date_updated)
+RETURNING id;
+
+-- GetArticleByID returns an article.
+--
+-- name: GetArticleByID :one
+SELECT id, title, slug, published, date_published, date_updated
+FROM articles
+WHERE id = :article_id;
+
+-- GetArticleBySlug returns an article.
+--
+-- name: GetArticleBySlug :one
+SELECT id, title, slug, published, date_published, date_updated
+FROM articles
+WHERE slug = :slug;
+
+-- ListPublishedArticles returns the published articles, newest first.
+--
+-- name: ListPublishedArticles :many
+SELECT id, title, slug, published, date_published, date_updated
+FROM articles
+WHERE published = 1
+ORDER BY id DESC;
+
+-- ListDraftArticles returns the unpublished articles, newest first.
+--
+-- name: ListDraftArticles :many
+SELECT id, title, slug, published, date_published, date_updated
+FROM articles
+WHERE published = 0
+ORDER BY id DESC;
+
+-- ListRecentArticles returns the most recently published articles.
+--
+-- name: ListRecentArticles :many
+SELECT id, title, slug, published, date_published, date_updated
+FROM articles
+WHERE published = 1
+ORDER BY date_published DESC, id DESC
+LIMIT :limit;
+
+-- UpdateArticle changes the title and slug of an article.
+--
+-- name: UpdateArticle :execrows
+UPDATE articles
+SET title        = :title,
+    slug         = :slug,
+    date_updated = :date_updated
+WHERE id = :article_id;
+
+-- PublishArticle publishes an article. The publication date is only
+-- set the first time the article is published.
+--
+-- name: PublishArticle :execrows
+UPDATE articles
+SET published      = 1,
+    date_published = coalesce(date_published, :date_updated),
+    date_updated   = :date_updated
+WHERE id = :article_id;
+
+-- UnpublishArticle returns an article to the drafts.
+--
+-- name: UnpublishArticle :execrows
+UPDATE articles
+SET published    = 0,
+    date_updated = :date_updated
+WHERE id = :article_id;
+
+-- DeleteArticle deletes an article.
+--
+-- name: DeleteArticle :execrows
+DELETE
+FROM articles
+WHERE id = :article_id;
+
+-- GetGameEmpire returns an empire in a game with the name of its player.
+--
+-- name: GetGameEmpire :one
+SELECT empires.id, empires.player_id, players.name
+FROM empires,
+     players
+WHERE empires.id = :empire_id
+  AND empires.game_id = :game_id
+  AND players.id = empires.player_id;
+
+-- CountGamePlayerEmpires returns the number of empires a player controls in a game.
+--
+-- name: CountGamePlayerEmpires :one
+SELECT count(*)
+FROM empires
+WHERE game_id = :game_id
+  AND player_id = :player_id;
+
+-- UpdateEmpirePlayer changes the player that controls an empire.
+--
+-- name: UpdateEmpirePlayer :exec
+UPDATE empires
+SET player_id = :player_id
+WHERE id = :empire_id;
+
+-- GetStockpile returns the quantity of a resource an empire has on hand.
+--
+-- name: GetStockpile :one
+SELECT quantity
+FROM stockpiles
+WHERE empire_id = :empire_id
+  AND kind = :kind;
+
+-- GetGameStar returns a star in a game.
+--
+-- name: GetGameStar :one
+SELECT stars.id, stars.class, stars.size, stars.luminosity
+FROM stars,
+     systems
+WHERE stars.id = :star_id
+  AND systems.id = stars.system_id
+  AND systems.game_id = :game_id;
+
+-- UpdateStar changes the class, size, and luminosity of a star.
+--
+-- name: UpdateStar :exec
+UPDATE stars
+SET class      = :class,
+    size       = :size,
+    luminosity = :luminosity
+WHERE id = :star_id;
@@ -0,0 +1,104 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"github.com/mdhender/moid/internal/models"
+	"github.com/mdhender/moid/internal/router"
+	"github.com/mdhender/moid/internal/sqlite"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// SessionCookie is the name of the cookie with the session token.
+const SessionCookie = "moid-session"
+
+type playerKey struct{}
+
+// Player returns the player that is logged in, or nil if no one is.
+func Player(r *http.Request) *models.Player {
+	player, _ := r.Context().Value(playerKey{}).(*models.Player)
+	return player
+}
+
+// Sessions middleware loads the player for the session cookie, if there
+// is one. Requests without a valid session are passed on without a player.
+func Sessions(db *sqlite.Store) router.Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cookie, err := r.Cookie(SessionCookie)
+			if err != nil || cookie.Value == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			player, err := db.ReadSession(r.Context(), cookie.Value, time.Now())
+			if errors.Is(err, sqlite.ErrNotFound) {
+				next.ServeHTTP(w, r)
+				return
+			} else if err != nil {
+				log.Printf("%s %s: session: %v\n", r.Method, r.URL.Path, err)
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), playerKey{}, player)))
+		})
+	}
+}
+
+// RequireLogin middleware sends visitors who aren't logged in to the
+// login page.
+func RequireLogin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if Player(r) == nil {
+			http.Redirect(w, r, "/login", http.StatusSeeOther)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireGM middleware only lets the GM through.
+func RequireGM(next http.Handler) http.Handler {
+	return RequireLogin(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if player := Player(r); !player.GM {
+			log.Printf("%s %s: player %q: not the GM\n", r.Method, r.URL.Path, player.Name)
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	}))
+}
+
+// RequireEmpire middleware only lets the player that controls the empire
+// in the "{code}" and "{id}" path values, or the GM, through. Empires that
+// the player can't see are reported as not found.
+func RequireEmpire(db *sqlite.Store) router.Middleware {
+	return func(next http.Handler) http.Handler {
+		return RequireLogin(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			player := Player(r)
+			if player.GM {
+				next.ServeHTTP(w, r)
+				return
+			}
+			empireID, err := strconv.Atoi(r.PathValue("id"))
+			if err != nil {
+				http.NotFound(w, r)
+				return
+			}
+			empire, err := db.ReadEmpire(r.Context(), r.PathValue("code"), empireID)
+			if errors.Is(err, sqlite.ErrNotFound) || (err == nil && empire.PlayerID != player.ID) {
+				http.NotFound(w, r)
+				return
+			} else if err != nil {
+				log.Printf("%s %s: %v\n", r.Method, r.URL.Path, err)
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+			next.ServeHTTP(w, r)
+		}))
+	}
+}
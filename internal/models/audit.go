@@ -0,0 +1,22 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package models
+
+import "time"
+
+// Edit identifies who made a manual change to a game and why.
+// Every change the GM makes from the console must have both.
+type Edit struct {
+	Editor string
+	Reason string
+}
+
+// AuditEntry is a manual change that was made to a game.
+type AuditEntry struct {
+	ID        int
+	Editor    string
+	Target    string // what was changed, like "orbit 975"
+	Change    string // the old and new values, like "habitability 3 -> 5"
+	Reason    string
+	CreatedAt time.Time
+}
@@ -0,0 +1,13 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package models
+
+// Battle is an empire's record of a battle it fought in a system.
+// Every empire in the battle has its own copy of the log.
+type Battle struct {
+	ID       int
+	EmpireID int
+	SystemID int
+	Turn     int      // the turn the battle was fought on
+	Log      []string // the battle log, one line per entry
+}
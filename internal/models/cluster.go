@@ -2,7 +2,11 @@
 
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"math"
+	"time"
+)
 
 // some notes to myself on the model:
 // - this needs to implement the minimal viable product
@@ -11,7 +15,9 @@ import "time"
 // A player is a human or AI or just an NPC.
 // Each player controls a single empire in any given game.
 type Player struct {
-	ID int // unique identifier for the player
+	ID   int // unique identifier for the player
+	Name string
+	GM   bool // true if the player can use the admin pages
 }
 
 // Game represents a single game.
@@ -21,18 +27,35 @@ type Game struct {
 	Name        string
 	DisplayName string
 	CurrentTurn int
+	Deadline    time.Time // orders lock at this time; zero if there is no deadline
+	Seed        uint64    // seed for the turn engine
+	Schedule    string    // when the server runs turns, like "mon,thu 18:00 UTC"; empty if run by hand
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	Cluster     *Cluster
 
-	Empires []*Empire // list of empires in the game
+	Empires  []*Empire // list of empires in the game
+	Colonies []*Colony // list of colonies in the game; may not be loaded
+	Fleets   []*Fleet  // list of fleets in the game; may not be loaded
+	Mines    []*Mine   // list of mines in the game; may not be loaded
 }
 
 // Empire is a single empire in the game.
 // They may be controlled by a human player or an AI.
 type Empire struct {
-	ID       int
-	PlayerID int // player that controls this empire
+	ID        int
+	PlayerID  int                // player that controls this empire
+	Knowledge *Knowledge         // what the empire has observed; may not be loaded
+	Stockpile map[Resource_e]int // refined resources on hand; may not be loaded
+
+	// Battles are the battles the empire fought on a turn. The engine
+	// adds them and they are saved with the turn. They are only loaded
+	// to generate the reports.
+	Battles []*Battle
+
+	// Rejections are the empire's orders that the engine ignored on a
+	// turn. They are saved and loaded the same way as the battles.
+	Rejections []*Rejection
 }
 
 // Cluster defines the cluster of systems in the game.
@@ -41,6 +64,7 @@ type Cluster struct {
 	ID int // unique identifier for the cluster
 
 	Systems []*System // list of systems in the cluster
+	Warps   []*Warp   // list of warp lines between systems
 }
 
 // System is a stellar system containing one or more star systems.
@@ -55,17 +79,154 @@ type System struct {
 	Stars []*Star // list of stars in the system
 }
 
+// ChebyshevDistance returns the largest difference between any
+// coordinate of the system and the given point.
+func (s *System) ChebyshevDistance(x, y, z int) int {
+	return max(abs(s.X-x), abs(s.Y-y), abs(s.Z-z))
+}
+
+// EuclideanDistance returns the straight line distance between
+// the system and the given point.
+func (s *System) EuclideanDistance(x, y, z int) float64 {
+	dx, dy, dz := float64(s.X-x), float64(s.Y-y), float64(s.Z-z)
+	return math.Sqrt(dx*dx + dy*dy + dz*dz)
+}
+
+// Warp is a two-way connection between two systems.
+type Warp struct {
+	ID       int // unique identifier for the warp
+	From, To *System
+}
+
+// MarshalJSON implements json.Marshaler. The systems are written as
+// coordinates since they are unique within a cluster.
+func (w *Warp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		ID   int
+		From [3]int
+		To   [3]int
+	}{
+		ID:   w.ID,
+		From: [3]int{w.From.X, w.From.Y, w.From.Z},
+		To:   [3]int{w.To.X, w.To.Y, w.To.Z},
+	})
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
 // Star is a single star in a stellar system.
 // All stars have 10 orbits; orbits may be empty or contain a single planet.
 type Star struct {
 	ID       int // unique identifier for the star
 	Sequence int // 1, 2, 3, etc
 
+	Class      Spectral_e // spectral class, from hot blue O to cool red M
+	Size       StarSize_e // main sequence, giant, or supergiant
+	Luminosity int        // brightness as a percentage of Sol's
+
 	// Orbits is a list of planets in the star system.
 	// The zero index is always nil.
 	Orbits [11]*Planet
 }
 
+// HabitableOrbit returns the orbit at the center of the star's habitable
+// zone. Sol, at 100% luminosity, centers the zone on orbit 4. The zone
+// moves out with the square root of the luminosity, so the result may be
+// past the last orbit for very bright stars.
+func (s *Star) HabitableOrbit() int {
+	return max(1, int(math.Round(4*math.Sqrt(float64(s.Luminosity)/100))))
+}
+
+// IsHabitableOrbit returns true if the orbit is within one orbit of
+// the center of the star's habitable zone.
+func (s *Star) IsHabitableOrbit(orbit int) bool {
+	return abs(orbit-s.HabitableOrbit()) <= 1
+}
+
+// String implements fmt.Stringer; it returns a description like
+// "yellow main sequence (G)".
+func (s *Star) String() string {
+	return s.Class.Color() + " " + s.Size.String() + " (" + s.Class.String() + ")"
+}
+
+type Spectral_e int
+
+const (
+	CLASS_O Spectral_e = iota + 1
+	CLASS_B
+	CLASS_A
+	CLASS_F
+	CLASS_G
+	CLASS_K
+	CLASS_M
+)
+
+func (k Spectral_e) String() string {
+	switch k {
+	case CLASS_O:
+		return "O"
+	case CLASS_B:
+		return "B"
+	case CLASS_A:
+		return "A"
+	case CLASS_F:
+		return "F"
+	case CLASS_G:
+		return "G"
+	case CLASS_K:
+		return "K"
+	case CLASS_M:
+		return "M"
+	}
+	return "unknown"
+}
+
+// Color returns the apparent color of stars in the spectral class.
+func (k Spectral_e) Color() string {
+	switch k {
+	case CLASS_O:
+		return "blue"
+	case CLASS_B:
+		return "blue-white"
+	case CLASS_A:
+		return "white"
+	case CLASS_F:
+		return "yellow-white"
+	case CLASS_G:
+		return "yellow"
+	case CLASS_K:
+		return "orange"
+	case CLASS_M:
+		return "red"
+	}
+	return "unknown"
+}
+
+type StarSize_e int
+
+const (
+	MAIN_SEQUENCE StarSize_e = iota + 1
+	GIANT
+	SUPERGIANT
+)
+
+func (k StarSize_e) String() string {
+	switch k {
+	case MAIN_SEQUENCE:
+		return "main sequence"
+	case GIANT:
+		return "giant"
+	case SUPERGIANT:
+		return "supergiant"
+	}
+	return "unknown"
+}
+
 type Planet struct {
 	ID   int // unique identifier for the planet
 	Kind Planet_e
@@ -75,8 +236,8 @@ type Planet struct {
 	Habitability int
 
 	// NaturalResources is a list of natural resources on the planet.
-	// The zero index is always nil.
-	NaturalResources [35]*Deposit
+	// The zero index is always nil; deposits are numbered 1 through 35.
+	NaturalResources [36]*Deposit
 }
 
 type Planet_e int
@@ -87,6 +248,18 @@ const (
 	TERRESTRIAL
 )
 
+func (k Planet_e) String() string {
+	switch k {
+	case ASTEROID_BELT:
+		return "asteroid belt"
+	case GAS_GIANT:
+		return "gas giant"
+	case TERRESTRIAL:
+		return "terrestrial"
+	}
+	return "empty"
+}
+
 type Deposit struct {
 	ID       int        // unique identifier for the deposit
 	Kind     Resource_e // the type of resource
@@ -102,3 +275,17 @@ const (
 	METALLICS
 	NONMETALLICS
 )
+
+func (k Resource_e) String() string {
+	switch k {
+	case FUEL:
+		return "fuel"
+	case GOLD:
+		return "gold"
+	case METALLICS:
+		return "metallics"
+	case NONMETALLICS:
+		return "non-metallics"
+	}
+	return "unknown"
+}
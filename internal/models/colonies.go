@@ -0,0 +1,52 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package models
+
+// ColonistsPerHabitability is the number of colonists that each point
+// of habitability supports in an open colony.
+const ColonistsPerHabitability = 1_000
+
+// ColonistsPerEnclosure is the number of colonists that each enclosure
+// supports in an enclosed colony.
+const ColonistsPerEnclosure = 500
+
+// Colony is a settlement of an empire on a planet.
+//
+// Open colonies live on the surface, so the planet's habitability
+// limits their population. Enclosed colonies live in enclosures and
+// can be built on any planet, but only grow as enclosures are added.
+type Colony struct {
+	ID         int
+	EmpireID   int
+	OrbitID    int      // the planet the colony is on
+	Kind       Colony_e // open or enclosed
+	Population int      // number of colonists
+	Enclosures int      // number of enclosures; always zero for open colonies
+	Founded    int      // turn the colony was founded
+}
+
+// Capacity returns the number of colonists the colony can support
+// on a planet with the given habitability.
+func (c *Colony) Capacity(habitability int) int {
+	if c.Kind == ENCLOSED {
+		return c.Enclosures * ColonistsPerEnclosure
+	}
+	return habitability * ColonistsPerHabitability
+}
+
+type Colony_e int
+
+const (
+	OPEN Colony_e = iota + 1
+	ENCLOSED
+)
+
+func (k Colony_e) String() string {
+	switch k {
+	case OPEN:
+		return "open"
+	case ENCLOSED:
+		return "enclosed"
+	}
+	return "unknown"
+}
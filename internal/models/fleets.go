@@ -0,0 +1,110 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package models
+
+import "sort"
+
+// Fleet is a group of ships that move together.
+//
+// A fleet is always in a system or in transit between two systems.
+// In a system, it may be orbiting a planet. In transit, SystemID is
+// the system it left and Arrives is the first turn that it will be
+// at the destination.
+type Fleet struct {
+	ID            int
+	EmpireID      int
+	SystemID      int // the system the fleet is in, or left from if it is in transit
+	OrbitID       int // the planet the fleet is orbiting; zero if it isn't at a planet
+	DestinationID int // the system the fleet is travelling to; zero if it isn't in transit
+	Arrives       int // the turn the fleet is at the destination; zero if it isn't in transit
+	Fuel          int // fuel in the fleet's tanks
+
+	// Ships is the number of ships of each class, keyed by class name.
+	Ships map[string]int
+
+	// Cargo is the quantity of each kind of cargo, keyed by cargo name.
+	// Fuel is never cargo.
+	Cargo map[string]int
+}
+
+// InTransit returns true if the fleet is travelling between systems.
+func (f *Fleet) InTransit() bool {
+	return f.DestinationID != 0
+}
+
+// Classes returns the classes of the ships in the fleet, sorted by name.
+func (f *Fleet) Classes() []string {
+	var list []string
+	for name, n := range f.Ships {
+		if n > 0 {
+			list = append(list, name)
+		}
+	}
+	sort.Strings(list)
+	return list
+}
+
+// Speed is the distance the fleet travels each turn. A fleet
+// moves at the speed of its slowest ship.
+func (f *Fleet) Speed() int {
+	speed := 0
+	for _, name := range f.Classes() {
+		if class, ok := ShipClasses[name]; ok && (speed == 0 || class.Speed < speed) {
+			speed = class.Speed
+		}
+	}
+	return speed
+}
+
+// FuelPerDistance is the fuel the fleet burns to travel one unit of distance.
+func (f *Fleet) FuelPerDistance() int {
+	return f.sum(func(c *ShipClass) int { return c.FuelPerDistance })
+}
+
+// FuelCapacity is the most fuel the fleet's tanks can hold.
+func (f *Fleet) FuelCapacity() int {
+	return f.sum(func(c *ShipClass) int { return c.FuelCapacity })
+}
+
+// CargoCapacity is the most cargo the fleet can carry.
+func (f *Fleet) CargoCapacity() int {
+	return f.sum(func(c *ShipClass) int { return c.CargoCapacity })
+}
+
+// CargoLoaded is the total cargo the fleet is carrying.
+func (f *Fleet) CargoLoaded() int {
+	total := 0
+	for _, n := range f.Cargo {
+		total += n
+	}
+	return total
+}
+
+func (f *Fleet) sum(value func(c *ShipClass) int) int {
+	total := 0
+	for name, n := range f.Ships {
+		if class, ok := ShipClasses[name]; ok {
+			total += n * value(class)
+		}
+	}
+	return total
+}
+
+// ShipClass is the design of a ship.
+type ShipClass struct {
+	Name            string
+	Speed           int // distance travelled each turn
+	FuelPerDistance int // fuel burned per unit of distance
+	FuelCapacity    int // fuel the ship's tanks can hold
+	CargoCapacity   int // cargo the ship can carry
+	Attack          int // zero if the ship is unarmed
+	Defense         int
+	HitPoints       int // damage the ship can take before it is destroyed
+}
+
+// ShipClasses are the classes of ship that can be built, keyed by name.
+var ShipClasses = map[string]*ShipClass{
+	"scout":     {Name: "scout", Speed: 5, FuelPerDistance: 1, FuelCapacity: 50, Defense: 3, HitPoints: 1},
+	"transport": {Name: "transport", Speed: 3, FuelPerDistance: 2, FuelCapacity: 100, CargoCapacity: 5_000, Defense: 2, HitPoints: 2},
+	"warship":   {Name: "warship", Speed: 4, FuelPerDistance: 4, FuelCapacity: 200, Attack: 4, Defense: 4, HitPoints: 3},
+}
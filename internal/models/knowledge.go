@@ -0,0 +1,166 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package models
+
+// Knowledge is what an empire has observed of the cluster.
+// Observations keep the values as they were when observed, along with
+// the turn of the observation, so stale knowledge can be reported as
+// "as of turn N".
+//
+// Only saved systems, planets, and deposits can be observed, since
+// knowledge is keyed by their IDs.
+type Knowledge struct {
+	Systems map[int]*SeenSystem // keyed by system id
+}
+
+// SeenSystem is a system as the empire last observed it.
+type SeenSystem struct {
+	ID      int // the system id
+	X, Y, Z int
+	Stars   int                // number of stars in the system
+	Turn    int                // turn the system was last observed
+	Orbits  map[int]*SeenOrbit // keyed by orbit id
+}
+
+// SeenOrbit is a planet as the empire last observed it.
+type SeenOrbit struct {
+	ID           int // the orbit id
+	Star         int // sequence of the star
+	Orbit        int
+	Kind         Planet_e
+	Habitability int
+	Turn         int                  // turn the orbit was last observed
+	Deposits     map[int]*SeenDeposit // keyed by deposit id
+}
+
+// SeenDeposit is a deposit as the empire last observed it.
+type SeenDeposit struct {
+	ID       int // the deposit id
+	Number   int // deposit number on the planet
+	Kind     Resource_e
+	Quantity int
+	YieldPct int
+	Turn     int // turn the deposit was last observed
+}
+
+// NewKnowledge returns knowledge with nothing observed.
+func NewKnowledge() *Knowledge {
+	return &Knowledge{Systems: map[int]*SeenSystem{}}
+}
+
+// ObserveSystem records the coordinates and number of stars of the system.
+func (k *Knowledge) ObserveSystem(system *System, turn int) *SeenSystem {
+	seen, ok := k.Systems[system.ID]
+	if !ok {
+		seen = &SeenSystem{ID: system.ID, X: system.X, Y: system.Y, Z: system.Z, Orbits: map[int]*SeenOrbit{}}
+		k.Systems[system.ID] = seen
+	}
+	seen.Stars, seen.Turn = len(system.Stars), turn
+	return seen
+}
+
+// ObserveOrbits records the system and the kind and habitability of
+// every planet in it.
+func (k *Knowledge) ObserveOrbits(system *System, turn int) {
+	seen := k.ObserveSystem(system, turn)
+	for _, star := range system.Stars {
+		for orbit, planet := range star.Orbits {
+			if planet != nil {
+				seen.observePlanet(star.Sequence, orbit, planet, turn)
+			}
+		}
+	}
+}
+
+// ObserveDeposits records the system, the planet in the orbit, and every
+// deposit on the planet. It does nothing if the orbit is empty.
+func (k *Knowledge) ObserveDeposits(system *System, star *Star, orbit int, turn int) {
+	planet := star.Orbits[orbit]
+	if planet == nil {
+		return
+	}
+	seen := k.ObserveSystem(system, turn).observePlanet(star.Sequence, orbit, planet, turn)
+	for n, deposit := range planet.NaturalResources {
+		if deposit == nil {
+			continue
+		}
+		seen.Deposits[deposit.ID] = &SeenDeposit{
+			ID:       deposit.ID,
+			Number:   n,
+			Kind:     deposit.Kind,
+			Quantity: deposit.Quantity,
+			YieldPct: deposit.YieldPct,
+			Turn:     turn,
+		}
+	}
+}
+
+func (s *SeenSystem) observePlanet(star, orbit int, planet *Planet, turn int) *SeenOrbit {
+	seen, ok := s.Orbits[planet.ID]
+	if !ok {
+		seen = &SeenOrbit{ID: planet.ID, Star: star, Orbit: orbit, Deposits: map[int]*SeenDeposit{}}
+		s.Orbits[planet.ID] = seen
+	}
+	seen.Kind, seen.Habitability, seen.Turn = planet.Kind, planet.Habitability, turn
+	return seen
+}
+
+// View returns the system as the empire last observed it, or nil if
+// the empire has never observed it. Stars that haven't been surveyed
+// have no planets, and planets that haven't been surveyed have no deposits.
+// Stars can be classified from afar, so the view isn't limited to what
+// was seen: it always has the current class, size, and luminosity of
+// each star, including any changes the GM has made since.
+func (k *Knowledge) View(system *System) *System {
+	seen, ok := k.Systems[system.ID]
+	if !ok {
+		return nil
+	}
+	view := &System{ID: seen.ID, X: seen.X, Y: seen.Y, Z: seen.Z}
+	for sequence := 1; sequence <= seen.Stars; sequence++ {
+		star := &Star{Sequence: sequence}
+		if sequence <= len(system.Stars) {
+			star.Class, star.Size, star.Luminosity = system.Stars[sequence-1].Class, system.Stars[sequence-1].Size, system.Stars[sequence-1].Luminosity
+		}
+		view.Stars = append(view.Stars, star)
+	}
+	for _, orbit := range seen.Orbits {
+		if orbit.Star < 1 || orbit.Star > len(view.Stars) || orbit.Orbit < 1 || orbit.Orbit >= len(view.Stars[0].Orbits) {
+			continue
+		}
+		planet := &Planet{ID: orbit.ID, Kind: orbit.Kind, Habitability: orbit.Habitability}
+		for _, deposit := range orbit.Deposits {
+			if 1 <= deposit.Number && deposit.Number < len(planet.NaturalResources) {
+				planet.NaturalResources[deposit.Number] = &Deposit{
+					ID:       deposit.ID,
+					Kind:     deposit.Kind,
+					Quantity: deposit.Quantity,
+					YieldPct: deposit.YieldPct,
+				}
+			}
+		}
+		view.Stars[orbit.Star-1].Orbits[orbit.Orbit] = planet
+	}
+	return view
+}
+
+// Cluster returns the part of the cluster that the empire has observed,
+// as it was observed. Warps are included if the empire has observed
+// the systems at both ends.
+func (k *Knowledge) Cluster(cluster *Cluster) *Cluster {
+	view := &Cluster{ID: cluster.ID}
+	views := map[*System]*System{}
+	for _, system := range cluster.Systems {
+		if sv := k.View(system); sv != nil {
+			views[system] = sv
+			view.Systems = append(view.Systems, sv)
+		}
+	}
+	for _, warp := range cluster.Warps {
+		from, to := views[warp.From], views[warp.To]
+		if from != nil && to != nil {
+			view.Warps = append(view.Warps, &Warp{ID: warp.ID, From: from, To: to})
+		}
+	}
+	return view
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package models
+
+// Mine is the mining units an empire has on a planet.
+type Mine struct {
+	ID       int
+	EmpireID int
+	OrbitID  int // the planet the mine is on
+	Units    int // number of mining units
+
+	// Assignments are the units working each deposit, keyed by deposit id.
+	// The total may not be more than Units.
+	Assignments map[int]int
+
+	// Extractions are the results of mining on the current turn.
+	// They are saved as the audit trail and are not loaded.
+	Extractions []*Extraction
+}
+
+// Assigned returns the number of units working deposits.
+func (m *Mine) Assigned() int {
+	total := 0
+	for _, units := range m.Assignments {
+		total += units
+	}
+	return total
+}
+
+// Extraction is the result of mining a deposit for a turn.
+type Extraction struct {
+	DepositID int
+	Turn      int
+	Units     int // mining units that worked the deposit
+	Mined     int // quantity removed from the deposit
+	Refined   int // quantity added to the stockpile after the yield
+	Remaining int // quantity left in the deposit
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package models
+
+import "time"
+
+// Orders is one revision of the orders an empire submitted for a turn.
+type Orders struct {
+	ID          int
+	EmpireID    int
+	Turn        int
+	Revision    int
+	Text        string
+	Accepted    bool   // true if the orders had no errors
+	Diagnostics string // the errors, one per line
+	CreatedAt   time.Time
+}
+
+// Rejection is an order that the turn engine ignored, and why.
+type Rejection struct {
+	ID       int
+	EmpireID int
+	Turn     int // the turn the order was for
+	Line     int // line of the order in the empire's orders
+	Reason   string
+}
@@ -0,0 +1,16 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package models
+
+import "time"
+
+// Report is the turn report for an empire.
+// The index of available reports doesn't load the Text or JSON.
+type Report struct {
+	ID        int
+	EmpireID  int
+	Turn      int
+	Text      string // the report in the classic layout
+	JSON      string // the same data for tools
+	CreatedAt time.Time
+}
@@ -0,0 +1,12 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package models
+
+import "time"
+
+// Snapshot describes the saved state of a game at the start of a turn.
+// The GM can roll the game back to any turn that has a snapshot.
+type Snapshot struct {
+	Turn      int
+	CreatedAt time.Time
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+// Package navigation plans routes along the warp lines in a cluster.
+package navigation
+
+import (
+	"container/heap"
+	"errors"
+	"github.com/mdhender/moid/internal/models"
+	"math"
+	"sort"
+)
+
+// ErrNoRoute is returned when the systems are not connected by warps.
+var ErrNoRoute = errors.New("no route")
+
+// Planner finds routes through the warp network of a cluster.
+// Ties between routes are always broken the same way, so the same
+// cluster will always return the same routes.
+type Planner struct {
+	systems []*models.System
+	index   map[*models.System]int
+	byID    map[int]*models.System
+	links   [][]int // neighbors of each system, ordered by index
+}
+
+// NewPlanner returns a planner for the systems and warps in the cluster.
+func NewPlanner(cluster *models.Cluster) *Planner {
+	p := &Planner{
+		systems: cluster.Systems,
+		index:   map[*models.System]int{},
+		byID:    map[int]*models.System{},
+		links:   make([][]int, len(cluster.Systems)),
+	}
+	for i, system := range cluster.Systems {
+		p.index[system] = i
+		if system.ID != 0 {
+			p.byID[system.ID] = system
+		}
+	}
+	for _, warp := range cluster.Warps {
+		from, ok := p.index[warp.From]
+		if !ok {
+			continue
+		}
+		to, ok := p.index[warp.To]
+		if !ok {
+			continue
+		}
+		p.links[from] = append(p.links[from], to)
+		p.links[to] = append(p.links[to], from)
+	}
+	for _, links := range p.links {
+		sort.Ints(links)
+	}
+	return p
+}
+
+// System returns the system with the given ID, or nil if it is not in the cluster.
+func (p *Planner) System(id int) *models.System {
+	return p.byID[id]
+}
+
+// Route is a path through the warp network.
+type Route struct {
+	Systems  []*models.System // every system on the route, including both ends
+	Jumps    int              // number of warps used
+	Distance float64          // total length of the warps used
+}
+
+// ShortestByJumps returns the route that uses the fewest warps.
+func (p *Planner) ShortestByJumps(from, to *models.System) (*Route, error) {
+	src, dst, err := p.ends(from, to)
+	if err != nil {
+		return nil, err
+	}
+	prev := make([]int, len(p.systems))
+	for i := range prev {
+		prev[i] = -1
+	}
+	prev[src] = src
+	queue := []int{src}
+	for len(queue) != 0 && prev[dst] == -1 {
+		n := queue[0]
+		queue = queue[1:]
+		for _, next := range p.links[n] {
+			if prev[next] == -1 {
+				prev[next] = n
+				queue = append(queue, next)
+			}
+		}
+	}
+	if prev[dst] == -1 {
+		return nil, ErrNoRoute
+	}
+	return p.route(prev, src, dst), nil
+}
+
+// ShortestByDistance returns the route with the shortest total warp length.
+func (p *Planner) ShortestByDistance(from, to *models.System) (*Route, error) {
+	src, dst, err := p.ends(from, to)
+	if err != nil {
+		return nil, err
+	}
+	dist := make([]float64, len(p.systems))
+	prev := make([]int, len(p.systems))
+	for i := range dist {
+		dist[i], prev[i] = math.Inf(1), -1
+	}
+	dist[src], prev[src] = 0, src
+	pq := &queue{{node: src}}
+	for pq.Len() != 0 {
+		item := heap.Pop(pq).(entry)
+		if item.node == dst {
+			break
+		} else if item.distance > dist[item.node] {
+			continue // stale entry
+		}
+		for _, next := range p.links[item.node] {
+			d := dist[item.node] + length(p.systems[item.node], p.systems[next])
+			if d < dist[next] {
+				dist[next], prev[next] = d, item.node
+				heap.Push(pq, entry{node: next, distance: d})
+			}
+		}
+	}
+	if prev[dst] == -1 {
+		return nil, ErrNoRoute
+	}
+	return p.route(prev, src, dst), nil
+}
+
+func (p *Planner) ends(from, to *models.System) (int, int, error) {
+	src, ok := p.index[from]
+	if !ok {
+		return 0, 0, errors.New("origin is not in the cluster")
+	}
+	dst, ok := p.index[to]
+	if !ok {
+		return 0, 0, errors.New("destination is not in the cluster")
+	}
+	return src, dst, nil
+}
+
+// route walks the predecessors back from the destination.
+func (p *Planner) route(prev []int, src, dst int) *Route {
+	var path []int
+	for n := dst; n != src; n = prev[n] {
+		path = append(path, n)
+	}
+	path = append(path, src)
+
+	r := &Route{}
+	for i := len(path) - 1; i >= 0; i-- {
+		r.Systems = append(r.Systems, p.systems[path[i]])
+	}
+	for i := 1; i < len(r.Systems); i++ {
+		r.Jumps++
+		r.Distance += length(r.Systems[i-1], r.Systems[i])
+	}
+	return r
+}
+
+func length(from, to *models.System) float64 {
+	return from.EuclideanDistance(to.X, to.Y, to.Z)
+}
+
+type entry struct {
+	node     int
+	distance float64
+}
+
+// queue is a min-heap of entries ordered by distance, then by node.
+type queue []entry
+
+func (q queue) Len() int { return len(q) }
+func (q queue) Less(i, j int) bool {
+	if q[i].distance != q[j].distance {
+		return q[i].distance < q[j].distance
+	}
+	return q[i].node < q[j].node
+}
+func (q queue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *queue) Push(x any)   { *q = append(*q, x.(entry)) }
+func (q *queue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
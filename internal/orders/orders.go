@@ -0,0 +1,218 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+// Package orders implements the parser for player orders.
+//
+// Orders are plain text with one order per line. Keywords are not case
+// sensitive and anything after a semicolon is a comment. Blank lines
+// are ignored.
+//
+//	MOVE FLEET <id> TO <coords>
+//	BUILD <quantity> <unit> AT <location>
+//	MINE <location> DEPOSIT <n> UNITS <quantity>
+//	COLONIZE <location> WITH FLEET <id> [OPEN | ENCLOSED]
+//	SURVEY <location> WITH FLEET <id>
+//	TRANSFER <quantity> <cargo> FROM <holder> TO <holder>
+//
+// Coordinates are written as "x,y,z" with no spaces, for example "3,-4,7".
+// Locations add the star sequence and orbit, as in "3,-4,7/1/5".
+// Holders are "FLEET <id>" or "COLONY <id>".
+//
+// Every web form, email handler, and GM tool should use this package
+// so that players only have to learn one grammar.
+package orders
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Order is implemented by all the typed commands.
+type Order interface {
+	// Pos returns the position of the order's verb in the input.
+	Pos() Position
+	// String returns the order in its canonical form.
+	String() string
+}
+
+// Position is the line and column (both starting at 1) of a token.
+type Position struct {
+	Line   int
+	Column int
+}
+
+func (p Position) Pos() Position {
+	return p
+}
+
+// Coords are the coordinates of a system.
+type Coords struct {
+	X, Y, Z int
+}
+
+func (c Coords) String() string {
+	return fmt.Sprintf("%d,%d,%d", c.X, c.Y, c.Z)
+}
+
+// Location is an orbit around a star in a system.
+type Location struct {
+	Coords
+	Star  int
+	Orbit int
+}
+
+func (l Location) String() string {
+	return fmt.Sprintf("%d,%d,%d/%d/%d", l.X, l.Y, l.Z, l.Star, l.Orbit)
+}
+
+// Holder is a fleet or colony that can hold cargo.
+type Holder struct {
+	Kind string // "fleet" or "colony"
+	ID   int
+}
+
+func (h Holder) String() string {
+	return fmt.Sprintf("%s %d", strings.ToUpper(h.Kind), h.ID)
+}
+
+// Move orders a fleet to travel to another system.
+type Move struct {
+	Position
+	Fleet int
+	To    Coords
+}
+
+func (o *Move) String() string {
+	return fmt.Sprintf("MOVE FLEET %d TO %s", o.Fleet, o.To)
+}
+
+// Build orders units to be built at a location.
+type Build struct {
+	Position
+	Quantity int
+	Unit     string // one of Units
+	At       Location
+}
+
+func (o *Build) String() string {
+	return fmt.Sprintf("BUILD %d %s AT %s", o.Quantity, strings.ToUpper(o.Unit), o.At)
+}
+
+// Mine assigns mining units at a location to a deposit.
+type Mine struct {
+	Position
+	At      Location
+	Deposit int
+	Units   int
+}
+
+func (o *Mine) String() string {
+	return fmt.Sprintf("MINE %s DEPOSIT %d UNITS %d", o.At, o.Deposit, o.Units)
+}
+
+// Colonize orders a fleet to settle colonists at a location.
+type Colonize struct {
+	Position
+	At    Location
+	Fleet int
+	Kind  string // "open" or "enclosed"
+}
+
+func (o *Colonize) String() string {
+	return fmt.Sprintf("COLONIZE %s WITH FLEET %d %s", o.At, o.Fleet, strings.ToUpper(o.Kind))
+}
+
+// Survey orders a fleet to survey the deposits at a location.
+type Survey struct {
+	Position
+	At    Location
+	Fleet int
+}
+
+func (o *Survey) String() string {
+	return fmt.Sprintf("SURVEY %s WITH FLEET %d", o.At, o.Fleet)
+}
+
+// Transfer moves cargo between fleets and colonies.
+type Transfer struct {
+	Position
+	Quantity int
+	Cargo    string // one of Cargoes
+	From     Holder
+	To       Holder
+}
+
+func (o *Transfer) String() string {
+	return fmt.Sprintf("TRANSFER %d %s FROM %s TO %s", o.Quantity, strings.ToUpper(o.Cargo), o.From, o.To)
+}
+
+var (
+	// Units are the things that can be built.
+	Units = []string{"enclosure", "factory", "mine", "scout", "transport", "warship"}
+	// Cargoes are the things that can be transferred.
+	Cargoes = []string{"colonists", "fuel", "gold", "metallics", "non-metallics"}
+)
+
+// Error is a problem with a single token in the input.
+type Error struct {
+	Position
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Msg)
+}
+
+// ErrorList is every error found in the input, ordered by position.
+type ErrorList []*Error
+
+func (list ErrorList) Error() string {
+	switch len(list) {
+	case 0:
+		return "no errors"
+	case 1:
+		return list[0].Error()
+	}
+	return fmt.Sprintf("%s (and %d more errors)", list[0], len(list)-1)
+}
+
+// Parse returns the orders in the input. It doesn't stop at the first
+// error; every line is checked so that the player can fix the entire
+// file at once. If there are any errors, the error is an ErrorList
+// and the orders from the lines without errors are still returned.
+func Parse(input string) ([]Order, error) {
+	var list []Order
+	var errs ErrorList
+	for n, line := range strings.Split(strings.ReplaceAll(input, "\r\n", "\n"), "\n") {
+		p := &parser{line: n + 1, tokens: tokenize(n+1, line)}
+		if len(p.tokens) == 0 {
+			continue
+		}
+		order, err := p.parseOrder()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		list = append(list, order)
+	}
+	if len(errs) != 0 {
+		sort.SliceStable(errs, func(i, j int) bool {
+			if errs[i].Line != errs[j].Line {
+				return errs[i].Line < errs[j].Line
+			}
+			return errs[i].Column < errs[j].Column
+		})
+		return list, errs
+	}
+	return list, nil
+}
+
+// Format returns the orders in their canonical form, one per line.
+func Format(list []Order) string {
+	sb := &strings.Builder{}
+	for _, order := range list {
+		sb.WriteString(order.String())
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
@@ -0,0 +1,332 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package orders
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type token struct {
+	Position
+	text string
+}
+
+// tokenize splits the line into words, dropping any comment.
+// Columns are counted in runes so that they match what the player sees.
+func tokenize(line int, text string) []*token {
+	var tokens []*token
+	var current *token
+	column := 0
+	for _, r := range text {
+		column++
+		if r == ';' {
+			break
+		} else if unicode.IsSpace(r) {
+			current = nil
+			continue
+		}
+		if current == nil {
+			current = &token{Position: Position{Line: line, Column: column}}
+			tokens = append(tokens, current)
+		}
+		current.text += string(r)
+	}
+	return tokens
+}
+
+// parser parses the tokens for a single line.
+type parser struct {
+	line   int
+	tokens []*token
+	pos    int
+}
+
+// errorf returns an error at the current token, or just past the
+// last token if the line ended early.
+func (p *parser) errorf(format string, args ...any) *Error {
+	e := &Error{Position: Position{Line: p.line, Column: 1}, Msg: fmt.Sprintf(format, args...)}
+	if p.pos < len(p.tokens) {
+		e.Column = p.tokens[p.pos].Column
+	} else if n := len(p.tokens); n != 0 {
+		e.Column = p.tokens[n-1].Column + len([]rune(p.tokens[n-1].text)) + 1
+	}
+	return e
+}
+
+// next returns the current token and advances.
+func (p *parser) next(want string) (*token, *Error) {
+	if p.pos >= len(p.tokens) {
+		return nil, p.errorf("expected %s", want)
+	}
+	t := p.tokens[p.pos]
+	p.pos++
+	return t, nil
+}
+
+// keyword consumes the keyword. Case is ignored.
+func (p *parser) keyword(word string) *Error {
+	if p.pos >= len(p.tokens) {
+		return p.errorf("expected %s", word)
+	} else if !strings.EqualFold(p.tokens[p.pos].text, word) {
+		return p.errorf("expected %s, found %q", word, p.tokens[p.pos].text)
+	}
+	p.pos++
+	return nil
+}
+
+// number consumes a positive integer.
+func (p *parser) number(want string) (int, *Error) {
+	t, err := p.next(want)
+	if err != nil {
+		return 0, err
+	}
+	n, cerr := strconv.Atoi(t.text)
+	if cerr != nil || n < 1 {
+		p.pos--
+		return 0, p.errorf("expected %s, found %q", want, t.text)
+	}
+	return n, nil
+}
+
+// word consumes one of the choices, which must be in lower case.
+// The word is returned in lower case.
+func (p *parser) word(want string, choices []string) (string, *Error) {
+	t, err := p.next(want)
+	if err != nil {
+		return "", err
+	}
+	word := strings.ToLower(t.text)
+	if !slices.Contains(choices, word) {
+		p.pos--
+		return "", p.errorf("expected %s (%s), found %q", want, strings.Join(choices, ", "), t.text)
+	}
+	return word, nil
+}
+
+func (p *parser) coords() (Coords, *Error) {
+	t, err := p.next("coordinates")
+	if err != nil {
+		return Coords{}, err
+	}
+	c, ok := parseCoords(t.text)
+	if !ok {
+		p.pos--
+		return Coords{}, p.errorf("expected coordinates (x,y,z), found %q", t.text)
+	}
+	return c, nil
+}
+
+func (p *parser) location() (Location, *Error) {
+	t, err := p.next("location")
+	if err != nil {
+		return Location{}, err
+	}
+	loc, ok := parseLocation(t.text)
+	if !ok {
+		p.pos--
+		return Location{}, p.errorf("expected location (x,y,z/star/orbit), found %q", t.text)
+	}
+	return loc, nil
+}
+
+func (p *parser) holder() (Holder, *Error) {
+	kind, err := p.word("fleet or colony", []string{"colony", "fleet"})
+	if err != nil {
+		return Holder{}, err
+	}
+	id, err := p.number(kind + " id")
+	if err != nil {
+		return Holder{}, err
+	}
+	return Holder{Kind: kind, ID: id}, nil
+}
+
+// ParseLocation returns the location from text like "3,-4,7/1/5".
+func ParseLocation(text string) (Location, error) {
+	loc, ok := parseLocation(text)
+	if !ok {
+		return Location{}, fmt.Errorf("%q: invalid location", text)
+	}
+	return loc, nil
+}
+
+func parseLocation(text string) (Location, bool) {
+	fields := strings.Split(text, "/")
+	if len(fields) != 3 {
+		return Location{}, false
+	}
+	c, ok := parseCoords(fields[0])
+	if !ok {
+		return Location{}, false
+	}
+	star, serr := strconv.Atoi(fields[1])
+	orbit, oerr := strconv.Atoi(fields[2])
+	if serr != nil || oerr != nil || star < 1 || orbit < 1 {
+		return Location{}, false
+	}
+	return Location{Coords: c, Star: star, Orbit: orbit}, true
+}
+
+func parseCoords(text string) (Coords, bool) {
+	fields := strings.Split(text, ",")
+	if len(fields) != 3 {
+		return Coords{}, false
+	}
+	var xyz [3]int
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return Coords{}, false
+		}
+		xyz[i] = n
+	}
+	return Coords{X: xyz[0], Y: xyz[1], Z: xyz[2]}, true
+}
+
+// parseOrder parses the verb and its arguments. It returns the first
+// error on the line; anything after that is usually noise.
+func (p *parser) parseOrder() (Order, *Error) {
+	verb := p.tokens[0]
+	pos := verb.Position
+	p.pos = 1
+
+	var order Order
+	var err *Error
+	switch strings.ToLower(verb.text) {
+	case "move":
+		order, err = p.parseMove(pos)
+	case "build":
+		order, err = p.parseBuild(pos)
+	case "mine":
+		order, err = p.parseMine(pos)
+	case "colonize":
+		order, err = p.parseColonize(pos)
+	case "survey":
+		order, err = p.parseSurvey(pos)
+	case "transfer":
+		order, err = p.parseTransfer(pos)
+	default:
+		p.pos = 0
+		return nil, p.errorf("unknown order %q", verb.text)
+	}
+	if err != nil {
+		return nil, err
+	} else if p.pos < len(p.tokens) {
+		return nil, p.errorf("unexpected %q", p.tokens[p.pos].text)
+	}
+	return order, nil
+}
+
+// MOVE FLEET <id> TO <coords>
+func (p *parser) parseMove(pos Position) (Order, *Error) {
+	o := &Move{Position: pos}
+	var err *Error
+	if err = p.keyword("FLEET"); err != nil {
+		return nil, err
+	} else if o.Fleet, err = p.number("fleet id"); err != nil {
+		return nil, err
+	} else if err = p.keyword("TO"); err != nil {
+		return nil, err
+	} else if o.To, err = p.coords(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// BUILD <quantity> <unit> AT <location>
+func (p *parser) parseBuild(pos Position) (Order, *Error) {
+	o := &Build{Position: pos}
+	var err *Error
+	if o.Quantity, err = p.number("quantity"); err != nil {
+		return nil, err
+	} else if o.Unit, err = p.word("unit", Units); err != nil {
+		return nil, err
+	} else if err = p.keyword("AT"); err != nil {
+		return nil, err
+	} else if o.At, err = p.location(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// MINE <location> DEPOSIT <n> UNITS <quantity>
+func (p *parser) parseMine(pos Position) (Order, *Error) {
+	o := &Mine{Position: pos}
+	var err *Error
+	if o.At, err = p.location(); err != nil {
+		return nil, err
+	} else if err = p.keyword("DEPOSIT"); err != nil {
+		return nil, err
+	} else if o.Deposit, err = p.number("deposit number"); err != nil {
+		return nil, err
+	} else if err = p.keyword("UNITS"); err != nil {
+		return nil, err
+	} else if o.Units, err = p.number("number of units"); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// COLONIZE <location> WITH FLEET <id> [OPEN | ENCLOSED]
+func (p *parser) parseColonize(pos Position) (Order, *Error) {
+	o := &Colonize{Position: pos, Kind: "open"}
+	var err *Error
+	if o.At, err = p.location(); err != nil {
+		return nil, err
+	} else if err = p.keyword("WITH"); err != nil {
+		return nil, err
+	} else if err = p.keyword("FLEET"); err != nil {
+		return nil, err
+	} else if o.Fleet, err = p.number("fleet id"); err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		if o.Kind, err = p.word("colony kind", []string{"enclosed", "open"}); err != nil {
+			return nil, err
+		}
+	}
+	return o, nil
+}
+
+// SURVEY <location> WITH FLEET <id>
+func (p *parser) parseSurvey(pos Position) (Order, *Error) {
+	o := &Survey{Position: pos}
+	var err *Error
+	if o.At, err = p.location(); err != nil {
+		return nil, err
+	} else if err = p.keyword("WITH"); err != nil {
+		return nil, err
+	} else if err = p.keyword("FLEET"); err != nil {
+		return nil, err
+	} else if o.Fleet, err = p.number("fleet id"); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// TRANSFER <quantity> <cargo> FROM <holder> TO <holder>
+func (p *parser) parseTransfer(pos Position) (Order, *Error) {
+	o := &Transfer{Position: pos}
+	var err *Error
+	if o.Quantity, err = p.number("quantity"); err != nil {
+		return nil, err
+	} else if o.Cargo, err = p.word("cargo", Cargoes); err != nil {
+		return nil, err
+	} else if err = p.keyword("FROM"); err != nil {
+		return nil, err
+	} else if o.From, err = p.holder(); err != nil {
+		return nil, err
+	} else if err = p.keyword("TO"); err != nil {
+		return nil, err
+	} else if o.To, err = p.holder(); err != nil {
+		return nil, err
+	} else if o.From == o.To {
+		p.pos--
+		return nil, p.errorf("cannot transfer to the same %s", o.To.Kind)
+	}
+	return o, nil
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package orders
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseValid(t *testing.T) {
+	loc := Location{Coords: Coords{X: 1, Y: 2, Z: 3}, Star: 1, Orbit: 4}
+	for _, tc := range []struct {
+		name  string
+		input string
+		want  Order
+		text  string // canonical form
+	}{
+		{"move", "MOVE FLEET 12 TO 3,-4,7",
+			&Move{Position: Position{1, 1}, Fleet: 12, To: Coords{X: 3, Y: -4, Z: 7}},
+			"MOVE FLEET 12 TO 3,-4,7"},
+		{"move lower case indented", "  move fleet 12 to 3,-4,7",
+			&Move{Position: Position{1, 3}, Fleet: 12, To: Coords{X: 3, Y: -4, Z: 7}},
+			"MOVE FLEET 12 TO 3,-4,7"},
+		{"build", "BUILD 5 Factory AT 1,2,3/1/4",
+			&Build{Position: Position{1, 1}, Quantity: 5, Unit: "factory", At: loc},
+			"BUILD 5 FACTORY AT 1,2,3/1/4"},
+		{"mine", "MINE 1,2,3/1/4 DEPOSIT 2 UNITS 10",
+			&Mine{Position: Position{1, 1}, At: loc, Deposit: 2, Units: 10},
+			"MINE 1,2,3/1/4 DEPOSIT 2 UNITS 10"},
+		{"colonize defaults to open", "COLONIZE 1,2,3/1/4 WITH FLEET 7",
+			&Colonize{Position: Position{1, 1}, At: loc, Fleet: 7, Kind: "open"},
+			"COLONIZE 1,2,3/1/4 WITH FLEET 7 OPEN"},
+		{"colonize enclosed", "COLONIZE 1,2,3/1/4 WITH FLEET 7 enclosed",
+			&Colonize{Position: Position{1, 1}, At: loc, Fleet: 7, Kind: "enclosed"},
+			"COLONIZE 1,2,3/1/4 WITH FLEET 7 ENCLOSED"},
+		{"survey", "SURVEY 1,2,3/1/4 WITH FLEET 7",
+			&Survey{Position: Position{1, 1}, At: loc, Fleet: 7},
+			"SURVEY 1,2,3/1/4 WITH FLEET 7"},
+		{"survey with comment", "SURVEY 1,2,3/1/4 WITH FLEET 7 ; look first",
+			&Survey{Position: Position{1, 1}, At: loc, Fleet: 7},
+			"SURVEY 1,2,3/1/4 WITH FLEET 7"},
+		{"transfer", "TRANSFER 100 Non-Metallics FROM COLONY 3 TO FLEET 7",
+			&Transfer{Position: Position{1, 1}, Quantity: 100, Cargo: "non-metallics", From: Holder{Kind: "colony", ID: 3}, To: Holder{Kind: "fleet", ID: 7}},
+			"TRANSFER 100 NON-METALLICS FROM COLONY 3 TO FLEET 7"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			list, err := Parse(tc.input)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tc.input, err)
+			} else if len(list) != 1 {
+				t.Fatalf("Parse(%q): got %d orders, want 1", tc.input, len(list))
+			}
+			if !reflect.DeepEqual(list[0], tc.want) {
+				t.Errorf("Parse(%q):\n got %#v\nwant %#v", tc.input, list[0], tc.want)
+			}
+			if got := list[0].String(); got != tc.text {
+				t.Errorf("String: got %q, want %q", got, tc.text)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		input string
+		want  string // the error, including the line and column
+	}{
+		{"unknown verb", "ATTACK 1,2,3", `1:1: unknown order "ATTACK"`},
+		{"unknown verb indented", "  attack 1,2,3", `1:3: unknown order "attack"`},
+		{"move wrong keyword", "MOVE SHIP 12 TO 1,2,3", `1:6: expected FLEET, found "SHIP"`},
+		{"move fleet id not a number", "MOVE FLEET x TO 1,2,3", `1:12: expected fleet id, found "x"`},
+		{"move fleet id zero", "MOVE FLEET 0 TO 1,2,3", `1:12: expected fleet id, found "0"`},
+		{"move missing destination", "MOVE FLEET 12", `1:15: expected TO`},
+		{"move bad coordinates", "MOVE FLEET 12 TO 1,2", `1:18: expected coordinates (x,y,z), found "1,2"`},
+		{"move trailing word", "MOVE FLEET 12 TO 1,2,3 NOW", `1:24: unexpected "NOW"`},
+		{"build unknown unit", "BUILD 5 DEATHSTAR AT 1,2,3/1/4", `1:9: expected unit (enclosure, factory, mine, scout, transport, warship), found "DEATHSTAR"`},
+		{"build negative quantity", "BUILD -5 FACTORY AT 1,2,3/1/4", `1:7: expected quantity, found "-5"`},
+		{"build coordinates for location", "BUILD 5 FACTORY AT 1,2,3", `1:20: expected location (x,y,z/star/orbit), found "1,2,3"`},
+		{"build star zero", "BUILD 5 FACTORY AT 1,2,3/0/4", `1:20: expected location (x,y,z/star/orbit), found "1,2,3/0/4"`},
+		{"mine missing units", "MINE 1,2,3/1/4 DEPOSIT 2", `1:26: expected UNITS`},
+		{"mine negative units", "MINE 1,2,3/1/4 DEPOSIT 2 UNITS -1", `1:32: expected number of units, found "-1"`},
+		{"colonize unknown kind", "COLONIZE 1,2,3/1/4 WITH FLEET 7 CLOSED", `1:33: expected colony kind (enclosed, open), found "CLOSED"`},
+		{"colonize trailing word", "COLONIZE 1,2,3/1/4 WITH FLEET 7 OPEN NOW", `1:38: unexpected "NOW"`},
+		{"colonize missing fleet id", "COLONIZE 1,2,3/1/4 WITH FLEET", `1:31: expected fleet id`},
+		{"survey missing with", "SURVEY 1,2,3/1/4 FLEET 7", `1:18: expected WITH, found "FLEET"`},
+		{"survey missing location", "SURVEY", `1:8: expected location`},
+		{"transfer unknown cargo", "TRANSFER 100 SPICE FROM COLONY 3 TO FLEET 7", `1:14: expected cargo (colonists, fuel, gold, metallics, non-metallics), found "SPICE"`},
+		{"transfer unknown holder", "TRANSFER 100 GOLD FROM SHIP 7 TO FLEET 8", `1:24: expected fleet or colony (colony, fleet), found "SHIP"`},
+		{"transfer to itself", "TRANSFER 100 GOLD FROM FLEET 7 TO FLEET 7", `1:41: cannot transfer to the same fleet`},
+		{"columns count runes", "\u3000MOVE FLEET x TO 1,2,3", `1:13: expected fleet id, found "x"`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			list, err := Parse(tc.input)
+			if err == nil {
+				t.Fatalf("Parse(%q): got %v, want error %q", tc.input, list, tc.want)
+			} else if got := err.Error(); got != tc.want {
+				t.Errorf("Parse(%q):\n got %s\nwant %s", tc.input, got, tc.want)
+			}
+			if len(list) != 0 {
+				t.Errorf("Parse(%q): got %d orders, want 0", tc.input, len(list))
+			}
+		})
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	input := "; orders for turn 3\r\n" +
+		"\r\n" +
+		"MOVE FLEET 1 TO 1,1,1\r\n" +
+		"   \t \r\n" +
+		"BUILD 2 GIZMO AT 1,2,3/1/4 ; not a unit\r\n" +
+		"SURVEY 1,2,3/1/4 WITH FLEET 2\r\n" +
+		"TRANSFER 5 FUEL FROM FLEET 1\r\n"
+	list, err := Parse(input)
+
+	// the orders from the good lines are still returned
+	if got, want := Format(list), "MOVE FLEET 1 TO 1,1,1\nSURVEY 1,2,3/1/4 WITH FLEET 2\n"; got != want {
+		t.Errorf("orders:\n got %q\nwant %q", got, want)
+	}
+	if got, want := []Position{list[0].Pos(), list[1].Pos()}, []Position{{3, 1}, {6, 1}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("positions: got %v, want %v", got, want)
+	}
+
+	// every bad line is reported, in order
+	var errs ErrorList
+	if !errors.As(err, &errs) {
+		t.Fatalf("error: got %T, want ErrorList", err)
+	}
+	want := []string{
+		`5:9: expected unit (enclosure, factory, mine, scout, transport, warship), found "GIZMO"`,
+		`7:30: expected TO`,
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("errors: got %v, want %d errors", errs, len(want))
+	}
+	for i, e := range errs {
+		if got := e.Error(); got != want[i] {
+			t.Errorf("error %d: got %s, want %s", i, got, want[i])
+		}
+	}
+	if got, want := err.Error(), want[0]+" (and 1 more errors)"; got != want {
+		t.Errorf("ErrorList: got %s, want %s", got, want)
+	}
+}
+
+func TestParseEmpty(t *testing.T) {
+	for _, input := range []string{"", "\n\n", "; nothing to do\n  ; still nothing", " \t\r\n"} {
+		list, err := Parse(input)
+		if err != nil || len(list) != 0 {
+			t.Errorf("Parse(%q): got %v, %v, want no orders and no error", input, list, err)
+		}
+	}
+}
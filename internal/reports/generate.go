@@ -0,0 +1,55 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package reports
+
+import (
+	"context"
+	"github.com/mdhender/moid/internal/models"
+	"github.com/mdhender/moid/internal/sqlite"
+	"log"
+)
+
+// Generate creates or replaces the report for every empire in the game
+// for the game's current turn.
+func Generate(ctx context.Context, db *sqlite.Store, code string) error {
+	game, err := db.ReadGameState(ctx, code)
+	if err != nil {
+		return err
+	}
+
+	// the rejected orders and battles in the report are from the turn that was just run.
+	var rejections map[int][]*models.Rejection
+	var battles map[int][]*models.Battle
+	if game.CurrentTurn > 0 {
+		if rejections, err = db.ReadRejections(ctx, code, game.CurrentTurn-1); err != nil {
+			return err
+		} else if battles, err = db.ReadBattles(ctx, code, game.CurrentTurn-1); err != nil {
+			return err
+		}
+	}
+
+	var list []*models.Report
+	for _, empire := range game.Empires {
+		empire.Rejections = rejections[empire.ID]
+		empire.Battles = battles[empire.ID]
+		report, err := New(game, empire)
+		if err != nil {
+			return err
+		}
+		data, err := report.JSON()
+		if err != nil {
+			return err
+		}
+		list = append(list, &models.Report{
+			EmpireID: empire.ID,
+			Turn:     game.CurrentTurn,
+			Text:     string(report.Text()),
+			JSON:     string(data),
+		})
+	}
+	if err := db.SaveReports(ctx, code, list); err != nil {
+		return err
+	}
+	log.Printf("reports: %s: turn %d: saved %d reports\n", code, game.CurrentTurn, len(list))
+	return nil
+}
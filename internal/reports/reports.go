@@ -0,0 +1,400 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+// Package reports generates the turn reports for empires.
+//
+// A report is built once from the game state and can then be written
+// as plain text in the classic play-by-mail layout or as JSON.
+package reports
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+	"sort"
+	"strings"
+)
+
+// Report is the data in an empire's turn report. It only includes
+// what the empire has observed. Each system, orbit, and deposit has
+// the turn it was last observed.
+type Report struct {
+	Game      string         `json:"game"`
+	Empire    int            `json:"empire"`
+	Turn      int            `json:"turn"`
+	Stockpile map[string]int `json:"stockpile"`
+	Colonies  []*Colony      `json:"colonies"`
+	Fleets    []*Fleet       `json:"fleets"`
+	Rejected  []*Rejection   `json:"rejected"`
+	Battles   []*Battle      `json:"battles"`
+	Systems   []*System      `json:"systems"`
+}
+
+// Colony is one of the empire's colonies. Capacity is the most
+// colonists the colony can support.
+type Colony struct {
+	ID         int    `json:"id"`
+	Location   string `json:"location"`
+	Kind       string `json:"kind"`
+	Population int    `json:"population"`
+	Capacity   int    `json:"capacity"`
+	Enclosures int    `json:"enclosures,omitempty"`
+	Founded    int    `json:"founded"`
+}
+
+// Fleet is one of the empire's fleets. Location is the system or planet
+// the fleet is at, or the system it left if it is in transit.
+type Fleet struct {
+	ID          int            `json:"id"`
+	Location    string         `json:"location"`
+	Destination string         `json:"destination,omitempty"`
+	Arrives     int            `json:"arrives,omitempty"`
+	Fuel        int            `json:"fuel"`
+	Ships       map[string]int `json:"ships"`
+	Cargo       map[string]int `json:"cargo"`
+}
+
+// Rejection is one of the empire's orders that the engine ignored
+// on the turn before the report.
+type Rejection struct {
+	Turn   int    `json:"turn"`
+	Line   int    `json:"line"`
+	Reason string `json:"reason"`
+}
+
+// Battle is a battle the empire fought on the turn before the report.
+type Battle struct {
+	Location string   `json:"location"`
+	Turn     int      `json:"turn"`
+	Log      []string `json:"log"`
+}
+
+type System struct {
+	X     int     `json:"x"`
+	Y     int     `json:"y"`
+	Z     int     `json:"z"`
+	Seen  int     `json:"seen"`
+	Stars []*Star `json:"stars"`
+}
+
+type Star struct {
+	Sequence   int      `json:"sequence"`
+	Class      string   `json:"class"`
+	Size       string   `json:"size"`
+	Luminosity int      `json:"luminosity"`
+	Orbits     []*Orbit `json:"orbits"`
+}
+
+// Orbit is a planet in an orbit. Empty orbits are not reported.
+type Orbit struct {
+	Orbit        int        `json:"orbit"`
+	Kind         string     `json:"kind"`
+	Habitability int        `json:"habitability"`
+	Seen         int        `json:"seen"`
+	Deposits     []*Deposit `json:"deposits,omitempty"`
+}
+
+type Deposit struct {
+	Number   int    `json:"number"`
+	Kind     string `json:"kind"`
+	Quantity int    `json:"quantity"`
+	YieldPct int    `json:"yield_pct"`
+	Seen     int    `json:"seen"`
+}
+
+// New returns the report for the empire on the game's current turn.
+// The game must have its cluster, colonies, and fleets loaded and the
+// empire must have its knowledge and stockpile loaded. The empire's
+// rejected orders and battles are reported if they are loaded.
+func New(game *models.Game, empire *models.Empire) (*Report, error) {
+	if game.Cluster == nil {
+		return nil, fmt.Errorf("%s: cluster not loaded", game.Code)
+	} else if empire.Knowledge == nil {
+		return nil, fmt.Errorf("%s: empire %d: knowledge not loaded", game.Code, empire.ID)
+	}
+	r := &Report{
+		Game:      game.Code,
+		Empire:    empire.ID,
+		Turn:      game.CurrentTurn,
+		Stockpile: Stockpile(empire),
+		Colonies:  Colonies(game, empire),
+		Fleets:    Fleets(game, empire),
+		Rejected:  Rejections(empire),
+		Battles:   Battles(game, empire),
+		Systems:   []*System{},
+	}
+	// systems are reported in the same order as the cluster.
+	for _, system := range game.Cluster.Systems {
+		seen, ok := empire.Knowledge.Systems[system.ID]
+		if !ok {
+			continue
+		}
+		rs := &System{X: seen.X, Y: seen.Y, Z: seen.Z, Seen: seen.Turn}
+		for sequence := 1; sequence <= seen.Stars; sequence++ {
+			star := &Star{Sequence: sequence, Orbits: []*Orbit{}}
+			// stars can be classified from afar, so the class is always current.
+			if sequence <= len(system.Stars) {
+				star.Class = system.Stars[sequence-1].Class.String()
+				star.Size = system.Stars[sequence-1].Size.String()
+				star.Luminosity = system.Stars[sequence-1].Luminosity
+			}
+			rs.Stars = append(rs.Stars, star)
+		}
+		for _, orbit := range seen.Orbits {
+			if orbit.Star < 1 || orbit.Star > len(rs.Stars) {
+				continue
+			}
+			ro := &Orbit{Orbit: orbit.Orbit, Kind: orbit.Kind.String(), Habitability: orbit.Habitability, Seen: orbit.Turn}
+			for _, deposit := range orbit.Deposits {
+				ro.Deposits = append(ro.Deposits, &Deposit{
+					Number:   deposit.Number,
+					Kind:     deposit.Kind.String(),
+					Quantity: deposit.Quantity,
+					YieldPct: deposit.YieldPct,
+					Seen:     deposit.Turn,
+				})
+			}
+			sort.Slice(ro.Deposits, func(i, j int) bool {
+				return ro.Deposits[i].Number < ro.Deposits[j].Number
+			})
+			star := rs.Stars[orbit.Star-1]
+			star.Orbits = append(star.Orbits, ro)
+		}
+		for _, star := range rs.Stars {
+			sort.Slice(star.Orbits, func(i, j int) bool {
+				return star.Orbits[i].Orbit < star.Orbits[j].Orbit
+			})
+		}
+		r.Systems = append(r.Systems, rs)
+	}
+	return r, nil
+}
+
+// Stockpile returns the empire's stockpile keyed by resource name.
+func Stockpile(empire *models.Empire) map[string]int {
+	stockpile := map[string]int{}
+	for _, kind := range []models.Resource_e{models.FUEL, models.GOLD, models.METALLICS, models.NONMETALLICS} {
+		stockpile[kind.String()] = empire.Stockpile[kind]
+	}
+	return stockpile
+}
+
+// Colonies returns the empire's colonies, ordered by id. Colonies on
+// planets that aren't in the cluster are skipped.
+func Colonies(game *models.Game, empire *models.Empire) []*Colony {
+	locations := map[int]string{}
+	habitability := map[int]int{}
+	for _, system := range game.Cluster.Systems {
+		for _, star := range system.Stars {
+			for orbit, planet := range star.Orbits {
+				if planet != nil {
+					locations[planet.ID] = fmt.Sprintf("%d,%d,%d/%d/%d", system.X, system.Y, system.Z, star.Sequence, orbit)
+					habitability[planet.ID] = planet.Habitability
+				}
+			}
+		}
+	}
+	list := []*Colony{}
+	for _, colony := range game.Colonies {
+		location, ok := locations[colony.OrbitID]
+		if colony.EmpireID != empire.ID || !ok {
+			continue
+		}
+		list = append(list, &Colony{
+			ID:         colony.ID,
+			Location:   location,
+			Kind:       colony.Kind.String(),
+			Population: colony.Population,
+			Capacity:   colony.Capacity(habitability[colony.OrbitID]),
+			Enclosures: colony.Enclosures,
+			Founded:    colony.Founded,
+		})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+	return list
+}
+
+// Fleets returns the empire's fleets, ordered by id.
+func Fleets(game *models.Game, empire *models.Empire) []*Fleet {
+	systems := map[int]string{}
+	planets := map[int]string{}
+	for _, system := range game.Cluster.Systems {
+		systems[system.ID] = fmt.Sprintf("%d,%d,%d", system.X, system.Y, system.Z)
+		for _, star := range system.Stars {
+			for orbit, planet := range star.Orbits {
+				if planet != nil {
+					planets[planet.ID] = fmt.Sprintf("%d,%d,%d/%d/%d", system.X, system.Y, system.Z, star.Sequence, orbit)
+				}
+			}
+		}
+	}
+	list := []*Fleet{}
+	for _, fleet := range game.Fleets {
+		if fleet.EmpireID != empire.ID {
+			continue
+		}
+		rf := &Fleet{
+			ID:       fleet.ID,
+			Location: systems[fleet.SystemID],
+			Fuel:     fleet.Fuel,
+			Ships:    map[string]int{},
+			Cargo:    map[string]int{},
+		}
+		if location, ok := planets[fleet.OrbitID]; ok {
+			rf.Location = location
+		}
+		if fleet.InTransit() {
+			rf.Destination, rf.Arrives = systems[fleet.DestinationID], fleet.Arrives
+		}
+		for _, class := range fleet.Classes() {
+			rf.Ships[class] = fleet.Ships[class]
+		}
+		for kind, n := range fleet.Cargo {
+			if n > 0 {
+				rf.Cargo[kind] = n
+			}
+		}
+		list = append(list, rf)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+	return list
+}
+
+// Rejections returns the empire's rejected orders in the order the
+// engine rejected them.
+func Rejections(empire *models.Empire) []*Rejection {
+	list := []*Rejection{}
+	for _, rejection := range empire.Rejections {
+		list = append(list, &Rejection{Turn: rejection.Turn, Line: rejection.Line, Reason: rejection.Reason})
+	}
+	return list
+}
+
+// Battles returns the empire's battles in the order they were fought.
+func Battles(game *models.Game, empire *models.Empire) []*Battle {
+	systems := map[int]string{}
+	for _, system := range game.Cluster.Systems {
+		systems[system.ID] = fmt.Sprintf("%d,%d,%d", system.X, system.Y, system.Z)
+	}
+	list := []*Battle{}
+	for _, battle := range empire.Battles {
+		list = append(list, &Battle{Location: systems[battle.SystemID], Turn: battle.Turn, Log: battle.Log})
+	}
+	return list
+}
+
+// JSON returns the report as indented JSON.
+func (r *Report) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// Text returns the report in the classic layout.
+func (r *Report) Text() []byte {
+	b := &bytes.Buffer{}
+	rule := "================================================================================\n"
+	fmt.Fprintf(b, "GAME %-8s  EMPIRE %04d  TURN %04d\n", r.Game, r.Empire, r.Turn)
+	b.WriteString(rule)
+	b.WriteString("\nSTOCKPILE\n")
+	for _, kind := range []models.Resource_e{models.FUEL, models.GOLD, models.METALLICS, models.NONMETALLICS} {
+		fmt.Fprintf(b, "  %-14s  %10s\n", strings.ToUpper(kind.String()), commas(r.Stockpile[kind.String()]))
+	}
+	b.WriteString("\nCOLONIES\n")
+	if len(r.Colonies) == 0 {
+		b.WriteString("  NONE\n")
+	}
+	for _, colony := range r.Colonies {
+		fmt.Fprintf(b, "  COLONY %4d  %-20s  %-8s  POPULATION %10s  CAPACITY %10s\n", colony.ID, colony.Location, strings.ToUpper(colony.Kind), commas(colony.Population), commas(colony.Capacity))
+	}
+	b.WriteString("\nFLEETS\n")
+	if len(r.Fleets) == 0 {
+		b.WriteString("  NONE\n")
+	}
+	for _, fleet := range r.Fleets {
+		if fleet.Destination == "" {
+			fmt.Fprintf(b, "  FLEET %5d  AT %-20s  FUEL %6s\n", fleet.ID, fleet.Location, commas(fleet.Fuel))
+		} else {
+			fmt.Fprintf(b, "  FLEET %5d  FROM %s TO %s, ARRIVES TURN %d  FUEL %6s\n", fleet.ID, fleet.Location, fleet.Destination, fleet.Arrives, commas(fleet.Fuel))
+		}
+		for _, class := range sortedKeys(fleet.Ships) {
+			fmt.Fprintf(b, "    SHIPS  %-14s  %10s\n", strings.ToUpper(class), commas(fleet.Ships[class]))
+		}
+		for _, kind := range sortedKeys(fleet.Cargo) {
+			fmt.Fprintf(b, "    CARGO  %-14s  %10s\n", strings.ToUpper(kind), commas(fleet.Cargo[kind]))
+		}
+	}
+	b.WriteString("\nREJECTED ORDERS\n")
+	if len(r.Rejected) == 0 {
+		b.WriteString("  NONE\n")
+	}
+	for _, rejection := range r.Rejected {
+		fmt.Fprintf(b, "  TURN %d  LINE %4d  %s\n", rejection.Turn, rejection.Line, strings.ToUpper(rejection.Reason))
+	}
+	b.WriteString("\nBATTLES\n")
+	if len(r.Battles) == 0 {
+		b.WriteString("  NONE\n")
+	}
+	for _, battle := range r.Battles {
+		fmt.Fprintf(b, "  BATTLE AT %-20s  TURN %d\n", battle.Location, battle.Turn)
+		for _, line := range battle.Log {
+			fmt.Fprintf(b, "    %s\n", strings.ToUpper(line))
+		}
+	}
+	b.WriteString("\n")
+	b.WriteString(rule)
+	if len(r.Systems) == 0 {
+		b.WriteString("\nNO SYSTEMS HAVE BEEN OBSERVED\n")
+	}
+	for _, system := range r.Systems {
+		fmt.Fprintf(b, "\nSYSTEM %3d %3d %3d   STARS %d%s\n", system.X, system.Y, system.Z, len(system.Stars), r.asOf(system.Seen))
+		for _, star := range system.Stars {
+			fmt.Fprintf(b, "  STAR %d  CLASS %-2s %-14s  LUMINOSITY %s%%\n", star.Sequence, star.Class, strings.ToUpper(star.Size), commas(star.Luminosity))
+			if len(star.Orbits) == 0 {
+				b.WriteString("    NOT SURVEYED\n")
+			}
+			for _, orbit := range star.Orbits {
+				fmt.Fprintf(b, "    ORBIT %2d  %-14s  HABITABILITY %2d  DEPOSITS %2d%s\n", orbit.Orbit, strings.ToUpper(orbit.Kind), orbit.Habitability, len(orbit.Deposits), r.asOf(orbit.Seen))
+				for _, deposit := range orbit.Deposits {
+					fmt.Fprintf(b, "      DEPOSIT %2d  %-14s  QUANTITY %10s  YIELD %3d%%%s\n", deposit.Number, strings.ToUpper(deposit.Kind), commas(deposit.Quantity), deposit.YieldPct, r.asOf(deposit.Seen))
+				}
+			}
+		}
+	}
+	b.WriteString("\n")
+	b.WriteString(rule)
+	fmt.Fprintf(b, "END OF REPORT FOR EMPIRE %04d TURN %04d\n", r.Empire, r.Turn)
+	return b.Bytes()
+}
+
+// asOf returns a note if the observation is older than the report.
+func (r *Report) asOf(turn int) string {
+	if turn >= r.Turn {
+		return ""
+	}
+	return fmt.Sprintf("  AS OF TURN %d", turn)
+}
+
+// commas returns the number with thousands separators.
+func commas(n int) string {
+	if n < 0 {
+		return "-" + commas(-n)
+	}
+	s := fmt.Sprintf("%d", n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
+
+// sortedKeys returns the keys of the map in order.
+func sortedKeys(m map[string]int) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
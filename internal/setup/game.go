@@ -0,0 +1,88 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package setup
+
+import (
+	"context"
+	"fmt"
+	"github.com/mdhender/moid/internal/generators/cluster"
+	"github.com/mdhender/moid/internal/models"
+	"github.com/mdhender/moid/internal/reports"
+	"github.com/mdhender/moid/internal/sqlite"
+	"log"
+	"strings"
+)
+
+// Config is the settings for a new game.
+type Config struct {
+	Code            string
+	Name            string // defaults to the code in lower case
+	DisplayName     string // defaults to the name
+	Seed            uint64 // seed for the cluster generator and the turn engine
+	Systems         int    // number of systems; zero for the generator's default
+	Players         []string
+	MinHabitability int // lowest habitability for a homeworld; zero for MinHabitability
+}
+
+// NewGame creates the game, generates its cluster, creates an empire for
+// each player, places the homeworlds, and saves the turn 0 reports.
+func NewGame(ctx context.Context, db *sqlite.Store, cfg Config) (*models.Game, error) {
+	cfg.Code = strings.ToUpper(strings.TrimSpace(cfg.Code))
+	if cfg.Code == "" {
+		return nil, fmt.Errorf("missing code")
+	}
+	if cfg.Name = strings.TrimSpace(cfg.Name); cfg.Name == "" {
+		cfg.Name = strings.ToLower(cfg.Code)
+	}
+	if cfg.DisplayName = strings.TrimSpace(cfg.DisplayName); cfg.DisplayName == "" {
+		cfg.DisplayName = cfg.Name
+	}
+	if cfg.MinHabitability == 0 {
+		cfg.MinHabitability = MinHabitability
+	} else if cfg.MinHabitability < 0 || cfg.MinHabitability > cluster.MaxHabitability {
+		return nil, fmt.Errorf("%d: invalid habitability", cfg.MinHabitability)
+	}
+	var players []string
+	for _, name := range cfg.Players {
+		if name = strings.TrimSpace(name); name != "" {
+			players = append(players, name)
+		}
+	}
+	if len(players) == 0 {
+		return nil, fmt.Errorf("missing players")
+	}
+
+	var options []cluster.Option
+	if cfg.Systems != 0 {
+		options = append(options, cluster.WithSystems(cfg.Systems))
+	}
+	g, err := cluster.New(cfg.Seed, options...)
+	if err != nil {
+		return nil, err
+	}
+	cl, err := g.Generate()
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("setup: %s: seed %d: generated %d systems\n", cfg.Code, cfg.Seed, len(cl.Systems))
+
+	game := &models.Game{
+		Code:        cfg.Code,
+		Name:        cfg.Name,
+		DisplayName: cfg.DisplayName,
+		Seed:        cfg.Seed,
+		Cluster:     cl,
+	}
+	homes, err := PlaceHomeworlds(cl, len(players), cfg.MinHabitability)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", cfg.Code, err)
+	}
+	if err := db.CreateGame(ctx, game, players, Homeworlds(homes)); err != nil {
+		return nil, err
+	}
+	if err := reports.Generate(ctx, db, game.Code); err != nil {
+		return nil, err
+	}
+	log.Printf("setup: %s: created game with %d empires\n", game.Code, len(game.Empires))
+	return game, nil
+}
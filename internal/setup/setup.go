@@ -0,0 +1,263 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+// Package setup places the empires in a new game.
+//
+// Every empire starts in its own home system with an open colony on a
+// terrestrial planet, some mining units, a small stockpile, and a fleet
+// with a scout and a transport. Home systems are chosen so that the
+// closest two empires are as far apart as possible.
+package setup
+
+import (
+	"fmt"
+	"github.com/mdhender/moid/internal/generators/cluster"
+	"github.com/mdhender/moid/internal/models"
+	"math"
+)
+
+// MinHabitability is the default for the lowest habitability a homeworld may have.
+const MinHabitability = 12
+
+// the starting position for every empire.
+const (
+	Population  = 10_000 // colonists on the homeworld, if the planet can support them
+	MiningUnits = 10     // mining units on the homeworld
+)
+
+// Stockpile is the starting stockpile for every empire.
+var Stockpile = map[models.Resource_e]int{
+	models.FUEL:         500,
+	models.METALLICS:    1_000,
+	models.NONMETALLICS: 1_000,
+}
+
+// Deposits are the deposits on a homeworld that terraform creates.
+var Deposits = []*models.Deposit{
+	{Kind: models.FUEL, Quantity: 10_000_000, YieldPct: 50},
+	{Kind: models.METALLICS, Quantity: 40_000_000, YieldPct: 50},
+	{Kind: models.NONMETALLICS, Quantity: 40_000_000, YieldPct: 50},
+}
+
+// Ships are the ships in every empire's starting fleet.
+var Ships = map[string]int{"scout": 1, "transport": 1}
+
+// Homeworld is an empire's starting planet.
+type Homeworld struct {
+	System *models.System
+	Star   *models.Star
+	Orbit  int
+	Planet *models.Planet
+}
+
+// Homeworlds returns a setup function that places every empire on one
+// of the homeworlds from PlaceHomeworlds. The homeworlds must be placed
+// before the cluster is saved, since placing them may change the cluster.
+func Homeworlds(homes []*Homeworld) func(game *models.Game) error {
+	return func(game *models.Game) error {
+		if len(homes) != len(game.Empires) {
+			return fmt.Errorf("%s: %d empires, %d homeworlds", game.Code, len(game.Empires), len(homes))
+		}
+		for i, empire := range game.Empires {
+			settle(game, empire, homes[i])
+		}
+		return nil
+	}
+}
+
+// settle gives the empire its starting position on the homeworld.
+func settle(game *models.Game, empire *models.Empire, home *Homeworld) {
+	colony := &models.Colony{
+		EmpireID: empire.ID,
+		OrbitID:  home.Planet.ID,
+		Kind:     models.OPEN,
+		Founded:  game.CurrentTurn,
+	}
+	colony.Population = min(Population, colony.Capacity(home.Planet.Habitability))
+	game.Colonies = append(game.Colonies, colony)
+
+	game.Mines = append(game.Mines, &models.Mine{
+		EmpireID:    empire.ID,
+		OrbitID:     home.Planet.ID,
+		Units:       MiningUnits,
+		Assignments: map[int]int{},
+	})
+
+	fleet := &models.Fleet{
+		EmpireID: empire.ID,
+		SystemID: home.System.ID,
+		OrbitID:  home.Planet.ID,
+		Ships:    map[string]int{},
+		Cargo:    map[string]int{},
+	}
+	for class, n := range Ships {
+		fleet.Ships[class] = n
+	}
+	fleet.Fuel = fleet.FuelCapacity()
+	game.Fleets = append(game.Fleets, fleet)
+
+	if empire.Stockpile == nil {
+		empire.Stockpile = map[models.Resource_e]int{}
+	}
+	for kind, n := range Stockpile {
+		empire.Stockpile[kind] += n
+	}
+
+	// the empire has surveyed its home system.
+	if empire.Knowledge == nil {
+		empire.Knowledge = models.NewKnowledge()
+	}
+	empire.Knowledge.ObserveOrbits(home.System, game.CurrentTurn)
+	for _, star := range home.System.Stars {
+		for orbit := range star.Orbits {
+			empire.Knowledge.ObserveDeposits(home.System, star, orbit, game.CurrentTurn)
+		}
+	}
+}
+
+// PlaceHomeworlds returns n homeworlds, each in a different system.
+// Only systems with a terrestrial planet with at least the minimum
+// habitability are considered. The systems are chosen to maximize the
+// smallest distance between any two of them; the best planet in each
+// system is the homeworld.
+//
+// If there aren't n such systems, the systems farthest from the ones
+// that qualify are made to qualify; see terraform. This changes the
+// cluster, so it must be called before the cluster is saved.
+//
+// The choice is made greedily: starting from each candidate in turn,
+// the candidate farthest from those already chosen is added until
+// there are n. The start that gives the largest smallest distance wins,
+// with ties going to the earlier start, so the result only depends on
+// the cluster.
+func PlaceHomeworlds(cluster *models.Cluster, n, minHabitability int) ([]*Homeworld, error) {
+	var candidates []*Homeworld
+	for _, system := range cluster.Systems {
+		if home := bestPlanet(system, minHabitability); home != nil {
+			candidates = append(candidates, home)
+		}
+	}
+	if n < 1 {
+		return nil, fmt.Errorf("%d: invalid number of homeworlds", n)
+	} else if n > len(cluster.Systems) {
+		return nil, fmt.Errorf("need %d homeworlds, cluster has %d systems", n, len(cluster.Systems))
+	}
+	for len(candidates) < n {
+		candidates = append(candidates, terraform(farthest(cluster, candidates), minHabitability))
+	}
+
+	var best []int
+	bestDistance := -1.0
+	for start := range candidates {
+		chosen := []int{start}
+		// nearest is the distance from each candidate to the closest chosen one.
+		nearest := make([]float64, len(candidates))
+		for i := range candidates {
+			nearest[i] = distance(candidates[i], candidates[start])
+		}
+		smallest := math.Inf(1)
+		for len(chosen) < n {
+			next := -1
+			for i := range candidates {
+				if nearest[i] > 0 && (next == -1 || nearest[i] > nearest[next]) {
+					next = i
+				}
+			}
+			if next == -1 {
+				break
+			}
+			smallest = min(smallest, nearest[next])
+			chosen = append(chosen, next)
+			for i := range candidates {
+				nearest[i] = min(nearest[i], distance(candidates[i], candidates[next]))
+			}
+		}
+		if len(chosen) == n && smallest > bestDistance {
+			best, bestDistance = chosen, smallest
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("could not place %d homeworlds", n)
+	}
+	var homes []*Homeworld
+	for _, i := range best {
+		homes = append(homes, candidates[i])
+	}
+	return homes, nil
+}
+
+// bestPlanet returns the terrestrial planet in the system with the highest
+// habitability, if it is at least the minimum. Ties go to the first planet.
+func bestPlanet(system *models.System, minHabitability int) *Homeworld {
+	var home *Homeworld
+	for _, star := range system.Stars {
+		for orbit, planet := range star.Orbits {
+			if planet == nil || planet.Kind != models.TERRESTRIAL || planet.Habitability < minHabitability {
+				continue
+			} else if home == nil || planet.Habitability > home.Planet.Habitability {
+				home = &Homeworld{System: system, Star: star, Orbit: orbit, Planet: planet}
+			}
+		}
+	}
+	return home
+}
+
+// farthest returns the system that is farthest from every candidate.
+// Ties go to the first system. If there are no candidates, it returns
+// the first system.
+func farthest(cluster *models.Cluster, candidates []*Homeworld) *models.System {
+	taken := map[*models.System]bool{}
+	for _, home := range candidates {
+		taken[home.System] = true
+	}
+	var far *models.System
+	farDistance := -1.0
+	for _, system := range cluster.Systems {
+		if taken[system] {
+			continue
+		}
+		nearest := math.Inf(1)
+		for _, home := range candidates {
+			nearest = min(nearest, system.EuclideanDistance(home.System.X, home.System.Y, home.System.Z))
+		}
+		if nearest > farDistance {
+			far, farDistance = system, nearest
+		}
+	}
+	return far
+}
+
+// terraform returns a homeworld in the system with the minimum habitability.
+// The system's best terrestrial planet is improved if it has one.
+// Otherwise, a new terrestrial planet with the starting Deposits is
+// created in the empty orbit closest to the first star's habitable zone.
+// If every orbit is full, the planet in the habitable zone is changed
+// to a terrestrial planet, keeping its deposits.
+func terraform(system *models.System, minHabitability int) *Homeworld {
+	if home := bestPlanet(system, 0); home != nil {
+		home.Planet.Habitability = max(home.Planet.Habitability, minHabitability)
+		return home
+	}
+	star := system.Stars[0]
+	zone := min(star.HabitableOrbit(), cluster.MaxOrbits)
+	for offset := 0; offset < cluster.MaxOrbits; offset++ {
+		for _, orbit := range []int{zone - offset, zone + offset} {
+			if orbit < 1 || orbit > cluster.MaxOrbits || star.Orbits[orbit] != nil {
+				continue
+			}
+			planet := &models.Planet{Kind: models.TERRESTRIAL, Habitability: minHabitability}
+			for i, deposit := range Deposits {
+				copied := *deposit
+				planet.NaturalResources[i+1] = &copied
+			}
+			star.Orbits[orbit] = planet
+			return &Homeworld{System: system, Star: star, Orbit: orbit, Planet: planet}
+		}
+	}
+	planet := star.Orbits[zone]
+	planet.Kind, planet.Habitability = models.TERRESTRIAL, minHabitability
+	return &Homeworld{System: system, Star: star, Orbit: zone, Planet: planet}
+}
+
+func distance(a, b *Homeworld) float64 {
+	return a.System.EuclideanDistance(b.System.X, b.System.Y, b.System.Z)
+}
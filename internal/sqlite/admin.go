@@ -0,0 +1,310 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+	"log"
+	"strings"
+)
+
+// ReadAuditLog returns the manual edits to the game, newest first.
+func (s *Store) ReadAuditLog(ctx context.Context, code string) ([]*models.AuditEntry, error) {
+	game, err := getGame(ctx, s.q, code)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.q.ListGameAuditLog(ctx, game.ID)
+	if err != nil {
+		return nil, err
+	}
+	var list []*models.AuditEntry
+	for _, row := range rows {
+		list = append(list, &models.AuditEntry{
+			ID:        int(row.ID),
+			Editor:    row.Editor,
+			Target:    row.Target,
+			Change:    row.Change,
+			Reason:    row.Reason,
+			CreatedAt: row.CreatedAt,
+		})
+	}
+	return list, nil
+}
+
+// ReadPlayerNames returns the name of the player for each empire
+// in the game, keyed by empire id.
+func (s *Store) ReadPlayerNames(ctx context.Context, code string) (map[int]string, error) {
+	game, err := getGame(ctx, s.q, code)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.q.ListGamePlayers(ctx, game.ID)
+	if err != nil {
+		return nil, err
+	}
+	names := map[int]string{}
+	for _, row := range rows {
+		names[int(row.ID)] = row.Name
+	}
+	return names, nil
+}
+
+// RenameGame changes the name and display name of the game.
+func (s *Store) RenameGame(ctx context.Context, code string, name, displayName string, edit models.Edit) error {
+	name, displayName = strings.TrimSpace(name), strings.TrimSpace(displayName)
+	if name == "" || displayName == "" {
+		return fmt.Errorf("name and display name are required")
+	}
+	return s.audit(ctx, code, edit, func(q *Queries, game Games) (string, string, error) {
+		var changes []string
+		if name != game.Name {
+			changes = append(changes, fmt.Sprintf("name %q -> %q", game.Name, name))
+		}
+		if displayName != game.DisplayName {
+			changes = append(changes, fmt.Sprintf("display name %q -> %q", game.DisplayName, displayName))
+		}
+		if len(changes) == 0 {
+			return "", "", nil
+		}
+		err := q.UpdateGameNames(ctx, UpdateGameNamesParams{Name: name, DisplayName: displayName, GameID: game.ID})
+		return "game", strings.Join(changes, ", "), err
+	})
+}
+
+// AdvanceGameTurn runs the game's current turn for the GM. The turn is
+// run the same way as AdvanceTurn, and it is recorded in the audit log in
+// the same transaction.
+func (s *Store) AdvanceGameTurn(ctx context.Context, code string, fn TurnFunc, edit models.Edit) error {
+	return s.audit(ctx, code, edit, func(q *Queries, game Games) (string, string, error) {
+		if _, err := advanceTurn(ctx, q, code, fn); err != nil {
+			return "", "", err
+		}
+		return "game", fmt.Sprintf("ran turn %d, current turn %d -> %d", game.CurrentTurn, game.CurrentTurn, game.CurrentTurn+1), nil
+	})
+}
+
+// RollbackGameTurn rolls the game back to the start of an earlier turn
+// for the GM. The rollback is the same as RollbackGame, and it is recorded
+// in the audit log in the same transaction.
+func (s *Store) RollbackGameTurn(ctx context.Context, code string, turn int, edit models.Edit) error {
+	return s.audit(ctx, code, edit, func(q *Queries, game Games) (string, string, error) {
+		if _, err := rollbackGame(ctx, q, code, turn); err != nil {
+			return "", "", err
+		}
+		return "game", fmt.Sprintf("rolled back, current turn %d -> %d", game.CurrentTurn, turn), nil
+	})
+}
+
+// SetOrbit changes the kind and habitability of a planet in the game.
+// Orbits can't be emptied or filled since that would orphan or create
+// deposits, colonies, and mines.
+func (s *Store) SetOrbit(ctx context.Context, code string, orbitID int, kind models.Planet_e, habitability int, edit models.Edit) error {
+	if kind == 0 {
+		return fmt.Errorf("orbit %d: can't be emptied", orbitID)
+	}
+	text, err := PlanetKindText(kind)
+	if err != nil {
+		return err
+	} else if habitability < 0 || habitability > 25 {
+		return fmt.Errorf("%d: invalid habitability", habitability)
+	}
+	return s.audit(ctx, code, edit, func(q *Queries, game Games) (string, string, error) {
+		target := fmt.Sprintf("orbit %d", orbitID)
+		orbit, err := q.GetGameOrbit(ctx, GetGameOrbitParams{OrbitID: int64(orbitID), GameID: game.ID})
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", "", fmt.Errorf("%s: %w", target, ErrNotFound)
+		} else if err != nil {
+			return "", "", err
+		} else if orbit.Kind == "empty" {
+			return "", "", fmt.Errorf("%s: is empty", target)
+		}
+		var changes []string
+		if text != orbit.Kind {
+			changes = append(changes, fmt.Sprintf("kind %s -> %s", orbit.Kind, text))
+		}
+		if int64(habitability) != orbit.Habitability {
+			changes = append(changes, fmt.Sprintf("habitability %d -> %d", orbit.Habitability, habitability))
+		}
+		if len(changes) == 0 {
+			return "", "", nil
+		}
+		err = q.UpdateOrbit(ctx, UpdateOrbitParams{Kind: text, Habitability: int64(habitability), OrbitID: int64(orbitID)})
+		return target, strings.Join(changes, ", "), err
+	})
+}
+
+// SetDeposit changes the quantity and yield of a deposit in the game.
+func (s *Store) SetDeposit(ctx context.Context, code string, depositID int, quantity, yieldPct int, edit models.Edit) error {
+	if quantity < 0 || quantity > 99_000_000 {
+		return fmt.Errorf("%d: invalid quantity", quantity)
+	} else if yieldPct < 0 || yieldPct > 100 {
+		return fmt.Errorf("%d: invalid yield", yieldPct)
+	}
+	return s.audit(ctx, code, edit, func(q *Queries, game Games) (string, string, error) {
+		target := fmt.Sprintf("deposit %d", depositID)
+		deposit, err := q.GetGameNaturalResource(ctx, GetGameNaturalResourceParams{DepositID: int64(depositID), GameID: game.ID})
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", "", fmt.Errorf("%s: %w", target, ErrNotFound)
+		} else if err != nil {
+			return "", "", err
+		}
+		var changes []string
+		if int64(quantity) != deposit.Quantity {
+			changes = append(changes, fmt.Sprintf("quantity %d -> %d", deposit.Quantity, quantity))
+		}
+		if int64(yieldPct) != deposit.YieldPct {
+			changes = append(changes, fmt.Sprintf("yield %d%% -> %d%%", deposit.YieldPct, yieldPct))
+		}
+		if len(changes) == 0 {
+			return "", "", nil
+		}
+		err = q.UpdateNaturalResource(ctx, UpdateNaturalResourceParams{Quantity: int64(quantity), YieldPct: int64(yieldPct), DepositID: int64(depositID)})
+		return target + " (" + deposit.Kind + ")", strings.Join(changes, ", "), err
+	})
+}
+
+// SetEmpirePlayer gives control of the empire to the player with the name,
+// creating the player if needed. A player can only control one empire in
+// a game. The change is not undone by rolling back the game.
+func (s *Store) SetEmpirePlayer(ctx context.Context, code string, empireID int, name string, edit models.Edit) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("missing player")
+	}
+	return s.audit(ctx, code, edit, func(q *Queries, game Games) (string, string, error) {
+		target := fmt.Sprintf("empire %d", empireID)
+		empire, err := q.GetGameEmpire(ctx, GetGameEmpireParams{EmpireID: int64(empireID), GameID: game.ID})
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", "", fmt.Errorf("%s: %w", target, ErrNotFound)
+		} else if err != nil {
+			return "", "", err
+		} else if empire.Name == name {
+			return "", "", nil
+		}
+		playerID, err := q.GetPlayerByName(ctx, name)
+		if errors.Is(err, sql.ErrNoRows) {
+			if playerID, err = q.CreatePlayer(ctx, name); err != nil {
+				return "", "", err
+			}
+		} else if err != nil {
+			return "", "", err
+		} else if n, err := q.CountGamePlayerEmpires(ctx, CountGamePlayerEmpiresParams{GameID: game.ID, PlayerID: playerID}); err != nil {
+			return "", "", err
+		} else if n != 0 {
+			return "", "", fmt.Errorf("player %q: already controls an empire", name)
+		}
+		err = q.UpdateEmpirePlayer(ctx, UpdateEmpirePlayerParams{PlayerID: playerID, EmpireID: empire.ID})
+		return target, fmt.Sprintf("player %q -> %q", empire.Name, name), err
+	})
+}
+
+// SetStockpile changes the quantity of a resource an empire has on hand.
+func (s *Store) SetStockpile(ctx context.Context, code string, empireID int, kind models.Resource_e, quantity int, edit models.Edit) error {
+	text, err := ResourceKindText(kind)
+	if err != nil {
+		return err
+	} else if quantity < 0 {
+		return fmt.Errorf("%d: invalid quantity", quantity)
+	}
+	return s.audit(ctx, code, edit, func(q *Queries, game Games) (string, string, error) {
+		target := fmt.Sprintf("empire %d", empireID)
+		if _, err := q.GetGameEmpire(ctx, GetGameEmpireParams{EmpireID: int64(empireID), GameID: game.ID}); errors.Is(err, sql.ErrNoRows) {
+			return "", "", fmt.Errorf("%s: %w", target, ErrNotFound)
+		} else if err != nil {
+			return "", "", err
+		}
+		// an empire without a row for the resource has none on hand.
+		current, err := q.GetStockpile(ctx, GetStockpileParams{EmpireID: int64(empireID), Kind: text})
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return "", "", err
+		} else if current == int64(quantity) {
+			return "", "", nil
+		}
+		err = q.UpsertStockpile(ctx, UpsertStockpileParams{EmpireID: int64(empireID), Kind: text, Quantity: int64(quantity)})
+		return target, fmt.Sprintf("stockpile %s %d -> %d", text, current, quantity), err
+	})
+}
+
+// SetStar changes the class, size, and luminosity of a star in the game.
+// The planets around the star are not changed.
+func (s *Store) SetStar(ctx context.Context, code string, starID int, class models.Spectral_e, size models.StarSize_e, luminosity int, edit models.Edit) error {
+	classText, err := SpectralClassText(class)
+	if err != nil {
+		return err
+	}
+	sizeText, err := StarSizeText(size)
+	if err != nil {
+		return err
+	} else if luminosity < 1 {
+		return fmt.Errorf("%d: invalid luminosity", luminosity)
+	}
+	return s.audit(ctx, code, edit, func(q *Queries, game Games) (string, string, error) {
+		target := fmt.Sprintf("star %d", starID)
+		star, err := q.GetGameStar(ctx, GetGameStarParams{StarID: int64(starID), GameID: game.ID})
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", "", fmt.Errorf("%s: %w", target, ErrNotFound)
+		} else if err != nil {
+			return "", "", err
+		}
+		var changes []string
+		if classText != star.Class {
+			changes = append(changes, fmt.Sprintf("class %s -> %s", star.Class, classText))
+		}
+		if sizeText != star.Size {
+			changes = append(changes, fmt.Sprintf("size %s -> %s", star.Size, sizeText))
+		}
+		if int64(luminosity) != star.Luminosity {
+			changes = append(changes, fmt.Sprintf("luminosity %d -> %d", star.Luminosity, luminosity))
+		}
+		if len(changes) == 0 {
+			return "", "", nil
+		}
+		err = q.UpdateStar(ctx, UpdateStarParams{Class: classText, Size: sizeText, Luminosity: int64(luminosity), StarID: int64(starID)})
+		return target, strings.Join(changes, ", "), err
+	})
+}
+
+// auditFunc makes a change to the game and returns what was changed
+// and a description of the change. An empty change means there was
+// nothing to do and nothing is recorded.
+type auditFunc func(q *Queries, game Games) (target, change string, err error)
+
+// audit runs the change and records it in the audit log in a single
+// transaction, so there are no changes without a record of them.
+func (s *Store) audit(ctx context.Context, code string, edit models.Edit, fn auditFunc) error {
+	edit.Editor, edit.Reason = strings.TrimSpace(edit.Editor), strings.TrimSpace(edit.Reason)
+	if edit.Editor == "" {
+		return fmt.Errorf("missing editor")
+	} else if edit.Reason == "" {
+		return fmt.Errorf("missing reason")
+	}
+
+	var target, change string
+	err := s.InTx(ctx, func(q *Queries) error {
+		game, err := getGame(ctx, q, code)
+		if err != nil {
+			return err
+		}
+		target, change, err = fn(q, game)
+		if err != nil || change == "" {
+			return err
+		}
+		return q.CreateAuditEntry(ctx, CreateAuditEntryParams{
+			GameID: game.ID,
+			Editor: edit.Editor,
+			Target: target,
+			Change: change,
+			Reason: edit.Reason,
+		})
+	})
+	if err != nil || change == "" {
+		return err
+	}
+	log.Printf("store: %s: %s: %s: %s (%s)\n", code, edit.Editor, target, change, edit.Reason)
+	return nil
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+	"time"
+)
+
+// ErrDuplicateArticle is returned when another article has the slug.
+var ErrDuplicateArticle = errors.New("duplicate article")
+
+// CreateArticle saves a new article and sets its ID.
+// It returns ErrDuplicateArticle if the slug is already used.
+func (s *Store) CreateArticle(ctx context.Context, article *models.Article) error {
+	var id int64
+	err := s.InTx(ctx, func(q *Queries) error {
+		if err := checkSlug(ctx, q, article.Slug, 0); err != nil {
+			return err
+		}
+		params := CreateArticleParams{
+			Title:       article.Title,
+			Slug:        article.Slug,
+			DateUpdated: article.DateUpdated,
+		}
+		if article.Published {
+			params.Published = 1
+		}
+		if !article.DatePublished.IsZero() {
+			params.DatePublished = sql.NullTime{Time: article.DatePublished, Valid: true}
+		}
+		var err error
+		id, err = q.CreateArticle(ctx, params)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	article.ID = int(id)
+	return nil
+}
+
+// ReadArticle returns the article with the given id.
+// It returns ErrNotFound if there is no such article.
+func (s *Store) ReadArticle(ctx context.Context, id int) (*models.Article, error) {
+	row, err := s.q.GetArticleByID(ctx, int64(id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("article %d: %w", id, ErrNotFound)
+	} else if err != nil {
+		return nil, err
+	}
+	return articleModel(row), nil
+}
+
+// ReadArticleBySlug returns the article with the given slug.
+// It returns ErrNotFound if there is no such article.
+func (s *Store) ReadArticleBySlug(ctx context.Context, slug string) (*models.Article, error) {
+	row, err := s.q.GetArticleBySlug(ctx, slug)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("article %q: %w", slug, ErrNotFound)
+	} else if err != nil {
+		return nil, err
+	}
+	return articleModel(row), nil
+}
+
+// ReadPublishedArticles returns the published articles, newest first.
+func (s *Store) ReadPublishedArticles(ctx context.Context) ([]*models.Article, error) {
+	rows, err := s.q.ListPublishedArticles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return articleModels(rows), nil
+}
+
+// ReadDraftArticles returns the unpublished articles, newest first.
+func (s *Store) ReadDraftArticles(ctx context.Context) ([]*models.Article, error) {
+	rows, err := s.q.ListDraftArticles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return articleModels(rows), nil
+}
+
+// ReadRecentArticles returns up to limit of the most recently
+// published articles.
+func (s *Store) ReadRecentArticles(ctx context.Context, limit int) ([]*models.Article, error) {
+	rows, err := s.q.ListRecentArticles(ctx, int64(limit))
+	if err != nil {
+		return nil, err
+	}
+	return articleModels(rows), nil
+}
+
+// UpdateArticle saves the article's title and slug.
+// It returns ErrNotFound if the article doesn't exist and
+// ErrDuplicateArticle if another article has the slug.
+func (s *Store) UpdateArticle(ctx context.Context, article *models.Article, now time.Time) error {
+	return s.InTx(ctx, func(q *Queries) error {
+		if err := checkSlug(ctx, q, article.Slug, article.ID); err != nil {
+			return err
+		}
+		n, err := q.UpdateArticle(ctx, UpdateArticleParams{
+			Title:       article.Title,
+			Slug:        article.Slug,
+			DateUpdated: now,
+			ArticleID:   int64(article.ID),
+		})
+		if err != nil {
+			return err
+		} else if n == 0 {
+			return fmt.Errorf("article %d: %w", article.ID, ErrNotFound)
+		}
+		return nil
+	})
+}
+
+// PublishArticle publishes the article. The publication date is only
+// set the first time an article is published.
+// It returns ErrNotFound if the article doesn't exist.
+func (s *Store) PublishArticle(ctx context.Context, id int, now time.Time) error {
+	n, err := s.q.PublishArticle(ctx, PublishArticleParams{DateUpdated: now, ArticleID: int64(id)})
+	return articleChanged(id, n, err)
+}
+
+// UnpublishArticle returns the article to the drafts.
+// It returns ErrNotFound if the article doesn't exist.
+func (s *Store) UnpublishArticle(ctx context.Context, id int, now time.Time) error {
+	n, err := s.q.UnpublishArticle(ctx, UnpublishArticleParams{DateUpdated: now, ArticleID: int64(id)})
+	return articleChanged(id, n, err)
+}
+
+// DeleteArticle deletes the article.
+// It returns ErrNotFound if the article doesn't exist.
+func (s *Store) DeleteArticle(ctx context.Context, id int) error {
+	n, err := s.q.DeleteArticle(ctx, int64(id))
+	return articleChanged(id, n, err)
+}
+
+// checkSlug returns ErrDuplicateArticle if an article other than
+// the one with the given id has the slug.
+func checkSlug(ctx context.Context, q *Queries, slug string, id int) error {
+	row, err := q.GetArticleBySlug(ctx, slug)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	} else if err != nil {
+		return err
+	} else if row.ID != int64(id) {
+		return fmt.Errorf("%q: %w", slug, ErrDuplicateArticle)
+	}
+	return nil
+}
+
+// articleChanged turns an update that touched no rows into ErrNotFound.
+func articleChanged(id int, n int64, err error) error {
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return fmt.Errorf("article %d: %w", id, ErrNotFound)
+	}
+	return nil
+}
+
+func articleModel(row Articles) *models.Article {
+	return &models.Article{
+		ID:            int(row.ID),
+		Title:         row.Title,
+		Slug:          row.Slug,
+		Published:     row.Published == 1,
+		DatePublished: row.DatePublished.Time,
+		DateUpdated:   row.DateUpdated,
+	}
+}
+
+func articleModels(rows []Articles) []*models.Article {
+	var articles []*models.Article
+	for _, row := range rows {
+		articles = append(articles, articleModel(row))
+	}
+	return articles
+}
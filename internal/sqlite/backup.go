@@ -0,0 +1,60 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Path returns the absolute path to the database file.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// VacuumInto writes a consistent copy of the live database to the path,
+// which must not exist. Other connections can keep reading and writing
+// while the copy is made.
+func (s *Store) VacuumInto(ctx context.Context, path string) error {
+	if _, err := s.db.ExecContext(ctx, `VACUUM INTO ?1`, path); err != nil {
+		return fmt.Errorf("vacuum into: %w", err)
+	}
+	return nil
+}
+
+// CheckIntegrity opens the database at the path read-only and runs
+// PRAGMA integrity_check. It returns an error listing the problems
+// if the database is damaged.
+func CheckIntegrity(ctx context.Context, path string) error {
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	rows, err := db.QueryContext(ctx, `PRAGMA integrity_check`)
+	if err != nil {
+		return fmt.Errorf("%s: integrity check: %w", path, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	var problems []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return err
+		} else if line != "ok" {
+			problems = append(problems, line)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("%s: integrity check: %w", path, err)
+	} else if len(problems) != 0 {
+		return fmt.Errorf("%s: integrity check: %s", path, strings.Join(problems, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+	"strings"
+)
+
+// ReadBattles returns the battles fought in the game on the turn,
+// keyed by empire id. Each empire's battles are in the order they
+// were fought.
+func (s *Store) ReadBattles(ctx context.Context, code string, turn int) (map[int][]*models.Battle, error) {
+	game, err := getGame(ctx, s.q, code)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.q.ListGameBattles(ctx, ListGameBattlesParams{GameID: game.ID, Turn: int64(turn)})
+	if err != nil {
+		return nil, err
+	}
+	battles := map[int][]*models.Battle{}
+	for _, row := range rows {
+		battle := &models.Battle{
+			ID:       int(row.ID),
+			EmpireID: int(row.EmpireID),
+			SystemID: int(row.SystemID),
+			Turn:     int(row.Turn),
+			Log:      strings.Split(row.Log, "\n"),
+		}
+		battles[battle.EmpireID] = append(battles[battle.EmpireID], battle)
+	}
+	return battles, nil
+}
+
+// saveBattles records the battles the empire fought on the turn.
+// The battles are cleared once they are saved so that they aren't
+// saved twice.
+func saveBattles(ctx context.Context, q *Queries, empire *models.Empire) error {
+	for _, battle := range empire.Battles {
+		if err := q.CreateBattle(ctx, CreateBattleParams{
+			EmpireID: int64(empire.ID),
+			SystemID: int64(battle.SystemID),
+			Turn:     int64(battle.Turn),
+			Log:      strings.Join(battle.Log, "\n"),
+		}); err != nil {
+			return fmt.Errorf("empire %d: system %d: battle: %w", empire.ID, battle.SystemID, err)
+		}
+	}
+	empire.Battles = nil
+	return nil
+}
@@ -0,0 +1,476 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+	"log"
+)
+
+// CreateCluster saves a new cluster for the game with the given code.
+// It returns an error if the game already has a cluster.
+//
+// The cluster is written inside a single transaction. On success,
+// the IDs of the systems, stars, planets, deposits, and warps are updated.
+// The transaction isn't retried since the IDs are set as it goes.
+func (s *Store) CreateCluster(ctx context.Context, code string, cluster *models.Cluster) error {
+	err := s.inTxOnce(ctx, func(q *Queries) error {
+		game, err := getGame(ctx, q, code)
+		if err != nil {
+			return err
+		}
+		if n, err := q.CountGameSystems(ctx, game.ID); err != nil {
+			return err
+		} else if n != 0 {
+			return fmt.Errorf("%s: game already has a cluster", code)
+		}
+		return saveCluster(ctx, q, game.ID, cluster)
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("store: %s: created cluster with %d systems\n", code, len(cluster.Systems))
+	return nil
+}
+
+// SaveCluster writes the cluster for the game with the given code.
+// Systems with a zero ID are created; all others are updated.
+// Stars, orbits, and deposits are matched by their sequence, orbit
+// and deposit numbers. The warps in the game are replaced.
+//
+// The cluster is written inside a single transaction. On success,
+// the IDs of the systems, stars, planets, deposits, and warps are updated.
+//
+// Deposits that have been removed from a planet are not deleted
+// unless the entire orbit is now empty. Like CreateCluster, the
+// transaction isn't retried.
+func (s *Store) SaveCluster(ctx context.Context, code string, cluster *models.Cluster) error {
+	return s.inTxOnce(ctx, func(q *Queries) error {
+		game, err := getGame(ctx, q, code)
+		if err != nil {
+			return err
+		}
+		return saveCluster(ctx, q, game.ID, cluster)
+	})
+}
+
+// SaveWarps replaces the warps for the game with the given code.
+// The systems must already be saved. The IDs of the warps are updated,
+// so the transaction isn't retried.
+func (s *Store) SaveWarps(ctx context.Context, code string, warps []*models.Warp) error {
+	err := s.inTxOnce(ctx, func(q *Queries) error {
+		game, err := getGame(ctx, q, code)
+		if err != nil {
+			return err
+		}
+		return saveWarps(ctx, q, game.ID, warps)
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("store: %s: saved %d warps\n", code, len(warps))
+	return nil
+}
+
+// ReadCluster loads the entire cluster for the game with the given code.
+// It uses one query for each level of the hierarchy, no matter how
+// many systems there are in the cluster.
+func (s *Store) ReadCluster(ctx context.Context, code string) (cluster *models.Cluster, err error) {
+	// use a transaction so that all the queries see the same data.
+	err = s.InReadTx(ctx, func(q *Queries) error {
+		game, err := getGame(ctx, q, code)
+		if err != nil {
+			return err
+		}
+		cluster, err = readCluster(ctx, q, game.ID)
+		return err
+	})
+	return cluster, err
+}
+
+// ReadClusterSystems loads the systems, stars, and warps for the game
+// with the given code. The orbits of the stars are not loaded.
+func (s *Store) ReadClusterSystems(ctx context.Context, code string) (cluster *models.Cluster, err error) {
+	err = s.InReadTx(ctx, func(q *Queries) error {
+		game, err := getGame(ctx, q, code)
+		if err != nil {
+			return err
+		}
+		cluster, _, err = readSystems(ctx, q, game.ID)
+		return err
+	})
+	return cluster, err
+}
+
+func readCluster(ctx context.Context, q *Queries, gameID int64) (*models.Cluster, error) {
+	cluster, stars, err := readSystems(ctx, q, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	planets := map[int64]*models.Planet{}
+	if rows, err := q.ListGameOrbits(ctx, gameID); err != nil {
+		return nil, err
+	} else {
+		for _, row := range rows {
+			star, ok := stars[row.StarID]
+			if !ok {
+				return nil, fmt.Errorf("orbit %d: star %d: not found", row.ID, row.StarID)
+			} else if row.Orbit < 1 || row.Orbit >= int64(len(star.Orbits)) {
+				return nil, fmt.Errorf("orbit %d: invalid orbit %d", row.ID, row.Orbit)
+			}
+			kind, err := PlanetKind(row.Kind)
+			if err != nil {
+				return nil, fmt.Errorf("orbit %d: %w", row.ID, err)
+			} else if kind == 0 {
+				// empty orbits are nil
+				continue
+			}
+			planet := &models.Planet{ID: int(row.ID), Kind: kind, Habitability: int(row.Habitability)}
+			planets[row.ID] = planet
+			star.Orbits[row.Orbit] = planet
+		}
+	}
+
+	if rows, err := q.ListGameNaturalResources(ctx, gameID); err != nil {
+		return nil, err
+	} else {
+		for _, row := range rows {
+			planet, ok := planets[row.OrbitID]
+			if !ok {
+				return nil, fmt.Errorf("deposit %d: orbit %d: not found", row.ID, row.OrbitID)
+			} else if row.DepositNo < 1 || row.DepositNo >= int64(len(planet.NaturalResources)) {
+				return nil, fmt.Errorf("deposit %d: invalid deposit number %d", row.ID, row.DepositNo)
+			}
+			kind, err := ResourceKind(row.Kind)
+			if err != nil {
+				return nil, fmt.Errorf("deposit %d: %w", row.ID, err)
+			}
+			planet.NaturalResources[row.DepositNo] = &models.Deposit{
+				ID:       int(row.ID),
+				Kind:     kind,
+				Quantity: int(row.Quantity),
+				YieldPct: int(row.YieldPct),
+			}
+		}
+	}
+
+	return cluster, nil
+}
+
+// readSystems loads the systems, stars, and warps in a game.
+// It returns the stars indexed by ID so that callers can attach orbits.
+func readSystems(ctx context.Context, q *Queries, gameID int64) (*models.Cluster, map[int64]*models.Star, error) {
+	cluster := &models.Cluster{ID: int(gameID)}
+	systems := map[int64]*models.System{}
+	if rows, err := q.ListGameSystems(ctx, gameID); err != nil {
+		return nil, nil, err
+	} else {
+		for _, row := range rows {
+			system := &models.System{ID: int(row.ID), X: int(row.X), Y: int(row.Y), Z: int(row.Z)}
+			systems[row.ID] = system
+			cluster.Systems = append(cluster.Systems, system)
+		}
+	}
+
+	stars := map[int64]*models.Star{}
+	if rows, err := q.ListGameStars(ctx, gameID); err != nil {
+		return nil, nil, err
+	} else {
+		for _, row := range rows {
+			system, ok := systems[row.SystemID]
+			if !ok {
+				return nil, nil, fmt.Errorf("star %d: system %d: not found", row.ID, row.SystemID)
+			}
+			star, err := readStar(row)
+			if err != nil {
+				return nil, nil, err
+			}
+			stars[row.ID] = star
+			system.Stars = append(system.Stars, star)
+		}
+	}
+
+	if rows, err := q.ListGameWarps(ctx, gameID); err != nil {
+		return nil, nil, err
+	} else {
+		for _, row := range rows {
+			from, ok := systems[row.FromSystemID]
+			if !ok {
+				return nil, nil, fmt.Errorf("warp %d: system %d: not found", row.ID, row.FromSystemID)
+			}
+			to, ok := systems[row.ToSystemID]
+			if !ok {
+				return nil, nil, fmt.Errorf("warp %d: system %d: not found", row.ID, row.ToSystemID)
+			}
+			cluster.Warps = append(cluster.Warps, &models.Warp{ID: int(row.ID), From: from, To: to})
+		}
+	}
+
+	return cluster, stars, nil
+}
+
+func saveCluster(ctx context.Context, q *Queries, gameID int64, cluster *models.Cluster) error {
+	for _, system := range cluster.Systems {
+		systemID := int64(system.ID)
+		if systemID == 0 {
+			id, err := q.CreateSystem(ctx, CreateSystemParams{
+				GameID: gameID,
+				X:      int64(system.X),
+				Y:      int64(system.Y),
+				Z:      int64(system.Z),
+			})
+			if err != nil {
+				return fmt.Errorf("system %d %d %d: %w", system.X, system.Y, system.Z, err)
+			}
+			systemID = id
+		} else if err := q.UpdateSystem(ctx, UpdateSystemParams{
+			X:        int64(system.X),
+			Y:        int64(system.Y),
+			Z:        int64(system.Z),
+			SystemID: systemID,
+		}); err != nil {
+			return fmt.Errorf("system %d %d %d: %w", system.X, system.Y, system.Z, err)
+		}
+		system.ID = int(systemID)
+		for _, star := range system.Stars {
+			class, err := SpectralClassText(star.Class)
+			if err != nil {
+				return fmt.Errorf("system %d %d %d: star %d: %w", system.X, system.Y, system.Z, star.Sequence, err)
+			}
+			size, err := StarSizeText(star.Size)
+			if err != nil {
+				return fmt.Errorf("system %d %d %d: star %d: %w", system.X, system.Y, system.Z, star.Sequence, err)
+			}
+			starID, err := q.UpsertStar(ctx, UpsertStarParams{
+				SystemID:   systemID,
+				Sequence:   int64(star.Sequence),
+				Class:      class,
+				Size:       size,
+				Luminosity: int64(star.Luminosity),
+			})
+			if err != nil {
+				return fmt.Errorf("system %d %d %d: star %d: %w", system.X, system.Y, system.Z, star.Sequence, err)
+			}
+			star.ID = int(starID)
+			for orbit := 1; orbit < len(star.Orbits); orbit++ {
+				if err := saveOrbit(ctx, q, starID, orbit, star.Orbits[orbit]); err != nil {
+					return fmt.Errorf("system %d %d %d: star %d: orbit %d: %w", system.X, system.Y, system.Z, star.Sequence, orbit, err)
+				}
+			}
+		}
+	}
+	return saveWarps(ctx, q, gameID, cluster.Warps)
+}
+
+// saveWarps replaces all the warps in the game.
+// The systems must have been saved first.
+func saveWarps(ctx context.Context, q *Queries, gameID int64, warps []*models.Warp) error {
+	if err := q.DeleteGameWarps(ctx, gameID); err != nil {
+		return err
+	}
+	for _, warp := range warps {
+		from, to := int64(warp.From.ID), int64(warp.To.ID)
+		if from == 0 || to == 0 {
+			return fmt.Errorf("warp %d %d %d: system has not been saved", warp.From.X, warp.From.Y, warp.From.Z)
+		} else if to < from {
+			from, to = to, from
+		}
+		id, err := q.CreateWarp(ctx, CreateWarpParams{
+			GameID:       gameID,
+			FromSystemID: from,
+			ToSystemID:   to,
+		})
+		if err != nil {
+			return fmt.Errorf("warp %d %d %d: %w", warp.From.X, warp.From.Y, warp.From.Z, err)
+		}
+		warp.ID = int(id)
+	}
+	return nil
+}
+
+// saveOrbit writes the orbit and any deposits on the planet.
+// A nil planet is saved as an empty orbit.
+func saveOrbit(ctx context.Context, q *Queries, starID int64, orbit int, planet *models.Planet) error {
+	kind, habitability := "empty", 0
+	if planet != nil {
+		var err error
+		if kind, err = PlanetKindText(planet.Kind); err != nil {
+			return err
+		}
+		habitability = planet.Habitability
+	}
+	orbitID, err := q.UpsertOrbit(ctx, UpsertOrbitParams{
+		StarID:       starID,
+		Orbit:        int64(orbit),
+		Kind:         kind,
+		Habitability: int64(habitability),
+	})
+	if err != nil {
+		return err
+	} else if planet == nil {
+		return q.DeleteOrbitNaturalResources(ctx, orbitID)
+	}
+	planet.ID = int(orbitID)
+	for depositNo := 1; depositNo < len(planet.NaturalResources); depositNo++ {
+		deposit := planet.NaturalResources[depositNo]
+		if deposit == nil {
+			continue
+		}
+		kind, err := ResourceKindText(deposit.Kind)
+		if err != nil {
+			return fmt.Errorf("deposit %d: %w", depositNo, err)
+		}
+		depositID, err := q.UpsertNaturalResource(ctx, UpsertNaturalResourceParams{
+			OrbitID:   orbitID,
+			DepositNo: int64(depositNo),
+			Kind:      kind,
+			Quantity:  int64(deposit.Quantity),
+			YieldPct:  int64(deposit.YieldPct),
+		})
+		if err != nil {
+			return fmt.Errorf("deposit %d: %w", depositNo, err)
+		}
+		deposit.ID = int(depositID)
+	}
+	return nil
+}
+
+// PlanetKind returns the planet kind for the value of orbits.kind.
+// It returns zero for empty orbits and an error for unknown values.
+func PlanetKind(text string) (models.Planet_e, error) {
+	switch text {
+	case "asteroid":
+		return models.ASTEROID_BELT, nil
+	case "empty":
+		return 0, nil
+	case "gas-giant":
+		return models.GAS_GIANT, nil
+	case "terrestrial":
+		return models.TERRESTRIAL, nil
+	}
+	return 0, fmt.Errorf("%q: unknown orbit kind", text)
+}
+
+// PlanetKindText returns the value of orbits.kind for the planet.
+func PlanetKindText(kind models.Planet_e) (string, error) {
+	switch kind {
+	case models.ASTEROID_BELT:
+		return "asteroid", nil
+	case models.GAS_GIANT:
+		return "gas-giant", nil
+	case models.TERRESTRIAL:
+		return "terrestrial", nil
+	}
+	return "", fmt.Errorf("%d: invalid planet kind", kind)
+}
+
+// SpectralClass returns the spectral class for the value of stars.class.
+// It returns an error for unknown values.
+func SpectralClass(text string) (models.Spectral_e, error) {
+	switch text {
+	case "O":
+		return models.CLASS_O, nil
+	case "B":
+		return models.CLASS_B, nil
+	case "A":
+		return models.CLASS_A, nil
+	case "F":
+		return models.CLASS_F, nil
+	case "G":
+		return models.CLASS_G, nil
+	case "K":
+		return models.CLASS_K, nil
+	case "M":
+		return models.CLASS_M, nil
+	}
+	return 0, fmt.Errorf("%q: unknown spectral class", text)
+}
+
+// SpectralClassText returns the value of stars.class for the star.
+func SpectralClassText(class models.Spectral_e) (string, error) {
+	switch class {
+	case models.CLASS_O, models.CLASS_B, models.CLASS_A, models.CLASS_F, models.CLASS_G, models.CLASS_K, models.CLASS_M:
+		return class.String(), nil
+	}
+	return "", fmt.Errorf("%d: invalid spectral class", class)
+}
+
+// StarSize returns the star size for the value of stars.size.
+// It returns an error for unknown values.
+func StarSize(text string) (models.StarSize_e, error) {
+	switch text {
+	case "main-sequence":
+		return models.MAIN_SEQUENCE, nil
+	case "giant":
+		return models.GIANT, nil
+	case "supergiant":
+		return models.SUPERGIANT, nil
+	}
+	return 0, fmt.Errorf("%q: unknown star size", text)
+}
+
+// StarSizeText returns the value of stars.size for the star.
+func StarSizeText(size models.StarSize_e) (string, error) {
+	switch size {
+	case models.MAIN_SEQUENCE:
+		return "main-sequence", nil
+	case models.GIANT:
+		return "giant", nil
+	case models.SUPERGIANT:
+		return "supergiant", nil
+	}
+	return "", fmt.Errorf("%d: invalid star size", size)
+}
+
+// readStar converts a row from the stars table to a star.
+func readStar(row Stars) (*models.Star, error) {
+	class, err := SpectralClass(row.Class)
+	if err != nil {
+		return nil, fmt.Errorf("star %d: %w", row.ID, err)
+	}
+	size, err := StarSize(row.Size)
+	if err != nil {
+		return nil, fmt.Errorf("star %d: %w", row.ID, err)
+	}
+	return &models.Star{
+		ID:         int(row.ID),
+		Sequence:   int(row.Sequence),
+		Class:      class,
+		Size:       size,
+		Luminosity: int(row.Luminosity),
+	}, nil
+}
+
+// ResourceKind returns the resource kind for the value of natural_resources.kind.
+// It returns an error for unknown values.
+func ResourceKind(text string) (models.Resource_e, error) {
+	switch text {
+	case "fuel":
+		return models.FUEL, nil
+	case "gold":
+		return models.GOLD, nil
+	case "metallic":
+		return models.METALLICS, nil
+	case "non-metallic":
+		return models.NONMETALLICS, nil
+	}
+	return 0, fmt.Errorf("%q: unknown resource kind", text)
+}
+
+// ResourceKindText returns the value of natural_resources.kind for the deposit.
+func ResourceKindText(kind models.Resource_e) (string, error) {
+	switch kind {
+	case models.FUEL:
+		return "fuel", nil
+	case models.GOLD:
+		return "gold", nil
+	case models.METALLICS:
+		return "metallic", nil
+	case models.NONMETALLICS:
+		return "non-metallic", nil
+	}
+	return "", fmt.Errorf("%d: invalid resource kind", kind)
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+	"log"
+)
+
+// AddColony adds colonists and enclosures to the empire's colony on the
+// orbit, founding the colony if needed. The orbit must be in the game.
+// It returns the colony.
+func (s *Store) AddColony(ctx context.Context, code string, empireID, orbitID int, kind models.Colony_e, population, enclosures int) (*models.Colony, error) {
+	if population < 0 {
+		return nil, fmt.Errorf("%d: invalid population", population)
+	} else if enclosures < 0 || (kind == models.OPEN && enclosures != 0) {
+		return nil, fmt.Errorf("%d: invalid number of enclosures", enclosures)
+	}
+
+	var colony *models.Colony
+	err := s.InTx(ctx, func(q *Queries) error {
+		game, err := getGame(ctx, q, code)
+		if err != nil {
+			return err
+		}
+		if err := checkEmpire(ctx, q, code, empireID); err != nil {
+			return err
+		}
+		colonies, err := readColonies(ctx, q, game.ID)
+		if err != nil {
+			return err
+		}
+		colony = &models.Colony{EmpireID: empireID, OrbitID: orbitID, Kind: kind, Founded: int(game.CurrentTurn)}
+		for _, c := range colonies {
+			if c.EmpireID == empireID && c.OrbitID == orbitID && c.Kind == kind {
+				colony = c
+				break
+			}
+		}
+		colony.Population += population
+		colony.Enclosures += enclosures
+		return saveColony(ctx, q, colony)
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("store: %s: empire %d: colony %d: %s: population %d\n", code, empireID, colony.ID, colony.Kind, colony.Population)
+	return colony, nil
+}
+
+// readColonies loads the colonies in the game.
+func readColonies(ctx context.Context, q *Queries, gameID int64) ([]*models.Colony, error) {
+	rows, err := q.ListGameColonies(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	var list []*models.Colony
+	for _, row := range rows {
+		kind, err := ColonyKind(row.Kind)
+		if err != nil {
+			return nil, fmt.Errorf("colony %d: %w", row.ID, err)
+		}
+		list = append(list, &models.Colony{
+			ID:         int(row.ID),
+			EmpireID:   int(row.EmpireID),
+			OrbitID:    int(row.OrbitID),
+			Kind:       kind,
+			Population: int(row.Population),
+			Enclosures: int(row.Enclosures),
+			Founded:    int(row.Founded),
+		})
+	}
+	return list, nil
+}
+
+// saveColony writes the colony. The colony ID is updated if the colony was founded.
+func saveColony(ctx context.Context, q *Queries, colony *models.Colony) error {
+	kind, err := ColonyKindText(colony.Kind)
+	if err != nil {
+		return fmt.Errorf("colony %d: %w", colony.ID, err)
+	}
+	id, err := q.UpsertColony(ctx, UpsertColonyParams{
+		EmpireID:   int64(colony.EmpireID),
+		OrbitID:    int64(colony.OrbitID),
+		Kind:       kind,
+		Population: int64(colony.Population),
+		Enclosures: int64(colony.Enclosures),
+		Founded:    int64(colony.Founded),
+	})
+	if err != nil {
+		return fmt.Errorf("colony %d: %w", colony.ID, err)
+	}
+	colony.ID = int(id)
+	return nil
+}
+
+// ColonyKind returns the kind of colony from the value of colonies.kind.
+func ColonyKind(text string) (models.Colony_e, error) {
+	switch text {
+	case "open":
+		return models.OPEN, nil
+	case "enclosed":
+		return models.ENCLOSED, nil
+	}
+	return 0, fmt.Errorf("%q: unknown colony kind", text)
+}
+
+// ColonyKindText returns the value of colonies.kind for the colony.
+func ColonyKindText(kind models.Colony_e) (string, error) {
+	switch kind {
+	case models.OPEN:
+		return "open", nil
+	case models.ENCLOSED:
+		return "enclosed", nil
+	}
+	return "", fmt.Errorf("%d: invalid colony kind", kind)
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+)
+
+// ReadEmpire returns the empire with the given id.
+// It returns ErrNotFound if the empire is not in the game.
+func (s *Store) ReadEmpire(ctx context.Context, code string, empireID int) (*models.Empire, error) {
+	game, err := getGame(ctx, s.q, code)
+	if err != nil {
+		return nil, err
+	}
+	empire, err := s.q.GetEmpire(ctx, int64(empireID))
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && empire.GameID != game.ID) {
+		return nil, fmt.Errorf("%s: empire %d: %w", code, empireID, ErrNotFound)
+	} else if err != nil {
+		return nil, err
+	}
+	return &models.Empire{ID: int(empire.ID), PlayerID: int(empire.PlayerID)}, nil
+}
+
+// ReadEmpires returns the empires in the game, ordered by id.
+func (s *Store) ReadEmpires(ctx context.Context, code string) ([]*models.Empire, error) {
+	game, err := getGame(ctx, s.q, code)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.q.ListGameEmpires(ctx, game.ID)
+	if err != nil {
+		return nil, err
+	}
+	var empires []*models.Empire
+	for _, row := range rows {
+		empires = append(empires, &models.Empire{ID: int(row.ID), PlayerID: int(row.PlayerID)})
+	}
+	return empires, nil
+}
+
+// checkEmpire returns ErrNotFound if the empire is not in the game.
+func checkEmpire(ctx context.Context, q *Queries, code string, empireID int) error {
+	game, err := getGame(ctx, q, code)
+	if err != nil {
+		return err
+	}
+	empire, err := q.GetEmpire(ctx, int64(empireID))
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && empire.GameID != game.ID) {
+		return fmt.Errorf("%s: empire %d: %w", code, empireID, ErrNotFound)
+	}
+	return err
+}
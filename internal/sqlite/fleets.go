@@ -0,0 +1,170 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+	"log"
+)
+
+// AddFleet creates a fleet for the empire orbiting the planet. The
+// planet must be in the game and every ship class must be known.
+// It returns the fleet.
+func (s *Store) AddFleet(ctx context.Context, code string, empireID, systemID, orbitID int, ships map[string]int, fuel int) (*models.Fleet, error) {
+	if fuel < 0 {
+		return nil, fmt.Errorf("%d: invalid fuel", fuel)
+	}
+	fleet := &models.Fleet{EmpireID: empireID, SystemID: systemID, OrbitID: orbitID, Fuel: fuel, Ships: map[string]int{}, Cargo: map[string]int{}}
+	for class, n := range ships {
+		if _, ok := models.ShipClasses[class]; !ok {
+			return nil, fmt.Errorf("%q: unknown ship class", class)
+		} else if n < 0 {
+			return nil, fmt.Errorf("%s: %d: invalid number of ships", class, n)
+		}
+		fleet.Ships[class] = n
+	}
+	if len(fleet.Classes()) == 0 {
+		return nil, fmt.Errorf("fleet has no ships")
+	} else if fuel > fleet.FuelCapacity() {
+		return nil, fmt.Errorf("%d: fuel is over capacity of %d", fuel, fleet.FuelCapacity())
+	}
+
+	err := s.InTx(ctx, func(q *Queries) error {
+		fleet.ID = 0 // the fleet is created again if the transaction is retried
+		if _, err := getGame(ctx, q, code); err != nil {
+			return err
+		} else if err := checkEmpire(ctx, q, code, empireID); err != nil {
+			return err
+		}
+		return saveFleet(ctx, q, fleet)
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("store: %s: empire %d: fleet %d: created\n", code, empireID, fleet.ID)
+	return fleet, nil
+}
+
+// readFleets loads the fleets in the game along with their ships and cargo.
+func readFleets(ctx context.Context, q *Queries, gameID int64) ([]*models.Fleet, error) {
+	var list []*models.Fleet
+	fleets := map[int64]*models.Fleet{}
+	if rows, err := q.ListGameFleets(ctx, gameID); err != nil {
+		return nil, err
+	} else {
+		for _, row := range rows {
+			fleet := &models.Fleet{
+				ID:            int(row.ID),
+				EmpireID:      int(row.EmpireID),
+				SystemID:      int(row.SystemID),
+				OrbitID:       int(row.OrbitID.Int64),
+				DestinationID: int(row.DestinationID.Int64),
+				Arrives:       int(row.Arrives.Int64),
+				Fuel:          int(row.Fuel),
+				Ships:         map[string]int{},
+				Cargo:         map[string]int{},
+			}
+			fleets[row.ID] = fleet
+			list = append(list, fleet)
+		}
+	}
+	if rows, err := q.ListGameFleetShips(ctx, gameID); err != nil {
+		return nil, err
+	} else {
+		for _, row := range rows {
+			fleet, ok := fleets[row.FleetID]
+			if !ok {
+				return nil, fmt.Errorf("fleet %d: not found", row.FleetID)
+			}
+			fleet.Ships[row.Class] = int(row.Quantity)
+		}
+	}
+	if rows, err := q.ListGameFleetCargo(ctx, gameID); err != nil {
+		return nil, err
+	} else {
+		for _, row := range rows {
+			fleet, ok := fleets[row.FleetID]
+			if !ok {
+				return nil, fmt.Errorf("fleet %d: not found", row.FleetID)
+			}
+			fleet.Cargo[row.Kind] = int(row.Quantity)
+		}
+	}
+	return list, nil
+}
+
+// saveFleet writes the fleet and replaces its ships and cargo.
+// The fleet ID is updated if the fleet was created. A fleet
+// without ships is deleted along with its cargo.
+func saveFleet(ctx context.Context, q *Queries, fleet *models.Fleet) error {
+	if len(fleet.Classes()) == 0 {
+		if fleet.ID == 0 {
+			return nil
+		} else if err := q.DeleteFleetShips(ctx, int64(fleet.ID)); err != nil {
+			return fmt.Errorf("fleet %d: %w", fleet.ID, err)
+		} else if err := q.DeleteFleetCargo(ctx, int64(fleet.ID)); err != nil {
+			return fmt.Errorf("fleet %d: %w", fleet.ID, err)
+		} else if err := q.DeleteFleet(ctx, int64(fleet.ID)); err != nil {
+			return fmt.Errorf("fleet %d: %w", fleet.ID, err)
+		}
+		return nil
+	}
+
+	orbitID := sql.NullInt64{Int64: int64(fleet.OrbitID), Valid: fleet.OrbitID != 0}
+	destinationID := sql.NullInt64{Int64: int64(fleet.DestinationID), Valid: fleet.InTransit()}
+	arrives := sql.NullInt64{Int64: int64(fleet.Arrives), Valid: fleet.InTransit()}
+	if fleet.ID == 0 {
+		id, err := q.CreateFleet(ctx, CreateFleetParams{
+			EmpireID:      int64(fleet.EmpireID),
+			SystemID:      int64(fleet.SystemID),
+			OrbitID:       orbitID,
+			DestinationID: destinationID,
+			Arrives:       arrives,
+			Fuel:          int64(fleet.Fuel),
+		})
+		if err != nil {
+			return fmt.Errorf("fleet: %w", err)
+		}
+		fleet.ID = int(id)
+	} else if err := q.UpdateFleet(ctx, UpdateFleetParams{
+		SystemID:      int64(fleet.SystemID),
+		OrbitID:       orbitID,
+		DestinationID: destinationID,
+		Arrives:       arrives,
+		Fuel:          int64(fleet.Fuel),
+		FleetID:       int64(fleet.ID),
+	}); err != nil {
+		return fmt.Errorf("fleet %d: %w", fleet.ID, err)
+	}
+
+	if err := q.DeleteFleetShips(ctx, int64(fleet.ID)); err != nil {
+		return fmt.Errorf("fleet %d: %w", fleet.ID, err)
+	}
+	for _, class := range fleet.Classes() {
+		if err := q.CreateFleetShips(ctx, CreateFleetShipsParams{
+			FleetID:  int64(fleet.ID),
+			Class:    class,
+			Quantity: int64(fleet.Ships[class]),
+		}); err != nil {
+			return fmt.Errorf("fleet %d: %s: %w", fleet.ID, class, err)
+		}
+	}
+	if err := q.DeleteFleetCargo(ctx, int64(fleet.ID)); err != nil {
+		return fmt.Errorf("fleet %d: %w", fleet.ID, err)
+	}
+	for _, kind := range []string{"colonists", "gold", "metallics", "non-metallics"} {
+		if n := fleet.Cargo[kind]; n > 0 {
+			if err := q.CreateFleetCargo(ctx, CreateFleetCargoParams{
+				FleetID:  int64(fleet.ID),
+				Kind:     kind,
+				Quantity: int64(n),
+			}); err != nil {
+				return fmt.Errorf("fleet %d: %s: %w", fleet.ID, kind, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+	"time"
+)
+
+// ReadGame returns the game with the given code.
+// The cluster and empires are not loaded.
+func (s *Store) ReadGame(ctx context.Context, code string) (*models.Game, error) {
+	row, err := getGame(ctx, s.q, code)
+	if err != nil {
+		return nil, err
+	}
+	return gameModel(row), nil
+}
+
+// ReadGames returns all the games, ordered by code, with their schedules.
+// The clusters and empires are not loaded.
+func (s *Store) ReadGames(ctx context.Context) ([]*models.Game, error) {
+	rows, err := s.q.ListGames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	schedules := map[int64]string{}
+	if rows, err := s.q.ListSchedules(ctx); err != nil {
+		return nil, err
+	} else {
+		for _, row := range rows {
+			schedules[row.GameID] = row.Schedule
+		}
+	}
+	var games []*models.Game
+	for _, row := range rows {
+		game := gameModel(row)
+		game.Schedule = schedules[row.ID]
+		games = append(games, game)
+	}
+	return games, nil
+}
+
+// getGame returns the game with the given code.
+// It returns ErrNotFound if there is no such game.
+func getGame(ctx context.Context, q *Queries, code string) (Games, error) {
+	row, err := q.GetGameByCode(ctx, code)
+	if errors.Is(err, sql.ErrNoRows) {
+		return row, fmt.Errorf("%s: game: %w", code, ErrNotFound)
+	}
+	return row, err
+}
+
+func gameModel(row Games) *models.Game {
+	game := &models.Game{
+		ID:          int(row.ID),
+		Code:        row.Code,
+		Name:        row.Name,
+		DisplayName: row.DisplayName,
+		CurrentTurn: int(row.CurrentTurn),
+		Seed:        uint64(row.Seed),
+	}
+	if row.TurnDeadline.Valid {
+		game.Deadline = row.TurnDeadline.Time
+	}
+	return game
+}
+
+// SetTurnDeadline sets the deadline for orders for the game's current turn.
+// A zero deadline removes the deadline.
+func (s *Store) SetTurnDeadline(ctx context.Context, code string, deadline time.Time) error {
+	game, err := getGame(ctx, s.q, code)
+	if err != nil {
+		return err
+	}
+	return s.q.UpdateGameTurnDeadline(ctx, UpdateGameTurnDeadlineParams{
+		TurnDeadline: sql.NullTime{Time: deadline.UTC(), Valid: !deadline.IsZero()},
+		GameID:       game.ID,
+	})
+}
+
+// SetGameSeed sets the seed for the game's turn engine.
+func (s *Store) SetGameSeed(ctx context.Context, code string, seed uint64) error {
+	game, err := getGame(ctx, s.q, code)
+	if err != nil {
+		return err
+	}
+	return s.q.UpdateGameSeed(ctx, UpdateGameSeedParams{Seed: int64(seed), GameID: game.ID})
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+)
+
+// ReadKnowledge returns what the empire has observed of the cluster.
+func (s *Store) ReadKnowledge(ctx context.Context, code string, empireID int) (knowledge *models.Knowledge, err error) {
+	err = s.InReadTx(ctx, func(q *Queries) error {
+		if err := checkEmpire(ctx, q, code, empireID); err != nil {
+			return err
+		}
+		knowledge, err = readKnowledge(ctx, q, int64(empireID))
+		return err
+	})
+	return knowledge, err
+}
+
+// SaveKnowledge writes the empire's observations that were made on or
+// after the given turn. Older observations are assumed to be saved.
+func (s *Store) SaveKnowledge(ctx context.Context, code string, empireID int, knowledge *models.Knowledge, since int) error {
+	return s.InTx(ctx, func(q *Queries) error {
+		if err := checkEmpire(ctx, q, code, empireID); err != nil {
+			return err
+		}
+		return saveKnowledge(ctx, q, int64(empireID), knowledge, since)
+	})
+}
+
+func readKnowledge(ctx context.Context, q *Queries, empireID int64) (*models.Knowledge, error) {
+	knowledge := models.NewKnowledge()
+	if rows, err := q.ListEmpireSeenSystems(ctx, empireID); err != nil {
+		return nil, err
+	} else {
+		for _, row := range rows {
+			knowledge.Systems[int(row.SystemID)] = &models.SeenSystem{
+				ID:     int(row.SystemID),
+				X:      int(row.X),
+				Y:      int(row.Y),
+				Z:      int(row.Z),
+				Stars:  int(row.Stars),
+				Turn:   int(row.Turn),
+				Orbits: map[int]*models.SeenOrbit{},
+			}
+		}
+	}
+
+	orbits := map[int64]*models.SeenOrbit{}
+	if rows, err := q.ListEmpireSeenOrbits(ctx, empireID); err != nil {
+		return nil, err
+	} else {
+		for _, row := range rows {
+			system, ok := knowledge.Systems[int(row.SystemID)]
+			if !ok {
+				return nil, fmt.Errorf("seen orbit %d: system %d: not seen", row.OrbitID, row.SystemID)
+			}
+			kind, err := PlanetKind(row.Kind)
+			if err != nil {
+				return nil, fmt.Errorf("seen orbit %d: %w", row.OrbitID, err)
+			}
+			orbit := &models.SeenOrbit{
+				ID:           int(row.OrbitID),
+				Star:         int(row.Sequence),
+				Orbit:        int(row.Orbit),
+				Kind:         kind,
+				Habitability: int(row.Habitability),
+				Turn:         int(row.Turn),
+				Deposits:     map[int]*models.SeenDeposit{},
+			}
+			orbits[row.OrbitID] = orbit
+			system.Orbits[orbit.ID] = orbit
+		}
+	}
+
+	if rows, err := q.ListEmpireSeenDeposits(ctx, empireID); err != nil {
+		return nil, err
+	} else {
+		for _, row := range rows {
+			orbit, ok := orbits[row.OrbitID]
+			if !ok {
+				return nil, fmt.Errorf("seen deposit %d: orbit %d: not seen", row.DepositID, row.OrbitID)
+			}
+			kind, err := ResourceKind(row.Kind)
+			if err != nil {
+				return nil, fmt.Errorf("seen deposit %d: %w", row.DepositID, err)
+			}
+			orbit.Deposits[int(row.DepositID)] = &models.SeenDeposit{
+				ID:       int(row.DepositID),
+				Number:   int(row.DepositNo),
+				Kind:     kind,
+				Quantity: int(row.Quantity),
+				YieldPct: int(row.YieldPct),
+				Turn:     int(row.Turn),
+			}
+		}
+	}
+
+	return knowledge, nil
+}
+
+func saveKnowledge(ctx context.Context, q *Queries, empireID int64, knowledge *models.Knowledge, since int) error {
+	for _, system := range knowledge.Systems {
+		if system.Turn >= since {
+			if err := q.UpsertSeenSystem(ctx, UpsertSeenSystemParams{
+				EmpireID: empireID,
+				SystemID: int64(system.ID),
+				Stars:    int64(system.Stars),
+				Turn:     int64(system.Turn),
+			}); err != nil {
+				return fmt.Errorf("seen system %d: %w", system.ID, err)
+			}
+		}
+		for _, orbit := range system.Orbits {
+			if orbit.Turn >= since {
+				kind, err := PlanetKindText(orbit.Kind)
+				if err != nil {
+					return fmt.Errorf("seen orbit %d: %w", orbit.ID, err)
+				}
+				if err := q.UpsertSeenOrbit(ctx, UpsertSeenOrbitParams{
+					EmpireID:     empireID,
+					OrbitID:      int64(orbit.ID),
+					Kind:         kind,
+					Habitability: int64(orbit.Habitability),
+					Turn:         int64(orbit.Turn),
+				}); err != nil {
+					return fmt.Errorf("seen orbit %d: %w", orbit.ID, err)
+				}
+			}
+			for _, deposit := range orbit.Deposits {
+				if deposit.Turn < since {
+					continue
+				}
+				kind, err := ResourceKindText(deposit.Kind)
+				if err != nil {
+					return fmt.Errorf("seen deposit %d: %w", deposit.ID, err)
+				}
+				if err := q.UpsertSeenDeposit(ctx, UpsertSeenDepositParams{
+					EmpireID:  empireID,
+					DepositID: int64(deposit.ID),
+					Kind:      kind,
+					Quantity:  int64(deposit.Quantity),
+					YieldPct:  int64(deposit.YieldPct),
+					Turn:      int64(deposit.Turn),
+				}); err != nil {
+					return fmt.Errorf("seen deposit %d: %w", deposit.ID, err)
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package sqlite
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrMigrationChanged is returned when an applied script no longer
+	// matches the checksum recorded when it was applied.
+	ErrMigrationChanged = errors.New("migration changed since it was applied")
+	// ErrMigrationMissing is returned when the database has applied a
+	// script that the binary doesn't have, usually because the database
+	// was migrated by a newer release.
+	ErrMigrationMissing = errors.New("migration applied but not found")
+)
+
+// Migration is a single DDL script and its state in the database.
+type Migration struct {
+	Version   int64
+	Script    string    // name of the script, like "202502110915_initial.sql"
+	Checksum  string    // SHA-256 of the script; empty if the script is missing
+	AppliedAt time.Time // zero if the script is pending
+	Changed   bool      // true if the script doesn't match the recorded checksum
+	Missing   bool      // true if the script was applied but isn't in the file system
+}
+
+// Pending returns true if the script has not been applied.
+func (m *Migration) Pending() bool {
+	return m.AppliedAt.IsZero() && !m.Missing
+}
+
+// The migration queries are written by hand rather than generated because
+// they must work against every version of the meta_migrations table,
+// including databases that don't have it yet.
+
+// MigrationStatus compares the scripts in fsys with the migrations that
+// have been applied to the database. It returns every migration, applied
+// or pending, in version order.
+func (s *Store) MigrationStatus(ctx context.Context, fsys fs.FS) ([]*Migration, error) {
+	return migrationStatus(ctx, s.db, fsys)
+}
+
+// Migrate applies the pending scripts in fsys in version order. Each script
+// runs in its own transaction along with the update to meta_migrations,
+// so a failed script leaves the database at the previous version.
+//
+// It refuses to run if an applied script has changed or is missing.
+// It returns the migrations that were applied.
+func (s *Store) Migrate(ctx context.Context, fsys fs.FS) ([]*Migration, error) {
+	migrations, err := migrationStatus(ctx, s.db, fsys)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range migrations {
+		if m.Changed {
+			return nil, fmt.Errorf("%s: %w", m.Script, ErrMigrationChanged)
+		} else if m.Missing {
+			return nil, fmt.Errorf("%s: %w", m.Script, ErrMigrationMissing)
+		}
+	}
+
+	var applied []*Migration
+	for _, m := range migrations {
+		if !m.Pending() {
+			continue
+		}
+		script, err := fs.ReadFile(fsys, m.Script)
+		if err != nil {
+			return applied, err
+		}
+		started := time.Now()
+		if err := s.applyMigration(ctx, m, string(script)); err != nil {
+			return applied, fmt.Errorf("%s: %w", m.Script, err)
+		}
+		log.Printf("store: %s: migration %s: applied in %v\n", s.path, m.Script, time.Since(started))
+		applied = append(applied, m)
+	}
+
+	// scripts applied before meta_migrations had a checksum column are
+	// recorded now, so that changes to them are caught from here on.
+	if ok, err := hasChecksums(ctx, s.db); err != nil {
+		return applied, err
+	} else if ok {
+		for _, m := range migrations {
+			if _, err := s.db.ExecContext(ctx, `UPDATE meta_migrations SET checksum = ?1 WHERE version = ?2 AND checksum = ''`, m.Checksum, m.Version); err != nil {
+				return applied, fmt.Errorf("%s: checksum: %w", m.Script, err)
+			}
+		}
+	}
+
+	return applied, nil
+}
+
+// applyMigration runs a script and records it in meta_migrations.
+// The scripts insert their own row, but the runner adds one if a script
+// forgets to.
+func (s *Store) applyMigration(ctx context.Context, m *Migration, script string) error {
+	return s.InTx(ctx, func(q *Queries) error {
+		tx := q.db // the scripts aren't queries, so run them directly
+		if _, err := tx.ExecContext(ctx, script); err != nil {
+			return err
+		}
+
+		var count int
+		if err := tx.QueryRowContext(ctx, `SELECT count(*) FROM meta_migrations WHERE version = ?1`, m.Version).Scan(&count); err != nil {
+			return err
+		} else if count == 0 {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO meta_migrations (version, comment, script) VALUES (?1, ?2, ?2)`, m.Version, m.Script); err != nil {
+				return err
+			}
+		}
+		if ok, err := hasChecksums(ctx, tx); err != nil {
+			return err
+		} else if ok {
+			if _, err := tx.ExecContext(ctx, `UPDATE meta_migrations SET checksum = ?1 WHERE version = ?2`, m.Checksum, m.Version); err != nil {
+				return err
+			}
+		}
+		return tx.QueryRowContext(ctx, `SELECT created_at FROM meta_migrations WHERE version = ?1`, m.Version).Scan(&m.AppliedAt)
+	})
+}
+
+// migrationStatus merges the scripts in the file system with the rows
+// in meta_migrations.
+func migrationStatus(ctx context.Context, db DBTX, fsys fs.FS) ([]*Migration, error) {
+	scripts, err := readMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+	byVersion := map[int64]*Migration{}
+	for _, m := range scripts {
+		byVersion[m.Version] = m
+	}
+
+	var exists int
+	if err := db.QueryRowContext(ctx, `SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = 'meta_migrations'`).Scan(&exists); err != nil {
+		return nil, err
+	} else if exists == 0 {
+		return scripts, nil
+	}
+	checksums, err := hasChecksums(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT version, script, created_at, '' FROM meta_migrations ORDER BY version`
+	if checksums {
+		query = `SELECT version, script, created_at, checksum FROM meta_migrations ORDER BY version`
+	}
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	migrations := scripts
+	for rows.Next() {
+		var version int64
+		var script, checksum string
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &script, &appliedAt, &checksum); err != nil {
+			return nil, err
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			migrations = append(migrations, &Migration{Version: version, Script: script, AppliedAt: appliedAt, Missing: true})
+			continue
+		}
+		m.AppliedAt = appliedAt
+		m.Changed = checksum != "" && checksum != m.Checksum
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// readMigrations returns the scripts in the root of the file system.
+// Scripts must be named "version_comment.sql" where version is a number.
+func readMigrations(fsys fs.FS) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	var migrations []*Migration
+	seen := map[int64]string{}
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+		prefix, _, ok := strings.Cut(entry.Name(), "_")
+		if !ok {
+			return nil, fmt.Errorf("%s: missing version", entry.Name())
+		}
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil || version < 1 {
+			return nil, fmt.Errorf("%s: invalid version", entry.Name())
+		} else if other, ok := seen[version]; ok {
+			return nil, fmt.Errorf("%s: version used by %s", entry.Name(), other)
+		}
+		seen[version] = entry.Name()
+		script, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(script)
+		migrations = append(migrations, &Migration{
+			Version:  version,
+			Script:   entry.Name(),
+			Checksum: hex.EncodeToString(sum[:]),
+		})
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// hasChecksums returns true if meta_migrations has the checksum column.
+func hasChecksums(ctx context.Context, db DBTX) (bool, error) {
+	var count int
+	err := db.QueryRowContext(ctx, `SELECT count(*) FROM pragma_table_info('meta_migrations') WHERE name = 'checksum'`).Scan(&count)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return count != 0, err
+}
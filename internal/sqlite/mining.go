@@ -0,0 +1,182 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+	"log"
+	"sort"
+)
+
+// AddMiningUnits adds units to the empire's mine on the orbit, creating
+// the mine if needed. The orbit must be in the game. It returns the mine.
+func (s *Store) AddMiningUnits(ctx context.Context, code string, empireID, orbitID, units int) (*models.Mine, error) {
+	if units < 1 {
+		return nil, fmt.Errorf("%d: invalid number of units", units)
+	}
+
+	var mine *models.Mine
+	err := s.InTx(ctx, func(q *Queries) error {
+		game, err := getGame(ctx, q, code)
+		if err != nil {
+			return err
+		}
+		if err := checkEmpire(ctx, q, code, empireID); err != nil {
+			return err
+		}
+		mines, err := readMines(ctx, q, game.ID)
+		if err != nil {
+			return err
+		}
+		mine = &models.Mine{EmpireID: empireID, OrbitID: orbitID, Assignments: map[int]int{}}
+		for _, m := range mines {
+			if m.EmpireID == empireID && m.OrbitID == orbitID {
+				mine = m
+				break
+			}
+		}
+		mine.Units += units
+		return saveMine(ctx, q, mine)
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("store: %s: empire %d: mine %d: %d units\n", code, empireID, mine.ID, mine.Units)
+	return mine, nil
+}
+
+// readMines loads the mines in the game and their assignments.
+func readMines(ctx context.Context, q *Queries, gameID int64) ([]*models.Mine, error) {
+	var list []*models.Mine
+	mines := map[int64]*models.Mine{}
+	if rows, err := q.ListGameMines(ctx, gameID); err != nil {
+		return nil, err
+	} else {
+		for _, row := range rows {
+			mine := &models.Mine{
+				ID:          int(row.ID),
+				EmpireID:    int(row.EmpireID),
+				OrbitID:     int(row.OrbitID),
+				Units:       int(row.Units),
+				Assignments: map[int]int{},
+			}
+			mines[row.ID] = mine
+			list = append(list, mine)
+		}
+	}
+	if rows, err := q.ListGameMineAssignments(ctx, gameID); err != nil {
+		return nil, err
+	} else {
+		for _, row := range rows {
+			mine, ok := mines[row.MineID]
+			if !ok {
+				return nil, fmt.Errorf("mine %d: not found", row.MineID)
+			}
+			mine.Assignments[int(row.DepositID)] = int(row.Units)
+		}
+	}
+	return list, nil
+}
+
+// readStockpiles loads the stockpiles for the empires in the game.
+// Empires without a stockpile get an empty one.
+func readStockpiles(ctx context.Context, q *Queries, gameID int64, empires []*models.Empire) error {
+	byID := map[int64]*models.Empire{}
+	for _, empire := range empires {
+		empire.Stockpile = map[models.Resource_e]int{}
+		byID[int64(empire.ID)] = empire
+	}
+	rows, err := q.ListGameStockpiles(ctx, gameID)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		empire, ok := byID[row.EmpireID]
+		if !ok {
+			return fmt.Errorf("stockpile: empire %d: not found", row.EmpireID)
+		}
+		kind, err := ResourceKind(row.Kind)
+		if err != nil {
+			return fmt.Errorf("stockpile: empire %d: %w", row.EmpireID, err)
+		}
+		empire.Stockpile[kind] = int(row.Quantity)
+	}
+	return nil
+}
+
+// saveMine writes the mine and replaces its assignments.
+// The mine ID is updated if the mine was created.
+func saveMine(ctx context.Context, q *Queries, mine *models.Mine) error {
+	id, err := q.UpsertMine(ctx, UpsertMineParams{
+		EmpireID: int64(mine.EmpireID),
+		OrbitID:  int64(mine.OrbitID),
+		Units:    int64(mine.Units),
+	})
+	if err != nil {
+		return fmt.Errorf("mine %d: %w", mine.ID, err)
+	}
+	mine.ID = int(id)
+
+	if err := q.DeleteMineAssignments(ctx, id); err != nil {
+		return fmt.Errorf("mine %d: %w", mine.ID, err)
+	}
+	var deposits []int
+	for depositID := range mine.Assignments {
+		deposits = append(deposits, depositID)
+	}
+	sort.Ints(deposits)
+	for _, depositID := range deposits {
+		if units := mine.Assignments[depositID]; units > 0 {
+			if err := q.CreateMineAssignment(ctx, CreateMineAssignmentParams{
+				MineID:    id,
+				DepositID: int64(depositID),
+				Units:     int64(units),
+			}); err != nil {
+				return fmt.Errorf("mine %d: deposit %d: %w", mine.ID, depositID, err)
+			}
+		}
+	}
+
+	for _, extraction := range mine.Extractions {
+		if err := q.CreateExtraction(ctx, CreateExtractionParams{
+			EmpireID:  int64(mine.EmpireID),
+			MineID:    id,
+			DepositID: int64(extraction.DepositID),
+			Turn:      int64(extraction.Turn),
+			Units:     int64(extraction.Units),
+			Mined:     int64(extraction.Mined),
+			Refined:   int64(extraction.Refined),
+			Remaining: int64(extraction.Remaining),
+		}); err != nil {
+			return fmt.Errorf("mine %d: deposit %d: extraction: %w", mine.ID, extraction.DepositID, err)
+		}
+	}
+	mine.Extractions = nil
+
+	return nil
+}
+
+// saveStockpile writes every resource in the empire's stockpile.
+func saveStockpile(ctx context.Context, q *Queries, empire *models.Empire) error {
+	var kinds []models.Resource_e
+	for kind := range empire.Stockpile {
+		kinds = append(kinds, kind)
+	}
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i] < kinds[j] })
+	for _, kind := range kinds {
+		text, err := ResourceKindText(kind)
+		if err != nil {
+			return fmt.Errorf("stockpile: empire %d: %w", empire.ID, err)
+		}
+		if err := q.UpsertStockpile(ctx, UpsertStockpileParams{
+			EmpireID: int64(empire.ID),
+			Kind:     text,
+			Quantity: int64(empire.Stockpile[kind]),
+		}); err != nil {
+			return fmt.Errorf("stockpile: empire %d: %w", empire.ID, err)
+		}
+	}
+	return nil
+}
@@ -5,21 +5,97 @@
 package sqlite
 
 import (
+	"database/sql"
 	"time"
 )
 
+type Articles struct {
+	ID            int64
+	Title         string
+	Slug          string
+	Published     int64
+	DatePublished sql.NullTime
+	DateUpdated   time.Time
+}
+
+type AuditLog struct {
+	ID        int64
+	GameID    int64
+	Editor    string
+	Target    string
+	Change    string
+	Reason    string
+	CreatedAt time.Time
+}
+
+type Battles struct {
+	ID        int64
+	EmpireID  int64
+	SystemID  int64
+	Turn      int64
+	Log       string
+	CreatedAt time.Time
+}
+
+type Colonies struct {
+	ID         int64
+	EmpireID   int64
+	OrbitID    int64
+	Kind       string
+	Population int64
+	Enclosures int64
+	Founded    int64
+}
+
 type Empires struct {
 	ID       int64
 	GameID   int64
 	PlayerID int64
 }
 
+type Extractions struct {
+	ID        int64
+	EmpireID  int64
+	MineID    int64
+	DepositID int64
+	Turn      int64
+	Units     int64
+	Mined     int64
+	Refined   int64
+	Remaining int64
+	CreatedAt time.Time
+}
+
+type FleetCargo struct {
+	FleetID  int64
+	Kind     string
+	Quantity int64
+}
+
+type FleetShips struct {
+	FleetID  int64
+	Class    string
+	Quantity int64
+}
+
+type Fleets struct {
+	ID            int64
+	EmpireID      int64
+	SystemID      int64
+	OrbitID       sql.NullInt64
+	DestinationID sql.NullInt64
+	Arrives       sql.NullInt64
+	Fuel          int64
+}
+
 type Games struct {
-	ID          int64
-	Code        string
-	Name        string
-	DisplayName string
-	CurrentTurn int64
+	ID           int64
+	Code         string
+	Name         string
+	DisplayName  string
+	CurrentTurn  int64
+	TurnDeadline sql.NullTime
+	Seed         int64
 }
 
 type MetaMigrations struct {
@@ -27,6 +103,21 @@ type MetaMigrations struct {
 	Comment   string
 	Script    string
 	CreatedAt time.Time
+	Checksum  string
+}
+
+type MineAssignments struct {
+	ID        int64
+	MineID    int64
+	DepositID int64
+	Units     int64
+}
+
+type Mines struct {
+	ID       int64
+	EmpireID int64
+	OrbitID  int64
+	Units    int64
 }
 
 type NaturalResources struct {
@@ -46,14 +137,104 @@ type Orbits struct {
 	Habitability int64
 }
 
+type Orders struct {
+	ID          int64
+	EmpireID    int64
+	Turn        int64
+	Revision    int64
+	Text        string
+	Accepted    int64
+	Diagnostics string
+	CreatedAt   time.Time
+}
+
 type Players struct {
-	ID int64
+	ID      int64
+	Name    string
+	KeyHash string
+	IsGm    int64
 }
 
-type Stars struct {
+type Rejections struct {
+	ID        int64
+	EmpireID  int64
+	Turn      int64
+	Line      int64
+	Reason    string
+	CreatedAt time.Time
+}
+
+type Reports struct {
+	ID         int64
+	EmpireID   int64
+	Turn       int64
+	TextReport string
+	JsonReport string
+	CreatedAt  time.Time
+}
+
+type Schedules struct {
+	GameID   int64
+	Schedule string
+}
+
+type SeenDeposits struct {
+	ID        int64
+	EmpireID  int64
+	DepositID int64
+	Kind      string
+	Quantity  int64
+	YieldPct  int64
+	Turn      int64
+}
+
+type SeenOrbits struct {
+	ID           int64
+	EmpireID     int64
+	OrbitID      int64
+	Kind         string
+	Habitability int64
+	Turn         int64
+}
+
+type SeenSystems struct {
 	ID       int64
+	EmpireID int64
 	SystemID int64
-	Sequence int64
+	Stars    int64
+	Turn     int64
+}
+
+type Sessions struct {
+	ID        int64
+	PlayerID  int64
+	TokenHash string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+type Snapshots struct {
+	ID        int64
+	GameID    int64
+	Turn      int64
+	State     []byte
+	CreatedAt time.Time
+}
+
+type Stars struct {
+	ID         int64
+	SystemID   int64
+	Sequence   int64
+	Class      string
+	Size       string
+	Luminosity int64
+}
+
+type Stockpiles struct {
+	ID       int64
+	EmpireID int64
+	Kind     string
+	Quantity int64
 }
 
 type Systems struct {
@@ -63,3 +244,24 @@ type Systems struct {
 	Y      int64
 	Z      int64
 }
+
+type TurnLocks struct {
+	GameID    int64
+	Turn      int64
+	Owner     string
+	ExpiresAt time.Time
+}
+
+type Turns struct {
+	ID          int64
+	GameID      int64
+	Turn        int64
+	ProcessedAt time.Time
+}
+
+type Warps struct {
+	ID           int64
+	GameID       int64
+	FromSystemID int64
+	ToSystemID   int64
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+	"time"
+)
+
+// ErrOrdersLocked is returned when orders are submitted after the deadline.
+var ErrOrdersLocked = errors.New("orders locked")
+
+// ReadOrders returns the latest revision of the empire's orders for the
+// turn and the latest revision that was accepted. Either may be nil if
+// no orders have been submitted.
+func (s *Store) ReadOrders(ctx context.Context, empireID, turn int) (latest, accepted *models.Orders, err error) {
+	err = s.InReadTx(ctx, func(q *Queries) error {
+		if row, err := q.GetLatestOrders(ctx, GetLatestOrdersParams{EmpireID: int64(empireID), Turn: int64(turn)}); err == nil {
+			latest = ordersModel(row)
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if row, err := q.GetLatestAcceptedOrders(ctx, GetLatestAcceptedOrdersParams{EmpireID: int64(empireID), Turn: int64(turn)}); err == nil {
+			accepted = ordersModel(row)
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return latest, accepted, nil
+}
+
+// SubmitOrders saves a new revision of the empire's orders for the game's
+// current turn. The caller is responsible for checking the syntax; the
+// orders are accepted if there are no diagnostics.
+//
+// It returns ErrOrdersLocked if the deadline for the turn has passed.
+func (s *Store) SubmitOrders(ctx context.Context, code string, empireID int, text, diagnostics string, now time.Time) (orders *models.Orders, err error) {
+	err = s.InTx(ctx, func(q *Queries) error {
+		game, err := getGame(ctx, q, code)
+		if err != nil {
+			return err
+		}
+		if game.TurnDeadline.Valid && !now.Before(game.TurnDeadline.Time) {
+			return fmt.Errorf("%s: turn %d: %w", code, game.CurrentTurn, ErrOrdersLocked)
+		}
+		empire, err := q.GetEmpire(ctx, int64(empireID))
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && empire.GameID != game.ID) {
+			return fmt.Errorf("%s: empire %d: %w", code, empireID, ErrNotFound)
+		} else if err != nil {
+			return err
+		}
+
+		revision := int64(1)
+		if row, err := q.GetLatestOrders(ctx, GetLatestOrdersParams{EmpireID: empire.ID, Turn: game.CurrentTurn}); err == nil {
+			revision = row.Revision + 1
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		orders = &models.Orders{
+			EmpireID:    int(empire.ID),
+			Turn:        int(game.CurrentTurn),
+			Revision:    int(revision),
+			Text:        text,
+			Accepted:    diagnostics == "",
+			Diagnostics: diagnostics,
+			CreatedAt:   now,
+		}
+		var accepted int64
+		if orders.Accepted {
+			accepted = 1
+		}
+		id, err := q.CreateOrders(ctx, CreateOrdersParams{
+			EmpireID:    empire.ID,
+			Turn:        game.CurrentTurn,
+			Revision:    revision,
+			Text:        text,
+			Accepted:    accepted,
+			Diagnostics: diagnostics,
+		})
+		if err != nil {
+			return err
+		}
+		orders.ID = int(id)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+func ordersModel(row Orders) *models.Orders {
+	return &models.Orders{
+		ID:          int(row.ID),
+		EmpireID:    int(row.EmpireID),
+		Turn:        int(row.Turn),
+		Revision:    int(row.Revision),
+		Text:        row.Text,
+		Accepted:    row.Accepted == 1,
+		Diagnostics: row.Diagnostics,
+		CreatedAt:   row.CreatedAt,
+	}
+}
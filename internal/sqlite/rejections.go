@@ -0,0 +1,53 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+)
+
+// ReadRejections returns the orders rejected in the game on the turn,
+// keyed by empire id. Each empire's rejections are in the order the
+// engine rejected them.
+func (s *Store) ReadRejections(ctx context.Context, code string, turn int) (map[int][]*models.Rejection, error) {
+	game, err := getGame(ctx, s.q, code)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.q.ListGameRejections(ctx, ListGameRejectionsParams{GameID: game.ID, Turn: int64(turn)})
+	if err != nil {
+		return nil, err
+	}
+	rejections := map[int][]*models.Rejection{}
+	for _, row := range rows {
+		rejection := &models.Rejection{
+			ID:       int(row.ID),
+			EmpireID: int(row.EmpireID),
+			Turn:     int(row.Turn),
+			Line:     int(row.Line),
+			Reason:   row.Reason,
+		}
+		rejections[rejection.EmpireID] = append(rejections[rejection.EmpireID], rejection)
+	}
+	return rejections, nil
+}
+
+// saveRejections records the empire's orders that were rejected on the
+// turn. The rejections are cleared once they are saved so that they
+// aren't saved twice.
+func saveRejections(ctx context.Context, q *Queries, empire *models.Empire) error {
+	for _, rejection := range empire.Rejections {
+		if err := q.CreateRejection(ctx, CreateRejectionParams{
+			EmpireID: int64(empire.ID),
+			Turn:     int64(rejection.Turn),
+			Line:     int64(rejection.Line),
+			Reason:   rejection.Reason,
+		}); err != nil {
+			return fmt.Errorf("empire %d: line %d: rejection: %w", empire.ID, rejection.Line, err)
+		}
+	}
+	empire.Rejections = nil
+	return nil
+}
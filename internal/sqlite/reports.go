@@ -0,0 +1,78 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+)
+
+// SaveReports creates or replaces the reports. The empires must be in the game.
+// The reports are written inside a single transaction.
+func (s *Store) SaveReports(ctx context.Context, code string, reports []*models.Report) error {
+	return s.InTx(ctx, func(q *Queries) error {
+		game, err := getGame(ctx, q, code)
+		if err != nil {
+			return err
+		}
+		for _, report := range reports {
+			empire, err := q.GetEmpire(ctx, int64(report.EmpireID))
+			if errors.Is(err, sql.ErrNoRows) || (err == nil && empire.GameID != game.ID) {
+				return fmt.Errorf("%s: empire %d: %w", code, report.EmpireID, ErrNotFound)
+			} else if err != nil {
+				return err
+			}
+			if err := q.UpsertReport(ctx, UpsertReportParams{
+				EmpireID:   empire.ID,
+				Turn:       int64(report.Turn),
+				TextReport: report.Text,
+				JsonReport: report.JSON,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ReadReport returns the empire's report for the turn.
+func (s *Store) ReadReport(ctx context.Context, code string, empireID, turn int) (*models.Report, error) {
+	if _, err := s.ReadEmpire(ctx, code, empireID); err != nil {
+		return nil, err
+	}
+	row, err := s.q.GetReport(ctx, GetReportParams{EmpireID: int64(empireID), Turn: int64(turn)})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%s: empire %d: turn %d: report: %w", code, empireID, turn, ErrNotFound)
+	} else if err != nil {
+		return nil, err
+	}
+	return &models.Report{
+		ID:        int(row.ID),
+		EmpireID:  int(row.EmpireID),
+		Turn:      int(row.Turn),
+		Text:      row.TextReport,
+		JSON:      row.JsonReport,
+		CreatedAt: row.CreatedAt,
+	}, nil
+}
+
+// ReadReportIndex returns the reports available for the game, ordered
+// by empire and turn. The text of the reports is not loaded.
+func (s *Store) ReadReportIndex(ctx context.Context, code string) ([]*models.Report, error) {
+	game, err := getGame(ctx, s.q, code)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.q.ListGameReports(ctx, game.ID)
+	if err != nil {
+		return nil, err
+	}
+	var list []*models.Report
+	for _, row := range rows {
+		list = append(list, &models.Report{EmpireID: int(row.EmpireID), Turn: int(row.Turn), CreatedAt: row.CreatedAt})
+	}
+	return list, nil
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+var (
+	// ErrTurnClaimed is returned when another server has claimed the turn.
+	ErrTurnClaimed = errors.New("turn claimed")
+	// ErrTurnChanged is returned when the game is no longer on the turn.
+	ErrTurnChanged = errors.New("turn changed")
+)
+
+// SetSchedule sets when the server runs the game's turns.
+// An empty schedule removes the schedule. The schedule is not
+// checked here; see turns.ParseSchedule.
+func (s *Store) SetSchedule(ctx context.Context, code string, schedule string) error {
+	game, err := getGame(ctx, s.q, code)
+	if err != nil {
+		return err
+	}
+	if schedule == "" {
+		return s.q.DeleteSchedule(ctx, game.ID)
+	}
+	return s.q.UpsertSchedule(ctx, UpsertScheduleParams{GameID: game.ID, Schedule: schedule})
+}
+
+// ClaimTurn claims the game's turn for the owner until the given time.
+// It returns ErrTurnChanged if the game is not on the turn and ErrTurnClaimed
+// if another owner holds an unexpired claim. An owner may renew its own claim.
+//
+// The check and the claim are made in one transaction, so if two servers
+// race for the turn, the database lets only one of them write the claim.
+func (s *Store) ClaimTurn(ctx context.Context, code string, turn int, owner string, until time.Time) error {
+	err := s.InTx(ctx, func(q *Queries) error {
+		game, err := getGame(ctx, q, code)
+		if err != nil {
+			return err
+		} else if game.CurrentTurn != int64(turn) {
+			return fmt.Errorf("%s: turn %d: %w", code, turn, ErrTurnChanged)
+		}
+		lock, err := q.GetTurnLock(ctx, GetTurnLockParams{GameID: game.ID, Turn: int64(turn)})
+		if err == nil && lock.Owner != owner && time.Now().Before(lock.ExpiresAt) {
+			return fmt.Errorf("%s: turn %d: %s: %w", code, turn, lock.Owner, ErrTurnClaimed)
+		} else if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		return q.UpsertTurnLock(ctx, UpsertTurnLockParams{
+			GameID:    game.ID,
+			Turn:      int64(turn),
+			Owner:     owner,
+			ExpiresAt: until.UTC(),
+		})
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("store: %s: turn %d: claimed by %s\n", code, turn, owner)
+	return nil
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+)
+
+// Metric is the distance function used for sector queries.
+type Metric int
+
+const (
+	Chebyshev Metric = iota
+	Euclidean
+)
+
+// ReadSector loads the systems in the game that are within the radius
+// of the point. The stars and non-empty orbits of each system are loaded,
+// but the natural resources are not.
+//
+// Chebyshev sectors are cubes centered on the point. Euclidean sectors
+// are spheres. Both are found with the same bounding box query and
+// Euclidean sectors are then trimmed to the sphere.
+func (s *Store) ReadSector(ctx context.Context, code string, x, y, z, radius int, metric Metric) ([]*models.System, error) {
+	if radius < 0 {
+		return nil, fmt.Errorf("%d: invalid radius", radius)
+	}
+
+	var list []*models.System
+	err := s.InReadTx(ctx, func(q *Queries) error {
+		game, err := getGame(ctx, q, code)
+		if err != nil {
+			return err
+		}
+		list, err = readSector(ctx, q, game.ID, x, y, z, radius, metric)
+		return err
+	})
+	return list, err
+}
+
+func readSector(ctx context.Context, q *Queries, gameID int64, x, y, z, radius int, metric Metric) ([]*models.System, error) {
+	minX, maxX := int64(x-radius), int64(x+radius)
+	minY, maxY := int64(y-radius), int64(y+radius)
+	minZ, maxZ := int64(z-radius), int64(z+radius)
+
+	var list []*models.System
+	systems := map[int64]*models.System{}
+	if rows, err := q.ListGameSystemsInBox(ctx, ListGameSystemsInBoxParams{
+		GameID: gameID,
+		MinX:   minX,
+		MaxX:   maxX,
+		MinY:   minY,
+		MaxY:   maxY,
+		MinZ:   minZ,
+		MaxZ:   maxZ,
+	}); err != nil {
+		return nil, err
+	} else {
+		for _, row := range rows {
+			system := &models.System{ID: int(row.ID), X: int(row.X), Y: int(row.Y), Z: int(row.Z)}
+			if metric == Euclidean && system.EuclideanDistance(x, y, z) > float64(radius) {
+				continue
+			}
+			systems[row.ID] = system
+			list = append(list, system)
+		}
+	}
+
+	stars := map[int64]*models.Star{}
+	if rows, err := q.ListGameStarsInBox(ctx, ListGameStarsInBoxParams{
+		GameID: gameID,
+		MinX:   minX,
+		MaxX:   maxX,
+		MinY:   minY,
+		MaxY:   maxY,
+		MinZ:   minZ,
+		MaxZ:   maxZ,
+	}); err != nil {
+		return nil, err
+	} else {
+		for _, row := range rows {
+			system, ok := systems[row.SystemID]
+			if !ok { // trimmed from the sector
+				continue
+			}
+			star, err := readStar(row)
+			if err != nil {
+				return nil, err
+			}
+			stars[row.ID] = star
+			system.Stars = append(system.Stars, star)
+		}
+	}
+
+	if rows, err := q.ListGameOrbitsInBox(ctx, ListGameOrbitsInBoxParams{
+		GameID: gameID,
+		MinX:   minX,
+		MaxX:   maxX,
+		MinY:   minY,
+		MaxY:   maxY,
+		MinZ:   minZ,
+		MaxZ:   maxZ,
+	}); err != nil {
+		return nil, err
+	} else {
+		for _, row := range rows {
+			star, ok := stars[row.StarID]
+			if !ok { // trimmed from the sector
+				continue
+			} else if row.Orbit < 1 || row.Orbit >= int64(len(star.Orbits)) {
+				return nil, fmt.Errorf("orbit %d: invalid orbit %d", row.ID, row.Orbit)
+			}
+			kind, err := PlanetKind(row.Kind)
+			if err != nil {
+				return nil, fmt.Errorf("orbit %d: %w", row.ID, err)
+			}
+			star.Orbits[row.Orbit] = &models.Planet{ID: int(row.ID), Kind: kind, Habitability: int(row.Habitability)}
+		}
+	}
+
+	return list, nil
+}
@@ -7,10 +7,235 @@ package sqlite
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
-const createGame = `-- name: CreateGame :one
+const countGamePlayerEmpires = `-- name: CountGamePlayerEmpires :one
+SELECT count(*)
+FROM empires
+WHERE game_id = ?1
+  AND player_id = ?2
+`
+
+type CountGamePlayerEmpiresParams struct {
+	GameID   int64
+	PlayerID int64
+}
+
+// CountGamePlayerEmpires returns the number of empires a player controls in a game.
+func (q *Queries) CountGamePlayerEmpires(ctx context.Context, arg CountGamePlayerEmpiresParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countGamePlayerEmpires, arg.GameID, arg.PlayerID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countGameSystems = `-- name: CountGameSystems :one
+SELECT COUNT(*)
+FROM systems
+WHERE game_id = ?1
+`
+
+// CountGameSystems returns the number of systems in a game.
+func (q *Queries) CountGameSystems(ctx context.Context, gameID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countGameSystems, gameID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createArticle = `-- name: CreateArticle :one
+INSERT INTO articles (title, slug, published, date_published, date_updated)
+VALUES (?1, ?2, ?3, ?4, ?5)
+RETURNING id
+`
+
+type CreateArticleParams struct {
+	Title         string
+	Slug          string
+	Published     int64
+	DatePublished sql.NullTime
+	DateUpdated   time.Time
+}
+
+// CreateArticle creates a new article and returns its id.
+func (q *Queries) CreateArticle(ctx context.Context, arg CreateArticleParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createArticle,
+		arg.Title,
+		arg.Slug,
+		arg.Published,
+		arg.DatePublished,
+		arg.DateUpdated,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createAuditEntry = `-- name: CreateAuditEntry :exec
+INSERT INTO audit_log (game_id, editor, target, change, reason)
+VALUES (?1, ?2, ?3, ?4, ?5)
+`
+
+type CreateAuditEntryParams struct {
+	GameID int64
+	Editor string
+	Target string
+	Change string
+	Reason string
+}
+
+// CreateAuditEntry records a manual edit to a game.
+func (q *Queries) CreateAuditEntry(ctx context.Context, arg CreateAuditEntryParams) error {
+	_, err := q.db.ExecContext(ctx, createAuditEntry,
+		arg.GameID,
+		arg.Editor,
+		arg.Target,
+		arg.Change,
+		arg.Reason,
+	)
+	return err
+}
+
+const createBattle = `-- name: CreateBattle :exec
+INSERT INTO battles (empire_id, system_id, turn, log)
+VALUES (?1, ?2, ?3, ?4)
+`
+
+type CreateBattleParams struct {
+	EmpireID int64
+	SystemID int64
+	Turn     int64
+	Log      string
+}
+
+// CreateBattle records an empire's copy of the log of a battle.
+func (q *Queries) CreateBattle(ctx context.Context, arg CreateBattleParams) error {
+	_, err := q.db.ExecContext(ctx, createBattle,
+		arg.EmpireID,
+		arg.SystemID,
+		arg.Turn,
+		arg.Log,
+	)
+	return err
+}
+
+const createEmpire = `-- name: CreateEmpire :one
+INSERT INTO empires (game_id, player_id)
+VALUES (?1, ?2)
+RETURNING id
+`
+
+type CreateEmpireParams struct {
+	GameID   int64
+	PlayerID int64
+}
+
+// CreateEmpire creates a new empire.
+func (q *Queries) CreateEmpire(ctx context.Context, arg CreateEmpireParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createEmpire, arg.GameID, arg.PlayerID)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createExtraction = `-- name: CreateExtraction :exec
+INSERT INTO extractions (empire_id, mine_id, deposit_id, turn, units, mined, refined, remaining)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8)
+`
+
+type CreateExtractionParams struct {
+	EmpireID  int64
+	MineID    int64
+	DepositID int64
+	Turn      int64
+	Units     int64
+	Mined     int64
+	Refined   int64
+	Remaining int64
+}
+
+// CreateExtraction records the results of mining a deposit for a turn.
+func (q *Queries) CreateExtraction(ctx context.Context, arg CreateExtractionParams) error {
+	_, err := q.db.ExecContext(ctx, createExtraction,
+		arg.EmpireID,
+		arg.MineID,
+		arg.DepositID,
+		arg.Turn,
+		arg.Units,
+		arg.Mined,
+		arg.Refined,
+		arg.Remaining,
+	)
+	return err
+}
+
+const createFleet = `-- name: CreateFleet :one
+INSERT INTO fleets (empire_id, system_id, orbit_id, destination_id, arrives, fuel)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6)
+RETURNING id
+`
+
+type CreateFleetParams struct {
+	EmpireID      int64
+	SystemID      int64
+	OrbitID       sql.NullInt64
+	DestinationID sql.NullInt64
+	Arrives       sql.NullInt64
+	Fuel          int64
+}
+
+// CreateFleet creates a new fleet and returns its id.
+func (q *Queries) CreateFleet(ctx context.Context, arg CreateFleetParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createFleet,
+		arg.EmpireID,
+		arg.SystemID,
+		arg.OrbitID,
+		arg.DestinationID,
+		arg.Arrives,
+		arg.Fuel,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createFleetCargo = `-- name: CreateFleetCargo :exec
+INSERT INTO fleet_cargo (fleet_id, kind, quantity)
+VALUES (?1, ?2, ?3)
+`
+
+type CreateFleetCargoParams struct {
+	FleetID  int64
+	Kind     string
+	Quantity int64
+}
+
+// CreateFleetCargo adds cargo to a fleet.
+func (q *Queries) CreateFleetCargo(ctx context.Context, arg CreateFleetCargoParams) error {
+	_, err := q.db.ExecContext(ctx, createFleetCargo, arg.FleetID, arg.Kind, arg.Quantity)
+	return err
+}
+
+const createFleetShips = `-- name: CreateFleetShips :exec
+INSERT INTO fleet_ships (fleet_id, class, quantity)
+VALUES (?1, ?2, ?3)
+`
+
+type CreateFleetShipsParams struct {
+	FleetID  int64
+	Class    string
+	Quantity int64
+}
 
+// CreateFleetShips adds ships of a class to a fleet.
+func (q *Queries) CreateFleetShips(ctx context.Context, arg CreateFleetShipsParams) error {
+	_, err := q.db.ExecContext(ctx, createFleetShips, arg.FleetID, arg.Class, arg.Quantity)
+	return err
+}
+
+const createGame = `-- name: CreateGame :one
 INSERT INTO games (code, name, display_name)
 VALUES (?1, ?2, ?3)
 RETURNING id
@@ -22,8 +247,6 @@ type CreateGameParams struct {
 	DisplayName string
 }
 
-//	Copyright (c) 2025 Michael D Henderson. All rights reserved.
-//
 // CreateGame creates a new game.
 func (q *Queries) CreateGame(ctx context.Context, arg CreateGameParams) (int64, error) {
 	row := q.db.QueryRowContext(ctx, createGame, arg.Code, arg.Name, arg.DisplayName)
@@ -32,20 +255,186 @@ func (q *Queries) CreateGame(ctx context.Context, arg CreateGameParams) (int64,
 	return id, err
 }
 
+const createMineAssignment = `-- name: CreateMineAssignment :exec
+INSERT INTO mine_assignments (mine_id, deposit_id, units)
+VALUES (?1, ?2, ?3)
+`
+
+type CreateMineAssignmentParams struct {
+	MineID    int64
+	DepositID int64
+	Units     int64
+}
+
+// CreateMineAssignment assigns units of a mine to a deposit.
+func (q *Queries) CreateMineAssignment(ctx context.Context, arg CreateMineAssignmentParams) error {
+	_, err := q.db.ExecContext(ctx, createMineAssignment, arg.MineID, arg.DepositID, arg.Units)
+	return err
+}
+
+const createNaturalResource = `-- name: CreateNaturalResource :one
+INSERT INTO natural_resources (orbit_id, deposit_no, kind, quantity, yield_pct)
+VALUES (?1, ?2, ?3, ?4, ?5)
+RETURNING id
+`
+
+type CreateNaturalResourceParams struct {
+	OrbitID   int64
+	DepositNo int64
+	Kind      string
+	Quantity  int64
+	YieldPct  int64
+}
+
+// CreateNaturalResource creates a new natural resource (deposit).
+func (q *Queries) CreateNaturalResource(ctx context.Context, arg CreateNaturalResourceParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createNaturalResource,
+		arg.OrbitID,
+		arg.DepositNo,
+		arg.Kind,
+		arg.Quantity,
+		arg.YieldPct,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createOrbit = `-- name: CreateOrbit :one
+INSERT INTO orbits (star_id, orbit, kind, habitability)
+VALUES (?1, ?2, ?3, ?4)
+RETURNING id
+`
+
+type CreateOrbitParams struct {
+	StarID       int64
+	Orbit        int64
+	Kind         string
+	Habitability int64
+}
+
+// CreateOrbit creates a new orbit.
+func (q *Queries) CreateOrbit(ctx context.Context, arg CreateOrbitParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createOrbit,
+		arg.StarID,
+		arg.Orbit,
+		arg.Kind,
+		arg.Habitability,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createOrders = `-- name: CreateOrders :one
+INSERT INTO orders (empire_id, turn, revision, text, accepted, diagnostics)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6)
+RETURNING id
+`
+
+type CreateOrdersParams struct {
+	EmpireID    int64
+	Turn        int64
+	Revision    int64
+	Text        string
+	Accepted    int64
+	Diagnostics string
+}
+
+// CreateOrders creates a new revision of an empire's orders.
+func (q *Queries) CreateOrders(ctx context.Context, arg CreateOrdersParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createOrders,
+		arg.EmpireID,
+		arg.Turn,
+		arg.Revision,
+		arg.Text,
+		arg.Accepted,
+		arg.Diagnostics,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createPlayer = `-- name: CreatePlayer :one
+INSERT INTO players (name)
+VALUES (?1)
+RETURNING id
+`
+
+//	Copyright (c) 2025 Michael D Henderson. All rights reserved.
+//
+// CreatePlayer creates a new player.
+func (q *Queries) CreatePlayer(ctx context.Context, name string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createPlayer, name)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createRejection = `-- name: CreateRejection :exec
+INSERT INTO rejections (empire_id, turn, line, reason)
+VALUES (?1, ?2, ?3, ?4)
+`
+
+type CreateRejectionParams struct {
+	EmpireID int64
+	Turn     int64
+	Line     int64
+	Reason   string
+}
+
+// CreateRejection records an order that the turn engine rejected.
+func (q *Queries) CreateRejection(ctx context.Context, arg CreateRejectionParams) error {
+	_, err := q.db.ExecContext(ctx, createRejection,
+		arg.EmpireID,
+		arg.Turn,
+		arg.Line,
+		arg.Reason,
+	)
+	return err
+}
+
+const createSession = `-- name: CreateSession :exec
+INSERT INTO sessions (player_id, token_hash, expires_at)
+VALUES (?1, ?2, ?3)
+`
+
+type CreateSessionParams struct {
+	PlayerID  int64
+	TokenHash string
+	ExpiresAt time.Time
+}
+
+// CreateSession creates a session for a player.
+func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) error {
+	_, err := q.db.ExecContext(ctx, createSession, arg.PlayerID, arg.TokenHash, arg.ExpiresAt)
+	return err
+}
+
 const createStar = `-- name: CreateStar :one
-INSERT INTO stars (system_id, sequence)
-VALUES (?1, ?2)
+INSERT INTO stars (system_id, sequence, class, size, luminosity)
+VALUES (?1, ?2, ?3, ?4, ?5)
 RETURNING id
 `
 
 type CreateStarParams struct {
-	SystemID int64
-	Sequence int64
+	SystemID   int64
+	Sequence   int64
+	Class      string
+	Size       string
+	Luminosity int64
 }
 
 // CreateStar creates a new star.
 func (q *Queries) CreateStar(ctx context.Context, arg CreateStarParams) (int64, error) {
-	row := q.db.QueryRowContext(ctx, createStar, arg.SystemID, arg.Sequence)
+	row := q.db.QueryRowContext(ctx, createStar,
+		arg.SystemID,
+		arg.Sequence,
+		arg.Class,
+		arg.Size,
+		arg.Luminosity,
+	)
 	var id int64
 	err := row.Scan(&id)
 	return id, err
@@ -77,33 +466,2958 @@ func (q *Queries) CreateSystem(ctx context.Context, arg CreateSystemParams) (int
 	return id, err
 }
 
-const getCurrentGameTurn = `-- name: GetCurrentGameTurn :one
-SELECT current_turn
-FROM games
+const createTurn = `-- name: CreateTurn :exec
+INSERT INTO turns (game_id, turn)
+VALUES (?1, ?2)
+`
+
+type CreateTurnParams struct {
+	GameID int64
+	Turn   int64
+}
+
+// CreateTurn records that a turn has been processed.
+func (q *Queries) CreateTurn(ctx context.Context, arg CreateTurnParams) error {
+	_, err := q.db.ExecContext(ctx, createTurn, arg.GameID, arg.Turn)
+	return err
+}
+
+const createWarp = `-- name: CreateWarp :one
+INSERT INTO warps (game_id, from_system_id, to_system_id)
+VALUES (?1, ?2, ?3)
+RETURNING id
+`
+
+type CreateWarpParams struct {
+	GameID       int64
+	FromSystemID int64
+	ToSystemID   int64
+}
+
+// CreateWarp creates a new warp between two systems.
+// The lower system id must be first.
+func (q *Queries) CreateWarp(ctx context.Context, arg CreateWarpParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createWarp, arg.GameID, arg.FromSystemID, arg.ToSystemID)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const deleteArticle = `-- name: DeleteArticle :execrows
+DELETE
+FROM articles
 WHERE id = ?1
 `
 
-// GetCurrentGameTurn gets the current game turn.
-func (q *Queries) GetCurrentGameTurn(ctx context.Context, gameID int64) (int64, error) {
-	row := q.db.QueryRowContext(ctx, getCurrentGameTurn, gameID)
-	var current_turn int64
-	err := row.Scan(&current_turn)
-	return current_turn, err
+// DeleteArticle deletes an article.
+func (q *Queries) DeleteArticle(ctx context.Context, articleID int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteArticle, articleID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
-const updateGameTurn = `-- name: UpdateGameTurn :exec
-UPDATE games
-SET current_turn = ?1
-WHERE id = ?2
+const deleteColony = `-- name: DeleteColony :exec
+DELETE
+FROM colonies
+WHERE id = ?1
 `
 
-type UpdateGameTurnParams struct {
-	TurnNumber int64
-	GameID     int64
+// DeleteColony deletes a colony.
+func (q *Queries) DeleteColony(ctx context.Context, colonyID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteColony, colonyID)
+	return err
 }
 
-// UpdateGameTurn increments the game turn number.
-func (q *Queries) UpdateGameTurn(ctx context.Context, arg UpdateGameTurnParams) error {
-	_, err := q.db.ExecContext(ctx, updateGameTurn, arg.TurnNumber, arg.GameID)
+const deleteEmpireSeenDeposits = `-- name: DeleteEmpireSeenDeposits :exec
+DELETE
+FROM seen_deposits
+WHERE empire_id = ?1
+`
+
+// DeleteEmpireSeenDeposits deletes the deposits an empire has observed.
+func (q *Queries) DeleteEmpireSeenDeposits(ctx context.Context, empireID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteEmpireSeenDeposits, empireID)
+	return err
+}
+
+const deleteEmpireSeenOrbits = `-- name: DeleteEmpireSeenOrbits :exec
+DELETE
+FROM seen_orbits
+WHERE empire_id = ?1
+`
+
+// DeleteEmpireSeenOrbits deletes the orbits an empire has observed.
+func (q *Queries) DeleteEmpireSeenOrbits(ctx context.Context, empireID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteEmpireSeenOrbits, empireID)
+	return err
+}
+
+const deleteEmpireSeenSystems = `-- name: DeleteEmpireSeenSystems :exec
+DELETE
+FROM seen_systems
+WHERE empire_id = ?1
+`
+
+// DeleteEmpireSeenSystems deletes the systems an empire has observed.
+// The orbits and deposits must be deleted first.
+func (q *Queries) DeleteEmpireSeenSystems(ctx context.Context, empireID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteEmpireSeenSystems, empireID)
+	return err
+}
+
+const deleteEmpireStockpile = `-- name: DeleteEmpireStockpile :exec
+DELETE
+FROM stockpiles
+WHERE empire_id = ?1
+`
+
+// DeleteEmpireStockpile deletes every resource an empire has on hand.
+func (q *Queries) DeleteEmpireStockpile(ctx context.Context, empireID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteEmpireStockpile, empireID)
+	return err
+}
+
+const deleteFleet = `-- name: DeleteFleet :exec
+DELETE
+FROM fleets
+WHERE id = ?1
+`
+
+// DeleteFleet deletes a fleet. Its ships and cargo must be deleted first.
+func (q *Queries) DeleteFleet(ctx context.Context, fleetID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteFleet, fleetID)
+	return err
+}
+
+const deleteFleetCargo = `-- name: DeleteFleetCargo :exec
+DELETE
+FROM fleet_cargo
+WHERE fleet_id = ?1
+`
+
+// DeleteFleetCargo deletes the cargo in a fleet.
+func (q *Queries) DeleteFleetCargo(ctx context.Context, fleetID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteFleetCargo, fleetID)
+	return err
+}
+
+const deleteFleetShips = `-- name: DeleteFleetShips :exec
+DELETE
+FROM fleet_ships
+WHERE fleet_id = ?1
+`
+
+// DeleteFleetShips deletes the ships in a fleet.
+func (q *Queries) DeleteFleetShips(ctx context.Context, fleetID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteFleetShips, fleetID)
+	return err
+}
+
+const deleteGameBattlesFrom = `-- name: DeleteGameBattlesFrom :exec
+DELETE
+FROM battles
+WHERE turn >= ?1
+  AND empire_id IN (SELECT id FROM empires WHERE game_id = ?2)
+`
+
+type DeleteGameBattlesFromParams struct {
+	Turn   int64
+	GameID int64
+}
+
+// DeleteGameBattlesFrom deletes the battles for the given turn and later.
+func (q *Queries) DeleteGameBattlesFrom(ctx context.Context, arg DeleteGameBattlesFromParams) error {
+	_, err := q.db.ExecContext(ctx, deleteGameBattlesFrom, arg.Turn, arg.GameID)
+	return err
+}
+
+const deleteGameExtractionsFrom = `-- name: DeleteGameExtractionsFrom :exec
+DELETE
+FROM extractions
+WHERE turn >= ?1
+  AND empire_id IN (SELECT id FROM empires WHERE game_id = ?2)
+`
+
+type DeleteGameExtractionsFromParams struct {
+	Turn   int64
+	GameID int64
+}
+
+// DeleteGameExtractionsFrom deletes the extractions for the given turn and later.
+func (q *Queries) DeleteGameExtractionsFrom(ctx context.Context, arg DeleteGameExtractionsFromParams) error {
+	_, err := q.db.ExecContext(ctx, deleteGameExtractionsFrom, arg.Turn, arg.GameID)
+	return err
+}
+
+const deleteGameRejectionsFrom = `-- name: DeleteGameRejectionsFrom :exec
+DELETE
+FROM rejections
+WHERE turn >= ?1
+  AND empire_id IN (SELECT id FROM empires WHERE game_id = ?2)
+`
+
+type DeleteGameRejectionsFromParams struct {
+	Turn   int64
+	GameID int64
+}
+
+// DeleteGameRejectionsFrom deletes the rejected orders for the given turn and later.
+func (q *Queries) DeleteGameRejectionsFrom(ctx context.Context, arg DeleteGameRejectionsFromParams) error {
+	_, err := q.db.ExecContext(ctx, deleteGameRejectionsFrom, arg.Turn, arg.GameID)
+	return err
+}
+
+const deleteGameReportsAfter = `-- name: DeleteGameReportsAfter :exec
+DELETE
+FROM reports
+WHERE turn > ?1
+  AND empire_id IN (SELECT id FROM empires WHERE game_id = ?2)
+`
+
+type DeleteGameReportsAfterParams struct {
+	Turn   int64
+	GameID int64
+}
+
+// DeleteGameReportsAfter deletes the reports for turns after the given turn.
+func (q *Queries) DeleteGameReportsAfter(ctx context.Context, arg DeleteGameReportsAfterParams) error {
+	_, err := q.db.ExecContext(ctx, deleteGameReportsAfter, arg.Turn, arg.GameID)
+	return err
+}
+
+const deleteGameSnapshotsAfter = `-- name: DeleteGameSnapshotsAfter :exec
+DELETE
+FROM snapshots
+WHERE game_id = ?1
+  AND turn > ?2
+`
+
+type DeleteGameSnapshotsAfterParams struct {
+	GameID int64
+	Turn   int64
+}
+
+// DeleteGameSnapshotsAfter deletes the snapshots for turns after the given turn.
+func (q *Queries) DeleteGameSnapshotsAfter(ctx context.Context, arg DeleteGameSnapshotsAfterParams) error {
+	_, err := q.db.ExecContext(ctx, deleteGameSnapshotsAfter, arg.GameID, arg.Turn)
+	return err
+}
+
+const deleteGameTurnLocksFrom = `-- name: DeleteGameTurnLocksFrom :exec
+DELETE
+FROM turn_locks
+WHERE game_id = ?1
+  AND turn >= ?2
+`
+
+type DeleteGameTurnLocksFromParams struct {
+	GameID int64
+	Turn   int64
+}
+
+// DeleteGameTurnLocksFrom deletes the claims on the given turn and later.
+func (q *Queries) DeleteGameTurnLocksFrom(ctx context.Context, arg DeleteGameTurnLocksFromParams) error {
+	_, err := q.db.ExecContext(ctx, deleteGameTurnLocksFrom, arg.GameID, arg.Turn)
+	return err
+}
+
+const deleteGameTurnsFrom = `-- name: DeleteGameTurnsFrom :exec
+DELETE
+FROM turns
+WHERE game_id = ?1
+  AND turn >= ?2
+`
+
+type DeleteGameTurnsFromParams struct {
+	GameID int64
+	Turn   int64
+}
+
+// DeleteGameTurnsFrom deletes the processing records for the given turn and later.
+func (q *Queries) DeleteGameTurnsFrom(ctx context.Context, arg DeleteGameTurnsFromParams) error {
+	_, err := q.db.ExecContext(ctx, deleteGameTurnsFrom, arg.GameID, arg.Turn)
+	return err
+}
+
+const deleteGameWarps = `-- name: DeleteGameWarps :exec
+DELETE
+FROM warps
+WHERE game_id = ?1
+`
+
+// DeleteGameWarps deletes all the warps in a game.
+func (q *Queries) DeleteGameWarps(ctx context.Context, gameID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteGameWarps, gameID)
+	return err
+}
+
+const deleteMine = `-- name: DeleteMine :exec
+DELETE
+FROM mines
+WHERE id = ?1
+`
+
+// DeleteMine deletes a mine. Its assignments must be deleted first.
+func (q *Queries) DeleteMine(ctx context.Context, mineID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteMine, mineID)
+	return err
+}
+
+const deleteMineAssignments = `-- name: DeleteMineAssignments :exec
+DELETE
+FROM mine_assignments
+WHERE mine_id = ?1
+`
+
+// DeleteMineAssignments removes all the assignments for a mine.
+func (q *Queries) DeleteMineAssignments(ctx context.Context, mineID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteMineAssignments, mineID)
+	return err
+}
+
+const deleteOrbitNaturalResources = `-- name: DeleteOrbitNaturalResources :exec
+DELETE
+FROM natural_resources
+WHERE orbit_id = ?1
+`
+
+// DeleteOrbitNaturalResources deletes all the natural resources in an orbit.
+func (q *Queries) DeleteOrbitNaturalResources(ctx context.Context, orbitID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteOrbitNaturalResources, orbitID)
+	return err
+}
+
+const deletePlayerSessions = `-- name: DeletePlayerSessions :exec
+DELETE
+FROM sessions
+WHERE player_id = ?1
+`
+
+// DeletePlayerSessions deletes every session for a player.
+func (q *Queries) DeletePlayerSessions(ctx context.Context, playerID int64) error {
+	_, err := q.db.ExecContext(ctx, deletePlayerSessions, playerID)
+	return err
+}
+
+const deleteSchedule = `-- name: DeleteSchedule :exec
+DELETE
+FROM schedules
+WHERE game_id = ?1
+`
+
+// DeleteSchedule removes a game's schedule.
+func (q *Queries) DeleteSchedule(ctx context.Context, gameID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteSchedule, gameID)
+	return err
+}
+
+const deleteSession = `-- name: DeleteSession :exec
+DELETE
+FROM sessions
+WHERE token_hash = ?1
+`
+
+// DeleteSession deletes a session.
+func (q *Queries) DeleteSession(ctx context.Context, tokenHash string) error {
+	_, err := q.db.ExecContext(ctx, deleteSession, tokenHash)
+	return err
+}
+
+const getArticleByID = `-- name: GetArticleByID :one
+SELECT id, title, slug, published, date_published, date_updated
+FROM articles
+WHERE id = ?1
+`
+
+// GetArticleByID returns an article.
+func (q *Queries) GetArticleByID(ctx context.Context, articleID int64) (Articles, error) {
+	row := q.db.QueryRowContext(ctx, getArticleByID, articleID)
+	var i Articles
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Slug,
+		&i.Published,
+		&i.DatePublished,
+		&i.DateUpdated,
+	)
+	return i, err
+}
+
+const getArticleBySlug = `-- name: GetArticleBySlug :one
+SELECT id, title, slug, published, date_published, date_updated
+FROM articles
+WHERE slug = ?1
+`
+
+// GetArticleBySlug returns an article.
+func (q *Queries) GetArticleBySlug(ctx context.Context, slug string) (Articles, error) {
+	row := q.db.QueryRowContext(ctx, getArticleBySlug, slug)
+	var i Articles
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Slug,
+		&i.Published,
+		&i.DatePublished,
+		&i.DateUpdated,
+	)
+	return i, err
+}
+
+const getCurrentGameTurn = `-- name: GetCurrentGameTurn :one
+SELECT current_turn
+FROM games
+WHERE id = ?1
+`
+
+// GetCurrentGameTurn gets the current game turn.
+func (q *Queries) GetCurrentGameTurn(ctx context.Context, gameID int64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getCurrentGameTurn, gameID)
+	var current_turn int64
+	err := row.Scan(&current_turn)
+	return current_turn, err
+}
+
+const getEmpire = `-- name: GetEmpire :one
+SELECT id, game_id, player_id
+FROM empires
+WHERE id = ?1
+`
+
+// GetEmpire returns the empire with the given id.
+func (q *Queries) GetEmpire(ctx context.Context, empireID int64) (Empires, error) {
+	row := q.db.QueryRowContext(ctx, getEmpire, empireID)
+	var i Empires
+	err := row.Scan(
+		&i.ID,
+		&i.GameID,
+		&i.PlayerID,
+	)
+	return i, err
+}
+
+const getGameByCode = `-- name: GetGameByCode :one
+SELECT id, code, name, display_name, current_turn, turn_deadline, seed
+FROM games
+WHERE code = ?1
+`
+
+// GetGameByCode returns the game with the given code.
+func (q *Queries) GetGameByCode(ctx context.Context, code string) (Games, error) {
+	row := q.db.QueryRowContext(ctx, getGameByCode, code)
+	var i Games
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Name,
+		&i.DisplayName,
+		&i.CurrentTurn,
+		&i.TurnDeadline,
+		&i.Seed,
+	)
+	return i, err
+}
+
+const getGameEmpire = `-- name: GetGameEmpire :one
+SELECT empires.id, empires.player_id, players.name
+FROM empires,
+     players
+WHERE empires.id = ?1
+  AND empires.game_id = ?2
+  AND players.id = empires.player_id
+`
+
+type GetGameEmpireParams struct {
+	EmpireID int64
+	GameID   int64
+}
+
+type GetGameEmpireRow struct {
+	ID       int64
+	PlayerID int64
+	Name     string
+}
+
+// GetGameEmpire returns an empire in a game with the name of its player.
+func (q *Queries) GetGameEmpire(ctx context.Context, arg GetGameEmpireParams) (GetGameEmpireRow, error) {
+	row := q.db.QueryRowContext(ctx, getGameEmpire, arg.EmpireID, arg.GameID)
+	var i GetGameEmpireRow
+	err := row.Scan(
+		&i.ID,
+		&i.PlayerID,
+		&i.Name,
+	)
+	return i, err
+}
+
+const getGameNaturalResource = `-- name: GetGameNaturalResource :one
+SELECT natural_resources.id, natural_resources.kind, natural_resources.quantity, natural_resources.yield_pct
+FROM natural_resources,
+     orbits,
+     stars,
+     systems
+WHERE natural_resources.id = ?1
+  AND orbits.id = natural_resources.orbit_id
+  AND stars.id = orbits.star_id
+  AND systems.id = stars.system_id
+  AND systems.game_id = ?2
+`
+
+type GetGameNaturalResourceParams struct {
+	DepositID int64
+	GameID    int64
+}
+
+type GetGameNaturalResourceRow struct {
+	ID       int64
+	Kind     string
+	Quantity int64
+	YieldPct int64
+}
+
+// GetGameNaturalResource returns a deposit in a game.
+func (q *Queries) GetGameNaturalResource(ctx context.Context, arg GetGameNaturalResourceParams) (GetGameNaturalResourceRow, error) {
+	row := q.db.QueryRowContext(ctx, getGameNaturalResource, arg.DepositID, arg.GameID)
+	var i GetGameNaturalResourceRow
+	err := row.Scan(
+		&i.ID,
+		&i.Kind,
+		&i.Quantity,
+		&i.YieldPct,
+	)
+	return i, err
+}
+
+const getGameOrbit = `-- name: GetGameOrbit :one
+SELECT orbits.id, orbits.kind, orbits.habitability
+FROM orbits,
+     stars,
+     systems
+WHERE orbits.id = ?1
+  AND stars.id = orbits.star_id
+  AND systems.id = stars.system_id
+  AND systems.game_id = ?2
+`
+
+type GetGameOrbitParams struct {
+	OrbitID int64
+	GameID  int64
+}
+
+type GetGameOrbitRow struct {
+	ID           int64
+	Kind         string
+	Habitability int64
+}
+
+// GetGameOrbit returns an orbit in a game.
+func (q *Queries) GetGameOrbit(ctx context.Context, arg GetGameOrbitParams) (GetGameOrbitRow, error) {
+	row := q.db.QueryRowContext(ctx, getGameOrbit, arg.OrbitID, arg.GameID)
+	var i GetGameOrbitRow
+	err := row.Scan(
+		&i.ID,
+		&i.Kind,
+		&i.Habitability,
+	)
+	return i, err
+}
+
+const getGameStar = `-- name: GetGameStar :one
+SELECT stars.id, stars.class, stars.size, stars.luminosity
+FROM stars,
+     systems
+WHERE stars.id = ?1
+  AND systems.id = stars.system_id
+  AND systems.game_id = ?2
+`
+
+type GetGameStarParams struct {
+	StarID int64
+	GameID int64
+}
+
+type GetGameStarRow struct {
+	ID         int64
+	Class      string
+	Size       string
+	Luminosity int64
+}
+
+// GetGameStar returns a star in a game.
+func (q *Queries) GetGameStar(ctx context.Context, arg GetGameStarParams) (GetGameStarRow, error) {
+	row := q.db.QueryRowContext(ctx, getGameStar, arg.StarID, arg.GameID)
+	var i GetGameStarRow
+	err := row.Scan(
+		&i.ID,
+		&i.Class,
+		&i.Size,
+		&i.Luminosity,
+	)
+	return i, err
+}
+
+const getLatestAcceptedOrders = `-- name: GetLatestAcceptedOrders :one
+SELECT id, empire_id, turn, revision, text, accepted, diagnostics, created_at
+FROM orders
+WHERE empire_id = ?1
+  AND turn = ?2
+  AND accepted = 1
+ORDER BY revision DESC
+LIMIT 1
+`
+
+type GetLatestAcceptedOrdersParams struct {
+	EmpireID int64
+	Turn     int64
+}
+
+// GetLatestAcceptedOrders returns the latest revision of an empire's orders
+// for a turn that had no errors.
+func (q *Queries) GetLatestAcceptedOrders(ctx context.Context, arg GetLatestAcceptedOrdersParams) (Orders, error) {
+	row := q.db.QueryRowContext(ctx, getLatestAcceptedOrders, arg.EmpireID, arg.Turn)
+	var i Orders
+	err := row.Scan(
+		&i.ID,
+		&i.EmpireID,
+		&i.Turn,
+		&i.Revision,
+		&i.Text,
+		&i.Accepted,
+		&i.Diagnostics,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getLatestOrders = `-- name: GetLatestOrders :one
+SELECT id, empire_id, turn, revision, text, accepted, diagnostics, created_at
+FROM orders
+WHERE empire_id = ?1
+  AND turn = ?2
+ORDER BY revision DESC
+LIMIT 1
+`
+
+type GetLatestOrdersParams struct {
+	EmpireID int64
+	Turn     int64
+}
+
+// GetLatestOrders returns the latest revision of an empire's orders for a turn.
+func (q *Queries) GetLatestOrders(ctx context.Context, arg GetLatestOrdersParams) (Orders, error) {
+	row := q.db.QueryRowContext(ctx, getLatestOrders, arg.EmpireID, arg.Turn)
+	var i Orders
+	err := row.Scan(
+		&i.ID,
+		&i.EmpireID,
+		&i.Turn,
+		&i.Revision,
+		&i.Text,
+		&i.Accepted,
+		&i.Diagnostics,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getPlayerByName = `-- name: GetPlayerByName :one
+SELECT id
+FROM players
+WHERE name = ?1
+`
+
+// GetPlayerByName returns the id of the player with the name.
+func (q *Queries) GetPlayerByName(ctx context.Context, name string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getPlayerByName, name)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getPlayerLogin = `-- name: GetPlayerLogin :one
+SELECT id, name, key_hash, is_gm
+FROM players
+WHERE name = ?1
+`
+
+// GetPlayerLogin returns what is needed to log a player in.
+func (q *Queries) GetPlayerLogin(ctx context.Context, name string) (Players, error) {
+	row := q.db.QueryRowContext(ctx, getPlayerLogin, name)
+	var i Players
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.KeyHash,
+		&i.IsGm,
+	)
+	return i, err
+}
+
+const getReport = `-- name: GetReport :one
+SELECT id, empire_id, turn, text_report, json_report, created_at
+FROM reports
+WHERE empire_id = ?1
+  AND turn = ?2
+`
+
+type GetReportParams struct {
+	EmpireID int64
+	Turn     int64
+}
+
+// GetReport returns an empire's report for a turn.
+func (q *Queries) GetReport(ctx context.Context, arg GetReportParams) (Reports, error) {
+	row := q.db.QueryRowContext(ctx, getReport, arg.EmpireID, arg.Turn)
+	var i Reports
+	err := row.Scan(
+		&i.ID,
+		&i.EmpireID,
+		&i.Turn,
+		&i.TextReport,
+		&i.JsonReport,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getSessionPlayer = `-- name: GetSessionPlayer :one
+SELECT players.id, players.name, players.is_gm, sessions.expires_at
+FROM sessions,
+     players
+WHERE sessions.token_hash = ?1
+  AND players.id = sessions.player_id
+`
+
+type GetSessionPlayerRow struct {
+	ID        int64
+	Name      string
+	IsGm      int64
+	ExpiresAt time.Time
+}
+
+// GetSessionPlayer returns the player for a session and when the session expires.
+func (q *Queries) GetSessionPlayer(ctx context.Context, tokenHash string) (GetSessionPlayerRow, error) {
+	row := q.db.QueryRowContext(ctx, getSessionPlayer, tokenHash)
+	var i GetSessionPlayerRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.IsGm,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const getSnapshot = `-- name: GetSnapshot :one
+SELECT state
+FROM snapshots
+WHERE game_id = ?1
+  AND turn = ?2
+`
+
+type GetSnapshotParams struct {
+	GameID int64
+	Turn   int64
+}
+
+// GetSnapshot returns the state of a game at the start of a turn.
+func (q *Queries) GetSnapshot(ctx context.Context, arg GetSnapshotParams) ([]byte, error) {
+	row := q.db.QueryRowContext(ctx, getSnapshot, arg.GameID, arg.Turn)
+	var state []byte
+	err := row.Scan(&state)
+	return state, err
+}
+
+const getStockpile = `-- name: GetStockpile :one
+SELECT quantity
+FROM stockpiles
+WHERE empire_id = ?1
+  AND kind = ?2
+`
+
+type GetStockpileParams struct {
+	EmpireID int64
+	Kind     string
+}
+
+// GetStockpile returns the quantity of a resource an empire has on hand.
+func (q *Queries) GetStockpile(ctx context.Context, arg GetStockpileParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getStockpile, arg.EmpireID, arg.Kind)
+	var quantity int64
+	err := row.Scan(&quantity)
+	return quantity, err
+}
+
+const getTurnLock = `-- name: GetTurnLock :one
+SELECT owner, expires_at
+FROM turn_locks
+WHERE game_id = ?1
+  AND turn = ?2
+`
+
+type GetTurnLockParams struct {
+	GameID int64
+	Turn   int64
+}
+
+type GetTurnLockRow struct {
+	Owner     string
+	ExpiresAt time.Time
+}
+
+// GetTurnLock returns the claim on a game's turn.
+func (q *Queries) GetTurnLock(ctx context.Context, arg GetTurnLockParams) (GetTurnLockRow, error) {
+	row := q.db.QueryRowContext(ctx, getTurnLock, arg.GameID, arg.Turn)
+	var i GetTurnLockRow
+	err := row.Scan(
+		&i.Owner,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const listDraftArticles = `-- name: ListDraftArticles :many
+SELECT id, title, slug, published, date_published, date_updated
+FROM articles
+WHERE published = 0
+ORDER BY id DESC
+`
+
+// ListDraftArticles returns the unpublished articles, newest first.
+func (q *Queries) ListDraftArticles(ctx context.Context) ([]Articles, error) {
+	rows, err := q.db.QueryContext(ctx, listDraftArticles)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Articles
+	for rows.Next() {
+		var i Articles
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Slug,
+			&i.Published,
+			&i.DatePublished,
+			&i.DateUpdated,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEmpireSeenDeposits = `-- name: ListEmpireSeenDeposits :many
+SELECT seen_deposits.deposit_id,
+       natural_resources.orbit_id,
+       natural_resources.deposit_no,
+       seen_deposits.kind,
+       seen_deposits.quantity,
+       seen_deposits.yield_pct,
+       seen_deposits.turn
+FROM seen_deposits,
+     natural_resources
+WHERE seen_deposits.empire_id = ?1
+  AND natural_resources.id = seen_deposits.deposit_id
+ORDER BY natural_resources.orbit_id, natural_resources.deposit_no
+`
+
+type ListEmpireSeenDepositsRow struct {
+	DepositID int64
+	OrbitID   int64
+	DepositNo int64
+	Kind      string
+	Quantity  int64
+	YieldPct  int64
+	Turn      int64
+}
+
+// ListEmpireSeenDeposits returns the deposits an empire has observed.
+func (q *Queries) ListEmpireSeenDeposits(ctx context.Context, empireID int64) ([]ListEmpireSeenDepositsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listEmpireSeenDeposits, empireID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListEmpireSeenDepositsRow
+	for rows.Next() {
+		var i ListEmpireSeenDepositsRow
+		if err := rows.Scan(
+			&i.DepositID,
+			&i.OrbitID,
+			&i.DepositNo,
+			&i.Kind,
+			&i.Quantity,
+			&i.YieldPct,
+			&i.Turn,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEmpireSeenOrbits = `-- name: ListEmpireSeenOrbits :many
+SELECT seen_orbits.orbit_id,
+       stars.system_id,
+       stars.sequence,
+       orbits.orbit,
+       seen_orbits.kind,
+       seen_orbits.habitability,
+       seen_orbits.turn
+FROM seen_orbits,
+     orbits,
+     stars
+WHERE seen_orbits.empire_id = ?1
+  AND orbits.id = seen_orbits.orbit_id
+  AND stars.id = orbits.star_id
+ORDER BY stars.system_id, stars.sequence, orbits.orbit
+`
+
+type ListEmpireSeenOrbitsRow struct {
+	OrbitID      int64
+	SystemID     int64
+	Sequence     int64
+	Orbit        int64
+	Kind         string
+	Habitability int64
+	Turn         int64
+}
+
+// ListEmpireSeenOrbits returns the orbits an empire has observed.
+func (q *Queries) ListEmpireSeenOrbits(ctx context.Context, empireID int64) ([]ListEmpireSeenOrbitsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listEmpireSeenOrbits, empireID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListEmpireSeenOrbitsRow
+	for rows.Next() {
+		var i ListEmpireSeenOrbitsRow
+		if err := rows.Scan(
+			&i.OrbitID,
+			&i.SystemID,
+			&i.Sequence,
+			&i.Orbit,
+			&i.Kind,
+			&i.Habitability,
+			&i.Turn,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEmpireSeenSystems = `-- name: ListEmpireSeenSystems :many
+SELECT seen_systems.system_id, systems.x, systems.y, systems.z, seen_systems.stars, seen_systems.turn
+FROM seen_systems,
+     systems
+WHERE seen_systems.empire_id = ?1
+  AND systems.id = seen_systems.system_id
+ORDER BY seen_systems.system_id
+`
+
+type ListEmpireSeenSystemsRow struct {
+	SystemID int64
+	X        int64
+	Y        int64
+	Z        int64
+	Stars    int64
+	Turn     int64
+}
+
+// ListEmpireSeenSystems returns the systems an empire has observed.
+func (q *Queries) ListEmpireSeenSystems(ctx context.Context, empireID int64) ([]ListEmpireSeenSystemsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listEmpireSeenSystems, empireID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListEmpireSeenSystemsRow
+	for rows.Next() {
+		var i ListEmpireSeenSystemsRow
+		if err := rows.Scan(
+			&i.SystemID,
+			&i.X,
+			&i.Y,
+			&i.Z,
+			&i.Stars,
+			&i.Turn,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGameAcceptedOrders = `-- name: ListGameAcceptedOrders :many
+SELECT orders.id, orders.empire_id, orders.turn, orders.revision, orders.text, orders.accepted, orders.diagnostics, orders.created_at
+FROM orders,
+     empires
+WHERE empires.game_id = ?1
+  AND orders.empire_id = empires.id
+  AND orders.turn = ?2
+  AND orders.accepted = 1
+  AND orders.revision = (SELECT MAX(latest.revision)
+                         FROM orders latest
+                         WHERE latest.empire_id = orders.empire_id
+                           AND latest.turn = orders.turn
+                           AND latest.accepted = 1)
+ORDER BY orders.empire_id
+`
+
+type ListGameAcceptedOrdersParams struct {
+	GameID int64
+	Turn   int64
+}
+
+// ListGameAcceptedOrders returns the latest accepted revision of each
+// empire's orders for a turn, ordered by empire.
+func (q *Queries) ListGameAcceptedOrders(ctx context.Context, arg ListGameAcceptedOrdersParams) ([]Orders, error) {
+	rows, err := q.db.QueryContext(ctx, listGameAcceptedOrders, arg.GameID, arg.Turn)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Orders
+	for rows.Next() {
+		var i Orders
+		if err := rows.Scan(
+			&i.ID,
+			&i.EmpireID,
+			&i.Turn,
+			&i.Revision,
+			&i.Text,
+			&i.Accepted,
+			&i.Diagnostics,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGameAuditLog = `-- name: ListGameAuditLog :many
+SELECT id, game_id, editor, target, change, reason, created_at
+FROM audit_log
+WHERE game_id = ?1
+ORDER BY id DESC
+`
+
+// ListGameAuditLog returns the manual edits to a game, newest first.
+func (q *Queries) ListGameAuditLog(ctx context.Context, gameID int64) ([]AuditLog, error) {
+	rows, err := q.db.QueryContext(ctx, listGameAuditLog, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditLog
+	for rows.Next() {
+		var i AuditLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.GameID,
+			&i.Editor,
+			&i.Target,
+			&i.Change,
+			&i.Reason,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGameBattles = `-- name: ListGameBattles :many
+SELECT battles.id, battles.empire_id, battles.system_id, battles.turn, battles.log, battles.created_at
+FROM battles,
+     empires
+WHERE empires.game_id = ?1
+  AND battles.empire_id = empires.id
+  AND battles.turn = ?2
+ORDER BY battles.empire_id, battles.id
+`
+
+type ListGameBattlesParams struct {
+	GameID int64
+	Turn   int64
+}
+
+// ListGameBattles returns the battles fought in a game on a turn,
+// ordered by empire and then by the order they were fought.
+func (q *Queries) ListGameBattles(ctx context.Context, arg ListGameBattlesParams) ([]Battles, error) {
+	rows, err := q.db.QueryContext(ctx, listGameBattles, arg.GameID, arg.Turn)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Battles
+	for rows.Next() {
+		var i Battles
+		if err := rows.Scan(
+			&i.ID,
+			&i.EmpireID,
+			&i.SystemID,
+			&i.Turn,
+			&i.Log,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGameColonies = `-- name: ListGameColonies :many
+SELECT colonies.id,
+       colonies.empire_id,
+       colonies.orbit_id,
+       colonies.kind,
+       colonies.population,
+       colonies.enclosures,
+       colonies.founded
+FROM colonies,
+     empires
+WHERE empires.game_id = ?1
+  AND colonies.empire_id = empires.id
+ORDER BY colonies.id
+`
+
+// ListGameColonies returns the colonies in a game, ordered by id.
+func (q *Queries) ListGameColonies(ctx context.Context, gameID int64) ([]Colonies, error) {
+	rows, err := q.db.QueryContext(ctx, listGameColonies, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Colonies
+	for rows.Next() {
+		var i Colonies
+		if err := rows.Scan(
+			&i.ID,
+			&i.EmpireID,
+			&i.OrbitID,
+			&i.Kind,
+			&i.Population,
+			&i.Enclosures,
+			&i.Founded,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGameEmpires = `-- name: ListGameEmpires :many
+SELECT id, game_id, player_id
+FROM empires
+WHERE game_id = ?1
+ORDER BY id
+`
+
+// ListGameEmpires returns the empires in a game, ordered by id.
+func (q *Queries) ListGameEmpires(ctx context.Context, gameID int64) ([]Empires, error) {
+	rows, err := q.db.QueryContext(ctx, listGameEmpires, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Empires
+	for rows.Next() {
+		var i Empires
+		if err := rows.Scan(
+			&i.ID,
+			&i.GameID,
+			&i.PlayerID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGameFleetCargo = `-- name: ListGameFleetCargo :many
+SELECT fleet_cargo.fleet_id, fleet_cargo.kind, fleet_cargo.quantity
+FROM fleet_cargo,
+     fleets,
+     empires
+WHERE empires.game_id = ?1
+  AND fleets.empire_id = empires.id
+  AND fleet_cargo.fleet_id = fleets.id
+ORDER BY fleet_cargo.fleet_id, fleet_cargo.kind
+`
+
+// ListGameFleetCargo returns the cargo in every fleet in a game.
+func (q *Queries) ListGameFleetCargo(ctx context.Context, gameID int64) ([]FleetCargo, error) {
+	rows, err := q.db.QueryContext(ctx, listGameFleetCargo, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FleetCargo
+	for rows.Next() {
+		var i FleetCargo
+		if err := rows.Scan(
+			&i.FleetID,
+			&i.Kind,
+			&i.Quantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGameFleetShips = `-- name: ListGameFleetShips :many
+SELECT fleet_ships.fleet_id, fleet_ships.class, fleet_ships.quantity
+FROM fleet_ships,
+     fleets,
+     empires
+WHERE empires.game_id = ?1
+  AND fleets.empire_id = empires.id
+  AND fleet_ships.fleet_id = fleets.id
+ORDER BY fleet_ships.fleet_id, fleet_ships.class
+`
+
+// ListGameFleetShips returns the ships in every fleet in a game.
+func (q *Queries) ListGameFleetShips(ctx context.Context, gameID int64) ([]FleetShips, error) {
+	rows, err := q.db.QueryContext(ctx, listGameFleetShips, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FleetShips
+	for rows.Next() {
+		var i FleetShips
+		if err := rows.Scan(
+			&i.FleetID,
+			&i.Class,
+			&i.Quantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGameFleets = `-- name: ListGameFleets :many
+SELECT fleets.id,
+       fleets.empire_id,
+       fleets.system_id,
+       fleets.orbit_id,
+       fleets.destination_id,
+       fleets.arrives,
+       fleets.fuel
+FROM fleets,
+     empires
+WHERE empires.game_id = ?1
+  AND fleets.empire_id = empires.id
+ORDER BY fleets.id
+`
+
+// ListGameFleets returns the fleets in a game, ordered by id.
+func (q *Queries) ListGameFleets(ctx context.Context, gameID int64) ([]Fleets, error) {
+	rows, err := q.db.QueryContext(ctx, listGameFleets, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Fleets
+	for rows.Next() {
+		var i Fleets
+		if err := rows.Scan(
+			&i.ID,
+			&i.EmpireID,
+			&i.SystemID,
+			&i.OrbitID,
+			&i.DestinationID,
+			&i.Arrives,
+			&i.Fuel,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGameMineAssignments = `-- name: ListGameMineAssignments :many
+SELECT mine_assignments.mine_id, mine_assignments.deposit_id, mine_assignments.units
+FROM mine_assignments,
+     mines,
+     empires
+WHERE empires.game_id = ?1
+  AND mines.empire_id = empires.id
+  AND mine_assignments.mine_id = mines.id
+ORDER BY mine_assignments.mine_id, mine_assignments.deposit_id
+`
+
+type ListGameMineAssignmentsRow struct {
+	MineID    int64
+	DepositID int64
+	Units     int64
+}
+
+// ListGameMineAssignments returns the deposits worked by the mines in a game.
+func (q *Queries) ListGameMineAssignments(ctx context.Context, gameID int64) ([]ListGameMineAssignmentsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listGameMineAssignments, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListGameMineAssignmentsRow
+	for rows.Next() {
+		var i ListGameMineAssignmentsRow
+		if err := rows.Scan(
+			&i.MineID,
+			&i.DepositID,
+			&i.Units,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGameMines = `-- name: ListGameMines :many
+SELECT mines.id, mines.empire_id, mines.orbit_id, mines.units
+FROM mines,
+     empires
+WHERE empires.game_id = ?1
+  AND mines.empire_id = empires.id
+ORDER BY mines.id
+`
+
+// ListGameMines returns the mines in a game, ordered by id.
+func (q *Queries) ListGameMines(ctx context.Context, gameID int64) ([]Mines, error) {
+	rows, err := q.db.QueryContext(ctx, listGameMines, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Mines
+	for rows.Next() {
+		var i Mines
+		if err := rows.Scan(
+			&i.ID,
+			&i.EmpireID,
+			&i.OrbitID,
+			&i.Units,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGameNaturalResources = `-- name: ListGameNaturalResources :many
+SELECT natural_resources.id,
+       natural_resources.orbit_id,
+       natural_resources.deposit_no,
+       natural_resources.kind,
+       natural_resources.quantity,
+       natural_resources.yield_pct
+FROM natural_resources,
+     orbits,
+     stars,
+     systems
+WHERE systems.game_id = ?1
+  AND stars.system_id = systems.id
+  AND orbits.star_id = stars.id
+  AND natural_resources.orbit_id = orbits.id
+ORDER BY natural_resources.orbit_id, natural_resources.deposit_no
+`
+
+// ListGameNaturalResources returns all the natural resources in a game.
+func (q *Queries) ListGameNaturalResources(ctx context.Context, gameID int64) ([]NaturalResources, error) {
+	rows, err := q.db.QueryContext(ctx, listGameNaturalResources, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []NaturalResources
+	for rows.Next() {
+		var i NaturalResources
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrbitID,
+			&i.DepositNo,
+			&i.Kind,
+			&i.Quantity,
+			&i.YieldPct,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGameOrbits = `-- name: ListGameOrbits :many
+SELECT orbits.id, orbits.star_id, orbits.orbit, orbits.kind, orbits.habitability
+FROM orbits,
+     stars,
+     systems
+WHERE systems.game_id = ?1
+  AND stars.system_id = systems.id
+  AND orbits.star_id = stars.id
+ORDER BY orbits.star_id, orbits.orbit
+`
+
+// ListGameOrbits returns all the orbits in a game.
+func (q *Queries) ListGameOrbits(ctx context.Context, gameID int64) ([]Orbits, error) {
+	rows, err := q.db.QueryContext(ctx, listGameOrbits, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Orbits
+	for rows.Next() {
+		var i Orbits
+		if err := rows.Scan(
+			&i.ID,
+			&i.StarID,
+			&i.Orbit,
+			&i.Kind,
+			&i.Habitability,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGameOrbitsInBox = `-- name: ListGameOrbitsInBox :many
+SELECT orbits.id, orbits.star_id, orbits.orbit, orbits.kind, orbits.habitability
+FROM orbits,
+     stars,
+     systems
+WHERE systems.game_id = ?1
+  AND systems.x BETWEEN ?2 AND ?3
+  AND systems.y BETWEEN ?4 AND ?5
+  AND systems.z BETWEEN ?6 AND ?7
+  AND stars.system_id = systems.id
+  AND orbits.star_id = stars.id
+  AND orbits.kind != 'empty'
+ORDER BY orbits.star_id, orbits.orbit
+`
+
+type ListGameOrbitsInBoxParams struct {
+	GameID int64
+	MinX   int64
+	MaxX   int64
+	MinY   int64
+	MaxY   int64
+	MinZ   int64
+	MaxZ   int64
+}
+
+// ListGameOrbitsInBox returns the non-empty orbits in a game that are inside a box.
+func (q *Queries) ListGameOrbitsInBox(ctx context.Context, arg ListGameOrbitsInBoxParams) ([]Orbits, error) {
+	rows, err := q.db.QueryContext(ctx, listGameOrbitsInBox,
+		arg.GameID,
+		arg.MinX,
+		arg.MaxX,
+		arg.MinY,
+		arg.MaxY,
+		arg.MinZ,
+		arg.MaxZ,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Orbits
+	for rows.Next() {
+		var i Orbits
+		if err := rows.Scan(
+			&i.ID,
+			&i.StarID,
+			&i.Orbit,
+			&i.Kind,
+			&i.Habitability,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGamePlayers = `-- name: ListGamePlayers :many
+SELECT empires.id, players.name
+FROM empires,
+     players
+WHERE empires.game_id = ?1
+  AND players.id = empires.player_id
+ORDER BY empires.id
+`
+
+type ListGamePlayersRow struct {
+	ID   int64
+	Name string
+}
+
+// ListGamePlayers returns the name of the player for each empire in a game.
+func (q *Queries) ListGamePlayers(ctx context.Context, gameID int64) ([]ListGamePlayersRow, error) {
+	rows, err := q.db.QueryContext(ctx, listGamePlayers, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListGamePlayersRow
+	for rows.Next() {
+		var i ListGamePlayersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGameRejections = `-- name: ListGameRejections :many
+SELECT rejections.id, rejections.empire_id, rejections.turn, rejections.line, rejections.reason, rejections.created_at
+FROM rejections,
+     empires
+WHERE empires.game_id = ?1
+  AND rejections.empire_id = empires.id
+  AND rejections.turn = ?2
+ORDER BY rejections.empire_id, rejections.id
+`
+
+type ListGameRejectionsParams struct {
+	GameID int64
+	Turn   int64
+}
+
+// ListGameRejections returns the orders rejected in a game on a turn,
+// ordered by empire and then by the order they were rejected.
+func (q *Queries) ListGameRejections(ctx context.Context, arg ListGameRejectionsParams) ([]Rejections, error) {
+	rows, err := q.db.QueryContext(ctx, listGameRejections, arg.GameID, arg.Turn)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Rejections
+	for rows.Next() {
+		var i Rejections
+		if err := rows.Scan(
+			&i.ID,
+			&i.EmpireID,
+			&i.Turn,
+			&i.Line,
+			&i.Reason,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGameReports = `-- name: ListGameReports :many
+SELECT reports.empire_id, reports.turn, reports.created_at
+FROM reports,
+     empires
+WHERE empires.game_id = ?1
+  AND reports.empire_id = empires.id
+ORDER BY reports.empire_id, reports.turn
+`
+
+type ListGameReportsRow struct {
+	EmpireID  int64
+	Turn      int64
+	CreatedAt time.Time
+}
+
+// ListGameReports returns the reports available for a game, without the
+// report text, ordered by empire and turn.
+func (q *Queries) ListGameReports(ctx context.Context, gameID int64) ([]ListGameReportsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listGameReports, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListGameReportsRow
+	for rows.Next() {
+		var i ListGameReportsRow
+		if err := rows.Scan(
+			&i.EmpireID,
+			&i.Turn,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGameSnapshots = `-- name: ListGameSnapshots :many
+SELECT turn, created_at
+FROM snapshots
+WHERE game_id = ?1
+ORDER BY turn
+`
+
+type ListGameSnapshotsRow struct {
+	Turn      int64
+	CreatedAt time.Time
+}
+
+// ListGameSnapshots returns the snapshots for a game, without the state.
+func (q *Queries) ListGameSnapshots(ctx context.Context, gameID int64) ([]ListGameSnapshotsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listGameSnapshots, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListGameSnapshotsRow
+	for rows.Next() {
+		var i ListGameSnapshotsRow
+		if err := rows.Scan(
+			&i.Turn,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGameStars = `-- name: ListGameStars :many
+SELECT stars.id, stars.system_id, stars.sequence, stars.class, stars.size, stars.luminosity
+FROM stars,
+     systems
+WHERE systems.game_id = ?1
+  AND stars.system_id = systems.id
+ORDER BY stars.system_id, stars.sequence
+`
+
+// ListGameStars returns all the stars in a game.
+func (q *Queries) ListGameStars(ctx context.Context, gameID int64) ([]Stars, error) {
+	rows, err := q.db.QueryContext(ctx, listGameStars, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Stars
+	for rows.Next() {
+		var i Stars
+		if err := rows.Scan(
+			&i.ID,
+			&i.SystemID,
+			&i.Sequence,
+			&i.Class,
+			&i.Size,
+			&i.Luminosity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGameStarsInBox = `-- name: ListGameStarsInBox :many
+SELECT stars.id, stars.system_id, stars.sequence, stars.class, stars.size, stars.luminosity
+FROM stars,
+     systems
+WHERE systems.game_id = ?1
+  AND systems.x BETWEEN ?2 AND ?3
+  AND systems.y BETWEEN ?4 AND ?5
+  AND systems.z BETWEEN ?6 AND ?7
+  AND stars.system_id = systems.id
+ORDER BY stars.system_id, stars.sequence
+`
+
+type ListGameStarsInBoxParams struct {
+	GameID int64
+	MinX   int64
+	MaxX   int64
+	MinY   int64
+	MaxY   int64
+	MinZ   int64
+	MaxZ   int64
+}
+
+// ListGameStarsInBox returns the stars in a game that are inside a box.
+func (q *Queries) ListGameStarsInBox(ctx context.Context, arg ListGameStarsInBoxParams) ([]Stars, error) {
+	rows, err := q.db.QueryContext(ctx, listGameStarsInBox,
+		arg.GameID,
+		arg.MinX,
+		arg.MaxX,
+		arg.MinY,
+		arg.MaxY,
+		arg.MinZ,
+		arg.MaxZ,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Stars
+	for rows.Next() {
+		var i Stars
+		if err := rows.Scan(
+			&i.ID,
+			&i.SystemID,
+			&i.Sequence,
+			&i.Class,
+			&i.Size,
+			&i.Luminosity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGameStockpiles = `-- name: ListGameStockpiles :many
+SELECT stockpiles.empire_id, stockpiles.kind, stockpiles.quantity
+FROM stockpiles,
+     empires
+WHERE empires.game_id = ?1
+  AND stockpiles.empire_id = empires.id
+ORDER BY stockpiles.empire_id, stockpiles.kind
+`
+
+type ListGameStockpilesRow struct {
+	EmpireID int64
+	Kind     string
+	Quantity int64
+}
+
+// ListGameStockpiles returns the stockpiles of the empires in a game.
+func (q *Queries) ListGameStockpiles(ctx context.Context, gameID int64) ([]ListGameStockpilesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listGameStockpiles, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListGameStockpilesRow
+	for rows.Next() {
+		var i ListGameStockpilesRow
+		if err := rows.Scan(
+			&i.EmpireID,
+			&i.Kind,
+			&i.Quantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGameSystems = `-- name: ListGameSystems :many
+SELECT id, game_id, x, y, z
+FROM systems
+WHERE game_id = ?1
+ORDER BY id
+`
+
+// ListGameSystems returns all the systems in a game.
+func (q *Queries) ListGameSystems(ctx context.Context, gameID int64) ([]Systems, error) {
+	rows, err := q.db.QueryContext(ctx, listGameSystems, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Systems
+	for rows.Next() {
+		var i Systems
+		if err := rows.Scan(
+			&i.ID,
+			&i.GameID,
+			&i.X,
+			&i.Y,
+			&i.Z,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGameSystemsInBox = `-- name: ListGameSystemsInBox :many
+SELECT id, game_id, x, y, z
+FROM systems
+WHERE game_id = ?1
+  AND x BETWEEN ?2 AND ?3
+  AND y BETWEEN ?4 AND ?5
+  AND z BETWEEN ?6 AND ?7
+ORDER BY id
+`
+
+type ListGameSystemsInBoxParams struct {
+	GameID int64
+	MinX   int64
+	MaxX   int64
+	MinY   int64
+	MaxY   int64
+	MinZ   int64
+	MaxZ   int64
+}
+
+// ListGameSystemsInBox returns the systems in a game that are inside a box.
+func (q *Queries) ListGameSystemsInBox(ctx context.Context, arg ListGameSystemsInBoxParams) ([]Systems, error) {
+	rows, err := q.db.QueryContext(ctx, listGameSystemsInBox,
+		arg.GameID,
+		arg.MinX,
+		arg.MaxX,
+		arg.MinY,
+		arg.MaxY,
+		arg.MinZ,
+		arg.MaxZ,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Systems
+	for rows.Next() {
+		var i Systems
+		if err := rows.Scan(
+			&i.ID,
+			&i.GameID,
+			&i.X,
+			&i.Y,
+			&i.Z,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGameWarps = `-- name: ListGameWarps :many
+SELECT id, game_id, from_system_id, to_system_id
+FROM warps
+WHERE game_id = ?1
+ORDER BY from_system_id, to_system_id
+`
+
+// ListGameWarps returns all the warps in a game.
+func (q *Queries) ListGameWarps(ctx context.Context, gameID int64) ([]Warps, error) {
+	rows, err := q.db.QueryContext(ctx, listGameWarps, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Warps
+	for rows.Next() {
+		var i Warps
+		if err := rows.Scan(
+			&i.ID,
+			&i.GameID,
+			&i.FromSystemID,
+			&i.ToSystemID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGames = `-- name: ListGames :many
+SELECT id, code, name, display_name, current_turn, turn_deadline, seed
+FROM games
+ORDER BY code
+`
+
+// ListGames returns all the games.
+func (q *Queries) ListGames(ctx context.Context) ([]Games, error) {
+	rows, err := q.db.QueryContext(ctx, listGames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Games
+	for rows.Next() {
+		var i Games
+		if err := rows.Scan(
+			&i.ID,
+			&i.Code,
+			&i.Name,
+			&i.DisplayName,
+			&i.CurrentTurn,
+			&i.TurnDeadline,
+			&i.Seed,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPlayerEmpires = `-- name: ListPlayerEmpires :many
+SELECT empires.id, games.code
+FROM empires,
+     games
+WHERE empires.player_id = ?1
+  AND games.id = empires.game_id
+ORDER BY games.code, empires.id
+`
+
+type ListPlayerEmpiresRow struct {
+	ID   int64
+	Code string
+}
+
+// ListPlayerEmpires returns the empires a player controls, with the
+// code of each game, ordered by game and empire.
+func (q *Queries) ListPlayerEmpires(ctx context.Context, playerID int64) ([]ListPlayerEmpiresRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPlayerEmpires, playerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPlayerEmpiresRow
+	for rows.Next() {
+		var i ListPlayerEmpiresRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Code,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPublishedArticles = `-- name: ListPublishedArticles :many
+SELECT id, title, slug, published, date_published, date_updated
+FROM articles
+WHERE published = 1
+ORDER BY id DESC
+`
+
+// ListPublishedArticles returns the published articles, newest first.
+func (q *Queries) ListPublishedArticles(ctx context.Context) ([]Articles, error) {
+	rows, err := q.db.QueryContext(ctx, listPublishedArticles)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Articles
+	for rows.Next() {
+		var i Articles
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Slug,
+			&i.Published,
+			&i.DatePublished,
+			&i.DateUpdated,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecentArticles = `-- name: ListRecentArticles :many
+SELECT id, title, slug, published, date_published, date_updated
+FROM articles
+WHERE published = 1
+ORDER BY date_published DESC, id DESC
+LIMIT ?1
+`
+
+// ListRecentArticles returns the most recently published articles.
+func (q *Queries) ListRecentArticles(ctx context.Context, limit int64) ([]Articles, error) {
+	rows, err := q.db.QueryContext(ctx, listRecentArticles, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Articles
+	for rows.Next() {
+		var i Articles
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Slug,
+			&i.Published,
+			&i.DatePublished,
+			&i.DateUpdated,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSchedules = `-- name: ListSchedules :many
+SELECT game_id, schedule
+FROM schedules
+ORDER BY game_id
+`
+
+// ListSchedules returns the schedules for all the games.
+func (q *Queries) ListSchedules(ctx context.Context) ([]Schedules, error) {
+	rows, err := q.db.QueryContext(ctx, listSchedules)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Schedules
+	for rows.Next() {
+		var i Schedules
+		if err := rows.Scan(
+			&i.GameID,
+			&i.Schedule,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const publishArticle = `-- name: PublishArticle :execrows
+UPDATE articles
+SET published      = 1,
+    date_published = coalesce(date_published, ?1),
+    date_updated   = ?1
+WHERE id = ?2
+`
+
+type PublishArticleParams struct {
+	DateUpdated time.Time
+	ArticleID   int64
+}
+
+// PublishArticle publishes an article. The publication date is only
+// set the first time the article is published.
+func (q *Queries) PublishArticle(ctx context.Context, arg PublishArticleParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, publishArticle, arg.DateUpdated, arg.ArticleID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreColony = `-- name: RestoreColony :exec
+INSERT INTO colonies (id, empire_id, orbit_id, kind, population, enclosures, founded)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7)
+ON CONFLICT (id) DO UPDATE SET population = excluded.population,
+                               enclosures = excluded.enclosures,
+                               founded    = excluded.founded
+`
+
+type RestoreColonyParams struct {
+	ColonyID   int64
+	EmpireID   int64
+	OrbitID    int64
+	Kind       string
+	Population int64
+	Enclosures int64
+	Founded    int64
+}
+
+// RestoreColony creates or updates a colony, keeping its original id.
+func (q *Queries) RestoreColony(ctx context.Context, arg RestoreColonyParams) error {
+	_, err := q.db.ExecContext(ctx, restoreColony,
+		arg.ColonyID,
+		arg.EmpireID,
+		arg.OrbitID,
+		arg.Kind,
+		arg.Population,
+		arg.Enclosures,
+		arg.Founded,
+	)
+	return err
+}
+
+const restoreFleet = `-- name: RestoreFleet :exec
+INSERT INTO fleets (id, empire_id, system_id, orbit_id, destination_id, arrives, fuel)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7)
+ON CONFLICT (id) DO NOTHING
+`
+
+type RestoreFleetParams struct {
+	FleetID       int64
+	EmpireID      int64
+	SystemID      int64
+	OrbitID       sql.NullInt64
+	DestinationID sql.NullInt64
+	Arrives       sql.NullInt64
+	Fuel          int64
+}
+
+// RestoreFleet creates a fleet with its original id if it no longer exists.
+func (q *Queries) RestoreFleet(ctx context.Context, arg RestoreFleetParams) error {
+	_, err := q.db.ExecContext(ctx, restoreFleet,
+		arg.FleetID,
+		arg.EmpireID,
+		arg.SystemID,
+		arg.OrbitID,
+		arg.DestinationID,
+		arg.Arrives,
+		arg.Fuel,
+	)
+	return err
+}
+
+const restoreMine = `-- name: RestoreMine :exec
+INSERT INTO mines (id, empire_id, orbit_id, units)
+VALUES (?1, ?2, ?3, ?4)
+ON CONFLICT (id) DO NOTHING
+`
+
+type RestoreMineParams struct {
+	MineID   int64
+	EmpireID int64
+	OrbitID  int64
+	Units    int64
+}
+
+// RestoreMine creates a mine with its original id if it no longer exists.
+func (q *Queries) RestoreMine(ctx context.Context, arg RestoreMineParams) error {
+	_, err := q.db.ExecContext(ctx, restoreMine,
+		arg.MineID,
+		arg.EmpireID,
+		arg.OrbitID,
+		arg.Units,
+	)
+	return err
+}
+
+const unpublishArticle = `-- name: UnpublishArticle :execrows
+UPDATE articles
+SET published    = 0,
+    date_updated = ?1
+WHERE id = ?2
+`
+
+type UnpublishArticleParams struct {
+	DateUpdated time.Time
+	ArticleID   int64
+}
+
+// UnpublishArticle returns an article to the drafts.
+func (q *Queries) UnpublishArticle(ctx context.Context, arg UnpublishArticleParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, unpublishArticle, arg.DateUpdated, arg.ArticleID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateArticle = `-- name: UpdateArticle :execrows
+UPDATE articles
+SET title        = ?1,
+    slug         = ?2,
+    date_updated = ?3
+WHERE id = ?4
+`
+
+type UpdateArticleParams struct {
+	Title       string
+	Slug        string
+	DateUpdated time.Time
+	ArticleID   int64
+}
+
+// UpdateArticle changes the title and slug of an article.
+func (q *Queries) UpdateArticle(ctx context.Context, arg UpdateArticleParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateArticle,
+		arg.Title,
+		arg.Slug,
+		arg.DateUpdated,
+		arg.ArticleID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateEmpirePlayer = `-- name: UpdateEmpirePlayer :exec
+UPDATE empires
+SET player_id = ?1
+WHERE id = ?2
+`
+
+type UpdateEmpirePlayerParams struct {
+	PlayerID int64
+	EmpireID int64
+}
+
+// UpdateEmpirePlayer changes the player that controls an empire.
+func (q *Queries) UpdateEmpirePlayer(ctx context.Context, arg UpdateEmpirePlayerParams) error {
+	_, err := q.db.ExecContext(ctx, updateEmpirePlayer, arg.PlayerID, arg.EmpireID)
+	return err
+}
+
+const updateFleet = `-- name: UpdateFleet :exec
+UPDATE fleets
+SET system_id      = ?1,
+    orbit_id       = ?2,
+    destination_id = ?3,
+    arrives        = ?4,
+    fuel           = ?5
+WHERE id = ?6
+`
+
+type UpdateFleetParams struct {
+	SystemID      int64
+	OrbitID       sql.NullInt64
+	DestinationID sql.NullInt64
+	Arrives       sql.NullInt64
+	Fuel          int64
+	FleetID       int64
+}
+
+// UpdateFleet updates the location and fuel of a fleet.
+func (q *Queries) UpdateFleet(ctx context.Context, arg UpdateFleetParams) error {
+	_, err := q.db.ExecContext(ctx, updateFleet,
+		arg.SystemID,
+		arg.OrbitID,
+		arg.DestinationID,
+		arg.Arrives,
+		arg.Fuel,
+		arg.FleetID,
+	)
+	return err
+}
+
+const updateGameNames = `-- name: UpdateGameNames :exec
+UPDATE games
+SET name         = ?1,
+    display_name = ?2
+WHERE id = ?3
+`
+
+type UpdateGameNamesParams struct {
+	Name        string
+	DisplayName string
+	GameID      int64
+}
+
+// UpdateGameNames renames a game.
+func (q *Queries) UpdateGameNames(ctx context.Context, arg UpdateGameNamesParams) error {
+	_, err := q.db.ExecContext(ctx, updateGameNames, arg.Name, arg.DisplayName, arg.GameID)
+	return err
+}
+
+const updateGameSeed = `-- name: UpdateGameSeed :exec
+UPDATE games
+SET seed = ?1
+WHERE id = ?2
+`
+
+type UpdateGameSeedParams struct {
+	Seed   int64
+	GameID int64
+}
+
+// UpdateGameSeed sets the seed for the turn engine.
+func (q *Queries) UpdateGameSeed(ctx context.Context, arg UpdateGameSeedParams) error {
+	_, err := q.db.ExecContext(ctx, updateGameSeed, arg.Seed, arg.GameID)
+	return err
+}
+
+const updateGameTurn = `-- name: UpdateGameTurn :exec
+UPDATE games
+SET current_turn = ?1
+WHERE id = ?2
+`
+
+type UpdateGameTurnParams struct {
+	TurnNumber int64
+	GameID     int64
+}
+
+// UpdateGameTurn increments the game turn number.
+func (q *Queries) UpdateGameTurn(ctx context.Context, arg UpdateGameTurnParams) error {
+	_, err := q.db.ExecContext(ctx, updateGameTurn, arg.TurnNumber, arg.GameID)
+	return err
+}
+
+const updateGameTurnDeadline = `-- name: UpdateGameTurnDeadline :exec
+UPDATE games
+SET turn_deadline = ?1
+WHERE id = ?2
+`
+
+type UpdateGameTurnDeadlineParams struct {
+	TurnDeadline sql.NullTime
+	GameID       int64
+}
+
+// UpdateGameTurnDeadline sets the deadline for orders for the current turn.
+func (q *Queries) UpdateGameTurnDeadline(ctx context.Context, arg UpdateGameTurnDeadlineParams) error {
+	_, err := q.db.ExecContext(ctx, updateGameTurnDeadline, arg.TurnDeadline, arg.GameID)
+	return err
+}
+
+const updateNaturalResource = `-- name: UpdateNaturalResource :exec
+UPDATE natural_resources
+SET quantity  = ?1,
+    yield_pct = ?2
+WHERE id = ?3
+`
+
+type UpdateNaturalResourceParams struct {
+	Quantity  int64
+	YieldPct  int64
+	DepositID int64
+}
+
+// UpdateNaturalResource changes the quantity and yield of a deposit.
+func (q *Queries) UpdateNaturalResource(ctx context.Context, arg UpdateNaturalResourceParams) error {
+	_, err := q.db.ExecContext(ctx, updateNaturalResource, arg.Quantity, arg.YieldPct, arg.DepositID)
+	return err
+}
+
+const updateOrbit = `-- name: UpdateOrbit :exec
+UPDATE orbits
+SET kind         = ?1,
+    habitability = ?2
+WHERE id = ?3
+`
+
+type UpdateOrbitParams struct {
+	Kind         string
+	Habitability int64
+	OrbitID      int64
+}
+
+// UpdateOrbit changes the kind and habitability of an orbit.
+func (q *Queries) UpdateOrbit(ctx context.Context, arg UpdateOrbitParams) error {
+	_, err := q.db.ExecContext(ctx, updateOrbit, arg.Kind, arg.Habitability, arg.OrbitID)
+	return err
+}
+
+const updatePlayerKey = `-- name: UpdatePlayerKey :exec
+UPDATE players
+SET key_hash = ?1,
+    is_gm    = ?2
+WHERE id = ?3
+`
+
+type UpdatePlayerKeyParams struct {
+	KeyHash  string
+	IsGm     int64
+	PlayerID int64
+}
+
+// UpdatePlayerKey sets the hash of a player's access key and whether
+// the player is the GM.
+func (q *Queries) UpdatePlayerKey(ctx context.Context, arg UpdatePlayerKeyParams) error {
+	_, err := q.db.ExecContext(ctx, updatePlayerKey, arg.KeyHash, arg.IsGm, arg.PlayerID)
+	return err
+}
+
+const updateStar = `-- name: UpdateStar :exec
+UPDATE stars
+SET class      = ?1,
+    size       = ?2,
+    luminosity = ?3
+WHERE id = ?4
+`
+
+type UpdateStarParams struct {
+	Class      string
+	Size       string
+	Luminosity int64
+	StarID     int64
+}
+
+// UpdateStar changes the class, size, and luminosity of a star.
+func (q *Queries) UpdateStar(ctx context.Context, arg UpdateStarParams) error {
+	_, err := q.db.ExecContext(ctx, updateStar,
+		arg.Class,
+		arg.Size,
+		arg.Luminosity,
+		arg.StarID,
+	)
+	return err
+}
+
+const updateSystem = `-- name: UpdateSystem :exec
+UPDATE systems
+SET x = ?1,
+    y = ?2,
+    z = ?3
+WHERE id = ?4
+`
+
+type UpdateSystemParams struct {
+	X        int64
+	Y        int64
+	Z        int64
+	SystemID int64
+}
+
+// UpdateSystem updates the coordinates of a system.
+func (q *Queries) UpdateSystem(ctx context.Context, arg UpdateSystemParams) error {
+	_, err := q.db.ExecContext(ctx, updateSystem,
+		arg.X,
+		arg.Y,
+		arg.Z,
+		arg.SystemID,
+	)
+	return err
+}
+
+const upsertColony = `-- name: UpsertColony :one
+INSERT INTO colonies (empire_id, orbit_id, kind, population, enclosures, founded)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6)
+ON CONFLICT (empire_id, orbit_id, kind) DO UPDATE SET population = excluded.population,
+                                                      enclosures = excluded.enclosures
+RETURNING id
+`
+
+type UpsertColonyParams struct {
+	EmpireID   int64
+	OrbitID    int64
+	Kind       string
+	Population int64
+	Enclosures int64
+	Founded    int64
+}
+
+// UpsertColony creates or updates an empire's colony on an orbit.
+func (q *Queries) UpsertColony(ctx context.Context, arg UpsertColonyParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, upsertColony,
+		arg.EmpireID,
+		arg.OrbitID,
+		arg.Kind,
+		arg.Population,
+		arg.Enclosures,
+		arg.Founded,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const upsertMine = `-- name: UpsertMine :one
+INSERT INTO mines (empire_id, orbit_id, units)
+VALUES (?1, ?2, ?3)
+ON CONFLICT (empire_id, orbit_id) DO UPDATE SET units = excluded.units
+RETURNING id
+`
+
+type UpsertMineParams struct {
+	EmpireID int64
+	OrbitID  int64
+	Units    int64
+}
+
+// UpsertMine creates or updates an empire's mine on an orbit.
+func (q *Queries) UpsertMine(ctx context.Context, arg UpsertMineParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, upsertMine, arg.EmpireID, arg.OrbitID, arg.Units)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const upsertNaturalResource = `-- name: UpsertNaturalResource :one
+INSERT INTO natural_resources (orbit_id, deposit_no, kind, quantity, yield_pct)
+VALUES (?1, ?2, ?3, ?4, ?5)
+ON CONFLICT (orbit_id, deposit_no) DO UPDATE SET kind      = excluded.kind,
+                                                 quantity  = excluded.quantity,
+                                                 yield_pct = excluded.yield_pct
+RETURNING id
+`
+
+type UpsertNaturalResourceParams struct {
+	OrbitID   int64
+	DepositNo int64
+	Kind      string
+	Quantity  int64
+	YieldPct  int64
+}
+
+// UpsertNaturalResource creates or updates a natural resource (deposit).
+func (q *Queries) UpsertNaturalResource(ctx context.Context, arg UpsertNaturalResourceParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, upsertNaturalResource,
+		arg.OrbitID,
+		arg.DepositNo,
+		arg.Kind,
+		arg.Quantity,
+		arg.YieldPct,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const upsertOrbit = `-- name: UpsertOrbit :one
+INSERT INTO orbits (star_id, orbit, kind, habitability)
+VALUES (?1, ?2, ?3, ?4)
+ON CONFLICT (star_id, orbit) DO UPDATE SET kind         = excluded.kind,
+                                           habitability = excluded.habitability
+RETURNING id
+`
+
+type UpsertOrbitParams struct {
+	StarID       int64
+	Orbit        int64
+	Kind         string
+	Habitability int64
+}
+
+// UpsertOrbit creates or updates an orbit.
+func (q *Queries) UpsertOrbit(ctx context.Context, arg UpsertOrbitParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, upsertOrbit,
+		arg.StarID,
+		arg.Orbit,
+		arg.Kind,
+		arg.Habitability,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const upsertReport = `-- name: UpsertReport :exec
+INSERT INTO reports (empire_id, turn, text_report, json_report)
+VALUES (?1, ?2, ?3, ?4)
+ON CONFLICT (empire_id, turn) DO UPDATE SET text_report = excluded.text_report,
+                                            json_report = excluded.json_report,
+                                            created_at  = CURRENT_TIMESTAMP
+`
+
+type UpsertReportParams struct {
+	EmpireID   int64
+	Turn       int64
+	TextReport string
+	JsonReport string
+}
+
+// UpsertReport creates or replaces an empire's report for a turn.
+func (q *Queries) UpsertReport(ctx context.Context, arg UpsertReportParams) error {
+	_, err := q.db.ExecContext(ctx, upsertReport,
+		arg.EmpireID,
+		arg.Turn,
+		arg.TextReport,
+		arg.JsonReport,
+	)
+	return err
+}
+
+const upsertSchedule = `-- name: UpsertSchedule :exec
+INSERT INTO schedules (game_id, schedule)
+VALUES (?1, ?2)
+ON CONFLICT (game_id) DO UPDATE SET schedule = excluded.schedule
+`
+
+type UpsertScheduleParams struct {
+	GameID   int64
+	Schedule string
+}
+
+// UpsertSchedule sets when the server runs a game's turns.
+func (q *Queries) UpsertSchedule(ctx context.Context, arg UpsertScheduleParams) error {
+	_, err := q.db.ExecContext(ctx, upsertSchedule, arg.GameID, arg.Schedule)
+	return err
+}
+
+const upsertSeenDeposit = `-- name: UpsertSeenDeposit :exec
+INSERT INTO seen_deposits (empire_id, deposit_id, kind, quantity, yield_pct, turn)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6)
+ON CONFLICT (empire_id, deposit_id) DO UPDATE SET kind      = excluded.kind,
+                                                  quantity  = excluded.quantity,
+                                                  yield_pct = excluded.yield_pct,
+                                                  turn      = excluded.turn
+`
+
+type UpsertSeenDepositParams struct {
+	EmpireID  int64
+	DepositID int64
+	Kind      string
+	Quantity  int64
+	YieldPct  int64
+	Turn      int64
+}
+
+// UpsertSeenDeposit records that an empire observed a deposit.
+func (q *Queries) UpsertSeenDeposit(ctx context.Context, arg UpsertSeenDepositParams) error {
+	_, err := q.db.ExecContext(ctx, upsertSeenDeposit,
+		arg.EmpireID,
+		arg.DepositID,
+		arg.Kind,
+		arg.Quantity,
+		arg.YieldPct,
+		arg.Turn,
+	)
+	return err
+}
+
+const upsertSeenOrbit = `-- name: UpsertSeenOrbit :exec
+INSERT INTO seen_orbits (empire_id, orbit_id, kind, habitability, turn)
+VALUES (?1, ?2, ?3, ?4, ?5)
+ON CONFLICT (empire_id, orbit_id) DO UPDATE SET kind         = excluded.kind,
+                                                habitability = excluded.habitability,
+                                                turn         = excluded.turn
+`
+
+type UpsertSeenOrbitParams struct {
+	EmpireID     int64
+	OrbitID      int64
+	Kind         string
+	Habitability int64
+	Turn         int64
+}
+
+// UpsertSeenOrbit records that an empire observed an orbit.
+func (q *Queries) UpsertSeenOrbit(ctx context.Context, arg UpsertSeenOrbitParams) error {
+	_, err := q.db.ExecContext(ctx, upsertSeenOrbit,
+		arg.EmpireID,
+		arg.OrbitID,
+		arg.Kind,
+		arg.Habitability,
+		arg.Turn,
+	)
+	return err
+}
+
+const upsertSeenSystem = `-- name: UpsertSeenSystem :exec
+INSERT INTO seen_systems (empire_id, system_id, stars, turn)
+VALUES (?1, ?2, ?3, ?4)
+ON CONFLICT (empire_id, system_id) DO UPDATE SET stars = excluded.stars,
+                                                 turn  = excluded.turn
+`
+
+type UpsertSeenSystemParams struct {
+	EmpireID int64
+	SystemID int64
+	Stars    int64
+	Turn     int64
+}
+
+// UpsertSeenSystem records that an empire observed a system.
+func (q *Queries) UpsertSeenSystem(ctx context.Context, arg UpsertSeenSystemParams) error {
+	_, err := q.db.ExecContext(ctx, upsertSeenSystem,
+		arg.EmpireID,
+		arg.SystemID,
+		arg.Stars,
+		arg.Turn,
+	)
+	return err
+}
+
+const upsertSnapshot = `-- name: UpsertSnapshot :exec
+INSERT INTO snapshots (game_id, turn, state)
+VALUES (?1, ?2, ?3)
+ON CONFLICT (game_id, turn) DO UPDATE SET state      = excluded.state,
+                                          created_at = CURRENT_TIMESTAMP
+`
+
+type UpsertSnapshotParams struct {
+	GameID int64
+	Turn   int64
+	State  []byte
+}
+
+// UpsertSnapshot creates or replaces the snapshot of a game for a turn.
+func (q *Queries) UpsertSnapshot(ctx context.Context, arg UpsertSnapshotParams) error {
+	_, err := q.db.ExecContext(ctx, upsertSnapshot, arg.GameID, arg.Turn, arg.State)
+	return err
+}
+
+const upsertStar = `-- name: UpsertStar :one
+INSERT INTO stars (system_id, sequence, class, size, luminosity)
+VALUES (?1, ?2, ?3, ?4, ?5)
+ON CONFLICT (system_id, sequence) DO UPDATE SET class      = excluded.class,
+                                                size       = excluded.size,
+                                                luminosity = excluded.luminosity
+RETURNING id
+`
+
+type UpsertStarParams struct {
+	SystemID   int64
+	Sequence   int64
+	Class      string
+	Size       string
+	Luminosity int64
+}
+
+// UpsertStar creates or updates a star.
+func (q *Queries) UpsertStar(ctx context.Context, arg UpsertStarParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, upsertStar,
+		arg.SystemID,
+		arg.Sequence,
+		arg.Class,
+		arg.Size,
+		arg.Luminosity,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const upsertStockpile = `-- name: UpsertStockpile :exec
+INSERT INTO stockpiles (empire_id, kind, quantity)
+VALUES (?1, ?2, ?3)
+ON CONFLICT (empire_id, kind) DO UPDATE SET quantity = excluded.quantity
+`
+
+type UpsertStockpileParams struct {
+	EmpireID int64
+	Kind     string
+	Quantity int64
+}
+
+// UpsertStockpile sets the quantity of a resource an empire has on hand.
+func (q *Queries) UpsertStockpile(ctx context.Context, arg UpsertStockpileParams) error {
+	_, err := q.db.ExecContext(ctx, upsertStockpile, arg.EmpireID, arg.Kind, arg.Quantity)
+	return err
+}
+
+const upsertTurnLock = `-- name: UpsertTurnLock :exec
+INSERT INTO turn_locks (game_id, turn, owner, expires_at)
+VALUES (?1, ?2, ?3, ?4)
+ON CONFLICT (game_id, turn) DO UPDATE SET owner      = excluded.owner,
+                                          expires_at = excluded.expires_at
+`
+
+type UpsertTurnLockParams struct {
+	GameID    int64
+	Turn      int64
+	Owner     string
+	ExpiresAt time.Time
+}
+
+// UpsertTurnLock claims a game's turn.
+func (q *Queries) UpsertTurnLock(ctx context.Context, arg UpsertTurnLockParams) error {
+	_, err := q.db.ExecContext(ctx, upsertTurnLock,
+		arg.GameID,
+		arg.Turn,
+		arg.Owner,
+		arg.ExpiresAt,
+	)
 	return err
 }
@@ -0,0 +1,127 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package sqlite
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+	"strings"
+	"time"
+)
+
+// ErrInvalidLogin is returned when the player name or access key is wrong.
+// It doesn't say which so that it can't be used to find player names.
+var ErrInvalidLogin = errors.New("invalid name or access key")
+
+// IssueKey creates a new access key for the player, creating the player
+// if needed, and logs the player out everywhere. Only the hash of the key
+// is saved, so the key must be given to the player now.
+func (s *Store) IssueKey(ctx context.Context, name string, gm bool) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("missing player name")
+	}
+	key, err := newToken()
+	if err != nil {
+		return "", err
+	}
+	err = s.InTx(ctx, func(q *Queries) error {
+		playerID, err := q.GetPlayerByName(ctx, name)
+		if errors.Is(err, sql.ErrNoRows) {
+			if playerID, err = q.CreatePlayer(ctx, name); err != nil {
+				return fmt.Errorf("player %q: %w", name, err)
+			}
+		} else if err != nil {
+			return err
+		}
+		params := UpdatePlayerKeyParams{KeyHash: hashToken(key), PlayerID: playerID}
+		if gm {
+			params.IsGm = 1
+		}
+		if err := q.UpdatePlayerKey(ctx, params); err != nil {
+			return err
+		}
+		return q.DeletePlayerSessions(ctx, playerID)
+	})
+	if err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// CreateSession checks the player's name and access key and starts a
+// session that lasts until the expiry. It returns the session token for
+// the cookie. It returns ErrInvalidLogin if the name or key is wrong.
+func (s *Store) CreateSession(ctx context.Context, name, key string, expires time.Time) (string, *models.Player, error) {
+	row, err := s.q.GetPlayerLogin(ctx, strings.TrimSpace(name))
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil, ErrInvalidLogin
+	} else if err != nil {
+		return "", nil, err
+	} else if row.KeyHash == "" || subtle.ConstantTimeCompare([]byte(row.KeyHash), []byte(hashToken(key))) != 1 {
+		return "", nil, ErrInvalidLogin
+	}
+	token, err := newToken()
+	if err != nil {
+		return "", nil, err
+	}
+	err = s.q.CreateSession(ctx, CreateSessionParams{PlayerID: row.ID, TokenHash: hashToken(token), ExpiresAt: expires.UTC()})
+	if err != nil {
+		return "", nil, err
+	}
+	return token, &models.Player{ID: int(row.ID), Name: row.Name, GM: row.IsGm == 1}, nil
+}
+
+// ReadSession returns the player for the session token.
+// It returns ErrNotFound if there is no such session or it has expired.
+func (s *Store) ReadSession(ctx context.Context, token string, now time.Time) (*models.Player, error) {
+	row, err := s.q.GetSessionPlayer(ctx, hashToken(token))
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !now.Before(row.ExpiresAt)) {
+		return nil, fmt.Errorf("session: %w", ErrNotFound)
+	} else if err != nil {
+		return nil, err
+	}
+	return &models.Player{ID: int(row.ID), Name: row.Name, GM: row.IsGm == 1}, nil
+}
+
+// DeleteSession ends the session. It isn't an error if there is no such session.
+func (s *Store) DeleteSession(ctx context.Context, token string) error {
+	return s.q.DeleteSession(ctx, hashToken(token))
+}
+
+// ReadPlayerEmpires returns the ids of the empires the player controls,
+// keyed by game code.
+func (s *Store) ReadPlayerEmpires(ctx context.Context, playerID int) (map[string][]int, error) {
+	rows, err := s.q.ListPlayerEmpires(ctx, int64(playerID))
+	if err != nil {
+		return nil, err
+	}
+	empires := map[string][]int{}
+	for _, row := range rows {
+		empires[row.Code] = append(empires[row.Code], int(row.ID))
+	}
+	return empires, nil
+}
+
+// newToken returns a random token for an access key or a session.
+func newToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashToken returns the hash of the token that is saved in the database.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
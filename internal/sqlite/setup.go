@@ -0,0 +1,122 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+	"log"
+)
+
+// ErrDuplicateGame is returned when the game's code, name, or display
+// name is already used by another game.
+var ErrDuplicateGame = errors.New("duplicate game")
+
+// SetupFunc places the empires in a new game. The game has its cluster
+// and empires loaded, with IDs assigned. Each empire has empty knowledge
+// and an empty stockpile. The function adds the starting colonies,
+// fleets, mines, and stockpiles, and anything the empires have observed.
+type SetupFunc func(game *models.Game) error
+
+// CreateGame creates the game with its cluster and an empire for each
+// player, then calls the function to set up the empires. Players that
+// don't exist are created. Everything is written inside a single
+// transaction; if the function returns an error, nothing is saved.
+func (s *Store) CreateGame(ctx context.Context, game *models.Game, players []string, fn SetupFunc) error {
+	if game.Code == "" || game.Name == "" || game.DisplayName == "" {
+		return fmt.Errorf("game: missing code, name, or display name")
+	} else if game.Cluster == nil {
+		return fmt.Errorf("%s: missing cluster", game.Code)
+	} else if len(players) == 0 {
+		return fmt.Errorf("%s: missing players", game.Code)
+	}
+	seen := map[string]bool{}
+	for _, name := range players {
+		if name == "" {
+			return fmt.Errorf("%s: player: missing name", game.Code)
+		} else if seen[name] {
+			return fmt.Errorf("%s: player %q: listed twice", game.Code, name)
+		}
+		seen[name] = true
+	}
+
+	err := s.inTxOnce(ctx, func(q *Queries) error {
+		if games, err := q.ListGames(ctx); err != nil {
+			return err
+		} else {
+			for _, row := range games {
+				if row.Code == game.Code || row.Name == game.Name || row.DisplayName == game.DisplayName {
+					return fmt.Errorf("%s: %w", game.Code, ErrDuplicateGame)
+				}
+			}
+		}
+		gameID, err := q.CreateGame(ctx, CreateGameParams{Code: game.Code, Name: game.Name, DisplayName: game.DisplayName})
+		if err != nil {
+			return fmt.Errorf("%s: %w", game.Code, err)
+		}
+		game.ID = int(gameID)
+		if err := q.UpdateGameSeed(ctx, UpdateGameSeedParams{Seed: int64(game.Seed), GameID: gameID}); err != nil {
+			return err
+		}
+		if err := saveCluster(ctx, q, gameID, game.Cluster); err != nil {
+			return err
+		}
+		game.Empires = nil
+		for _, name := range players {
+			playerID, err := q.GetPlayerByName(ctx, name)
+			if errors.Is(err, sql.ErrNoRows) {
+				if playerID, err = q.CreatePlayer(ctx, name); err != nil {
+					return fmt.Errorf("player %q: %w", name, err)
+				}
+			} else if err != nil {
+				return fmt.Errorf("player %q: %w", name, err)
+			}
+			empireID, err := q.CreateEmpire(ctx, CreateEmpireParams{GameID: gameID, PlayerID: playerID})
+			if err != nil {
+				return fmt.Errorf("player %q: %w", name, err)
+			}
+			game.Empires = append(game.Empires, &models.Empire{
+				ID:        int(empireID),
+				PlayerID:  int(playerID),
+				Knowledge: models.NewKnowledge(),
+				Stockpile: map[models.Resource_e]int{},
+			})
+		}
+
+		if err := fn(game); err != nil {
+			return err
+		}
+
+		for _, empire := range game.Empires {
+			if err := saveKnowledge(ctx, q, int64(empire.ID), empire.Knowledge, game.CurrentTurn); err != nil {
+				return err
+			} else if err := saveStockpile(ctx, q, empire); err != nil {
+				return err
+			}
+		}
+		for _, colony := range game.Colonies {
+			if err := saveColony(ctx, q, colony); err != nil {
+				return err
+			}
+		}
+		for _, fleet := range game.Fleets {
+			if err := saveFleet(ctx, q, fleet); err != nil {
+				return err
+			}
+		}
+		for _, mine := range game.Mines {
+			if err := saveMine(ctx, q, mine); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("store: %s: created game with %d systems and %d empires\n", game.Code, len(game.Cluster.Systems), len(game.Empires))
+	return nil
+}
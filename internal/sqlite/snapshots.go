@@ -0,0 +1,366 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package sqlite
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+	"io"
+	"log"
+)
+
+// snapshotVersion is bumped when the snapshot format changes in a way
+// that old snapshots can't be read.
+const snapshotVersion = 1
+
+// snapshot is the state of a game at the start of a turn.
+//
+// Only the parts of the game that the turn engine or the GM can change
+// are kept. Systems never change and orbits are never emptied or filled,
+// so the cluster is reduced to the stars, planets, and deposits.
+// Snapshots taken before stars and planets were kept don't restore them.
+type snapshot struct {
+	Version  int
+	Turn     int
+	Stars    []*snapshotStar
+	Planets  []*snapshotPlanet
+	Deposits []*snapshotDeposit
+	Empires  []*models.Empire // with knowledge and stockpile
+	Colonies []*models.Colony
+	Fleets   []*models.Fleet
+	Mines    []*models.Mine
+}
+
+type snapshotStar struct {
+	ID         int
+	Class      models.Spectral_e
+	Size       models.StarSize_e
+	Luminosity int
+}
+
+type snapshotPlanet struct {
+	ID           int
+	Kind         models.Planet_e
+	Habitability int
+}
+
+type snapshotDeposit struct {
+	ID       int
+	Quantity int
+	YieldPct int
+}
+
+// ReadSnapshots returns the snapshots that are available for the game.
+func (s *Store) ReadSnapshots(ctx context.Context, code string) ([]*models.Snapshot, error) {
+	row, err := getGame(ctx, s.q, code)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.q.ListGameSnapshots(ctx, row.ID)
+	if err != nil {
+		return nil, err
+	}
+	var list []*models.Snapshot
+	for _, row := range rows {
+		list = append(list, &models.Snapshot{Turn: int(row.Turn), CreatedAt: row.CreatedAt})
+	}
+	return list, nil
+}
+
+// RollbackGame restores the game to the start of the turn, which must be
+// before the current turn. Everything the later turns changed is discarded:
+// the game state, the turn records and locks, the extractions, the battles, the
+// rejected orders, the snapshots, and the reports. Submitted orders are kept so
+// that the turns can be run again.
+//
+// The deadline is cleared since it applied to the turn being discarded.
+func (s *Store) RollbackGame(ctx context.Context, code string, turn int) error {
+	var row Games
+	err := s.InTx(ctx, func(q *Queries) error {
+		var err error
+		row, err = rollbackGame(ctx, q, code, turn)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("store: %s: rolled back from turn %d to turn %d\n", code, row.CurrentTurn, turn)
+	return nil
+}
+
+// rollbackGame rolls the game back inside the caller's transaction.
+// It returns the game as it was before the rollback.
+func rollbackGame(ctx context.Context, q *Queries, code string, turn int) (Games, error) {
+	row, err := getGame(ctx, q, code)
+	if err != nil {
+		return row, err
+	} else if turn < 0 || int64(turn) >= row.CurrentTurn {
+		return row, fmt.Errorf("%s: turn %d: must be before the current turn %d", code, turn, row.CurrentTurn)
+	}
+	state, err := q.GetSnapshot(ctx, GetSnapshotParams{GameID: row.ID, Turn: int64(turn)})
+	if errors.Is(err, sql.ErrNoRows) {
+		return row, fmt.Errorf("%s: turn %d: snapshot: %w", code, turn, ErrNotFound)
+	} else if err != nil {
+		return row, err
+	}
+	snap, err := decodeSnapshot(state)
+	if err != nil {
+		return row, fmt.Errorf("%s: turn %d: snapshot: %w", code, turn, err)
+	}
+	current, err := readGameState(ctx, q, row)
+	if err != nil {
+		return row, err
+	}
+
+	// extractions refer to mines, so they go before the mines are restored.
+	if err := q.DeleteGameExtractionsFrom(ctx, DeleteGameExtractionsFromParams{Turn: int64(turn), GameID: row.ID}); err != nil {
+		return row, err
+	}
+	if err := q.DeleteGameBattlesFrom(ctx, DeleteGameBattlesFromParams{Turn: int64(turn), GameID: row.ID}); err != nil {
+		return row, err
+	}
+	if err := q.DeleteGameRejectionsFrom(ctx, DeleteGameRejectionsFromParams{Turn: int64(turn), GameID: row.ID}); err != nil {
+		return row, err
+	}
+	if err := restoreSnapshot(ctx, q, row.ID, current, snap); err != nil {
+		return row, fmt.Errorf("%s: turn %d: %w", code, turn, err)
+	}
+	if err := q.DeleteGameTurnsFrom(ctx, DeleteGameTurnsFromParams{GameID: row.ID, Turn: int64(turn)}); err != nil {
+		return row, err
+	}
+	if err := q.DeleteGameTurnLocksFrom(ctx, DeleteGameTurnLocksFromParams{GameID: row.ID, Turn: int64(turn)}); err != nil {
+		return row, err
+	}
+	if err := q.DeleteGameReportsAfter(ctx, DeleteGameReportsAfterParams{Turn: int64(turn), GameID: row.ID}); err != nil {
+		return row, err
+	}
+	if err := q.DeleteGameSnapshotsAfter(ctx, DeleteGameSnapshotsAfterParams{GameID: row.ID, Turn: int64(turn)}); err != nil {
+		return row, err
+	}
+	if err := q.UpdateGameTurn(ctx, UpdateGameTurnParams{TurnNumber: int64(turn), GameID: row.ID}); err != nil {
+		return row, err
+	}
+	if err := q.UpdateGameTurnDeadline(ctx, UpdateGameTurnDeadlineParams{GameID: row.ID}); err != nil {
+		return row, err
+	}
+	return row, nil
+}
+
+// saveSnapshot saves the state of the game for the current turn,
+// replacing any earlier snapshot for the turn.
+func saveSnapshot(ctx context.Context, q *Queries, gameID int64, game *models.Game) error {
+	snap := &snapshot{
+		Version:  snapshotVersion,
+		Turn:     game.CurrentTurn,
+		Empires:  game.Empires,
+		Colonies: game.Colonies,
+		Fleets:   game.Fleets,
+		Mines:    game.Mines,
+	}
+	for _, system := range game.Cluster.Systems {
+		for _, star := range system.Stars {
+			snap.Stars = append(snap.Stars, &snapshotStar{ID: star.ID, Class: star.Class, Size: star.Size, Luminosity: star.Luminosity})
+			for _, planet := range star.Orbits {
+				if planet == nil {
+					continue
+				}
+				snap.Planets = append(snap.Planets, &snapshotPlanet{ID: planet.ID, Kind: planet.Kind, Habitability: planet.Habitability})
+				for _, deposit := range planet.NaturalResources {
+					if deposit != nil {
+						snap.Deposits = append(snap.Deposits, &snapshotDeposit{ID: deposit.ID, Quantity: deposit.Quantity, YieldPct: deposit.YieldPct})
+					}
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(gz).Encode(snap); err != nil {
+		return fmt.Errorf("snapshot: %w", err)
+	} else if err := gz.Close(); err != nil {
+		return fmt.Errorf("snapshot: %w", err)
+	}
+	if err := q.UpsertSnapshot(ctx, UpsertSnapshotParams{GameID: gameID, Turn: int64(game.CurrentTurn), State: buf.Bytes()}); err != nil {
+		return fmt.Errorf("snapshot: turn %d: %w", game.CurrentTurn, err)
+	}
+	return nil
+}
+
+func decodeSnapshot(state []byte) (*snapshot, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(state))
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(gz)
+	if err != nil {
+		return nil, err
+	}
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, err
+	} else if snap.Version != snapshotVersion {
+		return nil, fmt.Errorf("version %d: unsupported", snap.Version)
+	}
+	return &snap, nil
+}
+
+// restoreSnapshot writes the state in the snapshot over the current state
+// of the game. Rows created after the snapshot are deleted and rows that
+// were deleted are created again with their original ids, since orders
+// and reports refer to them by id.
+func restoreSnapshot(ctx context.Context, q *Queries, gameID int64, current *models.Game, snap *snapshot) error {
+	stars := map[int]*snapshotStar{}
+	for _, star := range snap.Stars {
+		stars[star.ID] = star
+	}
+	planets := map[int]*snapshotPlanet{}
+	for _, planet := range snap.Planets {
+		planets[planet.ID] = planet
+	}
+	deposits := map[int]*snapshotDeposit{}
+	for _, deposit := range snap.Deposits {
+		deposits[deposit.ID] = deposit
+	}
+	for _, system := range current.Cluster.Systems {
+		for _, star := range system.Stars {
+			if saved, ok := stars[star.ID]; ok {
+				star.Class, star.Size, star.Luminosity = saved.Class, saved.Size, saved.Luminosity
+			}
+			for _, planet := range star.Orbits {
+				if planet == nil {
+					continue
+				} else if saved, ok := planets[planet.ID]; ok {
+					planet.Kind, planet.Habitability = saved.Kind, saved.Habitability
+				}
+				for _, deposit := range planet.NaturalResources {
+					if deposit == nil {
+						continue
+					} else if saved, ok := deposits[deposit.ID]; ok {
+						deposit.Quantity, deposit.YieldPct = saved.Quantity, saved.YieldPct
+					}
+				}
+			}
+		}
+	}
+	if err := saveCluster(ctx, q, gameID, current.Cluster); err != nil {
+		return err
+	}
+
+	for _, empire := range snap.Empires {
+		id := int64(empire.ID)
+		if err := q.DeleteEmpireSeenDeposits(ctx, id); err != nil {
+			return fmt.Errorf("empire %d: %w", empire.ID, err)
+		} else if err := q.DeleteEmpireSeenOrbits(ctx, id); err != nil {
+			return fmt.Errorf("empire %d: %w", empire.ID, err)
+		} else if err := q.DeleteEmpireSeenSystems(ctx, id); err != nil {
+			return fmt.Errorf("empire %d: %w", empire.ID, err)
+		} else if err := q.DeleteEmpireStockpile(ctx, id); err != nil {
+			return fmt.Errorf("empire %d: %w", empire.ID, err)
+		}
+		if empire.Knowledge != nil {
+			if err := saveKnowledge(ctx, q, id, empire.Knowledge, 0); err != nil {
+				return fmt.Errorf("empire %d: %w", empire.ID, err)
+			}
+		}
+		if err := saveStockpile(ctx, q, empire); err != nil {
+			return err
+		}
+	}
+
+	colonies := map[int]bool{}
+	for _, colony := range snap.Colonies {
+		colonies[colony.ID] = true
+		kind, err := ColonyKindText(colony.Kind)
+		if err != nil {
+			return fmt.Errorf("colony %d: %w", colony.ID, err)
+		}
+		if err := q.RestoreColony(ctx, RestoreColonyParams{
+			ColonyID:   int64(colony.ID),
+			EmpireID:   int64(colony.EmpireID),
+			OrbitID:    int64(colony.OrbitID),
+			Kind:       kind,
+			Population: int64(colony.Population),
+			Enclosures: int64(colony.Enclosures),
+			Founded:    int64(colony.Founded),
+		}); err != nil {
+			return fmt.Errorf("colony %d: %w", colony.ID, err)
+		}
+	}
+	for _, colony := range current.Colonies {
+		if !colonies[colony.ID] {
+			if err := q.DeleteColony(ctx, int64(colony.ID)); err != nil {
+				return fmt.Errorf("colony %d: %w", colony.ID, err)
+			}
+		}
+	}
+
+	fleets := map[int]bool{}
+	for _, fleet := range current.Fleets {
+		fleets[fleet.ID] = true
+	}
+	for _, fleet := range snap.Fleets {
+		if !fleets[fleet.ID] {
+			if err := q.RestoreFleet(ctx, RestoreFleetParams{
+				FleetID:  int64(fleet.ID),
+				EmpireID: int64(fleet.EmpireID),
+				SystemID: int64(fleet.SystemID),
+				Fuel:     int64(fleet.Fuel),
+			}); err != nil {
+				return fmt.Errorf("fleet %d: %w", fleet.ID, err)
+			}
+		}
+		// saveFleet takes care of the location, ships, and cargo.
+		if err := saveFleet(ctx, q, fleet); err != nil {
+			return err
+		}
+		delete(fleets, fleet.ID)
+	}
+	for _, fleet := range current.Fleets {
+		if fleets[fleet.ID] {
+			// a fleet without ships is deleted when it is saved.
+			fleet.Ships = nil
+			if err := saveFleet(ctx, q, fleet); err != nil {
+				return err
+			}
+		}
+	}
+
+	mines := map[int]bool{}
+	for _, mine := range current.Mines {
+		mines[mine.ID] = true
+	}
+	for _, mine := range snap.Mines {
+		if !mines[mine.ID] {
+			if err := q.RestoreMine(ctx, RestoreMineParams{
+				MineID:   int64(mine.ID),
+				EmpireID: int64(mine.EmpireID),
+				OrbitID:  int64(mine.OrbitID),
+				Units:    int64(mine.Units),
+			}); err != nil {
+				return fmt.Errorf("mine %d: %w", mine.ID, err)
+			}
+		}
+		if err := saveMine(ctx, q, mine); err != nil {
+			return err
+		}
+		delete(mines, mine.ID)
+	}
+	for _, mine := range current.Mines {
+		if mines[mine.ID] {
+			if err := q.DeleteMineAssignments(ctx, int64(mine.ID)); err != nil {
+				return fmt.Errorf("mine %d: %w", mine.ID, err)
+			} else if err := q.DeleteMine(ctx, int64(mine.ID)); err != nil {
+				return fmt.Errorf("mine %d: %w", mine.ID, err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -9,13 +9,20 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"io/fs"
 	"log"
 	_ "modernc.org/sqlite"
+	"net/url"
 	"os"
 	"path/filepath"
+	"time"
 )
 
+// ErrNotFound is returned when a record does not exist.
+var ErrNotFound = errors.New("not found")
+
 type Store struct {
 	path string
 	db   *sql.DB
@@ -23,36 +30,134 @@ type Store struct {
 	q    *Queries
 }
 
+// options are the settings for opening a store.
+type options struct {
+	create          bool
+	migrations      fs.FS
+	busyTimeout     time.Duration
+	maxOpenConns    int
+	maxIdleConns    int
+	connMaxLifetime time.Duration
+}
+
+type Option func(*options) error
+
+// WithCreate creates the database if the file doesn't exist.
+// The directory must already exist.
+func WithCreate(create bool) Option {
+	return func(o *options) error {
+		o.create = create
+		return nil
+	}
+}
+
+// WithMigrations applies any pending migrations from fsys after
+// the database is opened.
+func WithMigrations(fsys fs.FS) Option {
+	return func(o *options) error {
+		o.migrations = fsys
+		return nil
+	}
+}
+
+// WithBusyTimeout sets how long a connection waits on a locked
+// database before returning SQLITE_BUSY.
+func WithBusyTimeout(d time.Duration) Option {
+	return func(o *options) error {
+		if d < 0 {
+			return fmt.Errorf("%v: invalid busy timeout", d)
+		}
+		o.busyTimeout = d
+		return nil
+	}
+}
+
+// WithPool sets the limits for the connection pool.
+// Zero means no limit, as with database/sql.
+func WithPool(maxOpenConns, maxIdleConns int, connMaxLifetime time.Duration) Option {
+	return func(o *options) error {
+		if maxOpenConns < 0 {
+			return fmt.Errorf("%d: invalid max open connections", maxOpenConns)
+		} else if maxIdleConns < 0 {
+			return fmt.Errorf("%d: invalid max idle connections", maxIdleConns)
+		} else if connMaxLifetime < 0 {
+			return fmt.Errorf("%v: invalid connection lifetime", connMaxLifetime)
+		}
+		o.maxOpenConns, o.maxIdleConns, o.connMaxLifetime = maxOpenConns, maxIdleConns, connMaxLifetime
+		return nil
+	}
+}
+
 // Open opens the database at the given path.
-// It returns an error if the path does not exist or is
-// not a regular file.
-func Open(path string, ctx context.Context) (*Store, error) {
+// It returns an error if the path is not a regular file, or if
+// it does not exist and the store wasn't opened WithCreate.
+//
+// Every connection in the pool enables foreign keys, uses WAL
+// journaling with normal synchronization, and waits for the busy
+// timeout (five seconds by default) when the database is locked.
+func Open(path string, ctx context.Context, opts ...Option) (*Store, error) {
+	o := &options{busyTimeout: 5 * time.Second}
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+
 	if abs, err := filepath.Abs(path); err != nil {
 		return nil, err
-	} else if sb, err := os.Stat(abs); err != nil {
+	} else if sb, err := os.Stat(abs); err != nil && !(o.create && errors.Is(err, fs.ErrNotExist)) {
 		return nil, err
-	} else if !sb.Mode().IsRegular() {
+	} else if err == nil && !sb.Mode().IsRegular() {
 		return nil, fmt.Errorf("not a regular file")
 	} else {
 		path = abs
 	}
-	db, err := sql.Open("sqlite", path)
+
+	// the pragmas are applied by the driver to every new connection.
+	dsn := url.Values{}
+	dsn.Add("_pragma", "foreign_keys(1)")
+	dsn.Add("_pragma", "journal_mode(WAL)")
+	dsn.Add("_pragma", "synchronous(NORMAL)")
+	dsn.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", o.busyTimeout.Milliseconds()))
+	// write transactions take the write lock when they begin so that they
+	// wait out the busy timeout instead of failing when they first write.
+	dsn.Add("_txlock", "immediate")
+	db, err := sql.Open("sqlite", "file:"+path+"?"+dsn.Encode())
 	if err != nil {
 		return nil, err
 	}
+	db.SetMaxOpenConns(o.maxOpenConns)
+	db.SetMaxIdleConns(o.maxIdleConns)
+	db.SetConnMaxLifetime(o.connMaxLifetime)
+	// sql.Open doesn't connect, so ping to create the file and catch
+	// bad paths and pragmas now rather than on the first query.
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	s := &Store{path: path, db: db, q: New(db), ctx: ctx}
 	log.Printf("store: %s: opened\n", path)
-	return &Store{path: path, db: db, q: New(db), ctx: ctx}, nil
+
+	if o.migrations != nil {
+		applied, err := s.Migrate(ctx, o.migrations)
+		if err != nil {
+			_ = s.Close()
+			return nil, err
+		} else if len(applied) != 0 {
+			log.Printf("store: %s: applied %d migrations\n", path, len(applied))
+		}
+	}
+
+	return s, nil
 }
 
+// Close closes the database. It is safe to call on a nil or closed store.
 func (s *Store) Close() error {
-	if s == nil && s.db == nil {
+	if s == nil || s.db == nil {
 		return nil
 	}
-	defer func() {
-		s.db = nil
-		if s != nil {
-			log.Printf("store: %s: closed\n", s.path)
-		}
-	}()
-	return s.db.Close()
+	err := s.db.Close()
+	s.db = nil
+	log.Printf("store: %s: closed\n", s.path)
+	return err
 }
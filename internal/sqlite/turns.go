@@ -0,0 +1,157 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+	"log"
+)
+
+// TurnFunc updates the game state for a turn. The game has its cluster,
+// empires, colonies, fleets, and mines loaded, along with each empire's
+// knowledge and stockpile. The orders are the latest accepted revision for each
+// empire that submitted orders for the current turn.
+//
+// The function is called again with freshly loaded state if the
+// transaction is retried, so it must not change anything else.
+type TurnFunc func(game *models.Game, orders []*models.Orders) error
+
+// AdvanceTurn runs the game's current turn. The state is loaded, passed
+// to the function, written back, and the turn is incremented, all inside
+// a single transaction. If the function returns an error, nothing is saved.
+//
+// The state is saved as a snapshot for the turn before the function runs.
+// The deadline is cleared since it applied to the turn that was processed.
+func (s *Store) AdvanceTurn(ctx context.Context, code string, fn TurnFunc) error {
+	var row Games
+	err := s.InTx(ctx, func(q *Queries) error {
+		var err error
+		row, err = advanceTurn(ctx, q, code, fn)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("store: %s: advanced to turn %d\n", code, row.CurrentTurn+1)
+	return nil
+}
+
+// advanceTurn runs the turn inside the caller's transaction.
+// It returns the game as it was before the turn was run.
+func advanceTurn(ctx context.Context, q *Queries, code string, fn TurnFunc) (Games, error) {
+	row, err := getGame(ctx, q, code)
+	if err != nil {
+		return row, err
+	}
+	game, err := readGameState(ctx, q, row)
+	if err != nil {
+		return row, err
+	}
+	// keep the state at the start of the turn so the GM can roll back to it.
+	if err := saveSnapshot(ctx, q, row.ID, game); err != nil {
+		return row, err
+	}
+	var orders []*models.Orders
+	if rows, err := q.ListGameAcceptedOrders(ctx, ListGameAcceptedOrdersParams{GameID: row.ID, Turn: row.CurrentTurn}); err != nil {
+		return row, err
+	} else {
+		for _, row := range rows {
+			orders = append(orders, ordersModel(row))
+		}
+	}
+
+	if err := fn(game, orders); err != nil {
+		return row, err
+	}
+
+	if err := saveCluster(ctx, q, row.ID, game.Cluster); err != nil {
+		return row, err
+	}
+	for _, empire := range game.Empires {
+		// observations made while running the turn are dated with the new turn.
+		if err := saveKnowledge(ctx, q, int64(empire.ID), empire.Knowledge, int(row.CurrentTurn)+1); err != nil {
+			return row, err
+		} else if err := saveStockpile(ctx, q, empire); err != nil {
+			return row, err
+		} else if err := saveBattles(ctx, q, empire); err != nil {
+			return row, err
+		} else if err := saveRejections(ctx, q, empire); err != nil {
+			return row, err
+		}
+	}
+	for _, colony := range game.Colonies {
+		if err := saveColony(ctx, q, colony); err != nil {
+			return row, err
+		}
+	}
+	for _, fleet := range game.Fleets {
+		if err := saveFleet(ctx, q, fleet); err != nil {
+			return row, err
+		}
+	}
+	for _, mine := range game.Mines {
+		if err := saveMine(ctx, q, mine); err != nil {
+			return row, err
+		}
+	}
+	if err := q.CreateTurn(ctx, CreateTurnParams{GameID: row.ID, Turn: row.CurrentTurn}); err != nil {
+		return row, fmt.Errorf("%s: turn %d: %w", code, row.CurrentTurn, err)
+	}
+	if err := q.UpdateGameTurn(ctx, UpdateGameTurnParams{TurnNumber: row.CurrentTurn + 1, GameID: row.ID}); err != nil {
+		return row, err
+	}
+	if err := q.UpdateGameTurnDeadline(ctx, UpdateGameTurnDeadlineParams{GameID: row.ID}); err != nil {
+		return row, err
+	}
+	return row, nil
+}
+
+// ReadGameState returns the game with everything the turn engine uses:
+// the cluster, empires, colonies, fleets, and mines, along with each
+// empire's knowledge and stockpile.
+func (s *Store) ReadGameState(ctx context.Context, code string) (game *models.Game, err error) {
+	err = s.InReadTx(ctx, func(q *Queries) error {
+		row, err := getGame(ctx, q, code)
+		if err != nil {
+			return err
+		}
+		game, err = readGameState(ctx, q, row)
+		return err
+	})
+	return game, err
+}
+
+// readGameState loads the state of the game.
+func readGameState(ctx context.Context, q *Queries, row Games) (*models.Game, error) {
+	var err error
+	game := gameModel(row)
+	if game.Cluster, err = readCluster(ctx, q, row.ID); err != nil {
+		return nil, err
+	}
+	if rows, err := q.ListGameEmpires(ctx, row.ID); err != nil {
+		return nil, err
+	} else {
+		for _, row := range rows {
+			empire := &models.Empire{ID: int(row.ID), PlayerID: int(row.PlayerID)}
+			if empire.Knowledge, err = readKnowledge(ctx, q, row.ID); err != nil {
+				return nil, err
+			}
+			game.Empires = append(game.Empires, empire)
+		}
+	}
+	if err := readStockpiles(ctx, q, row.ID, game.Empires); err != nil {
+		return nil, err
+	}
+	if game.Colonies, err = readColonies(ctx, q, row.ID); err != nil {
+		return nil, err
+	}
+	if game.Fleets, err = readFleets(ctx, q, row.ID); err != nil {
+		return nil, err
+	}
+	if game.Mines, err = readMines(ctx, q, row.ID); err != nil {
+		return nil, err
+	}
+	return game, nil
+}
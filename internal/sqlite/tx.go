@@ -0,0 +1,94 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	driver "modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+	"time"
+)
+
+// busyRetries is the number of times a transaction is retried when the
+// database is still busy after the busy timeout, which happens when
+// a long transaction, like running a turn, holds the write lock.
+const busyRetries = 3
+
+// TxFunc is called with queries bound to a transaction.
+type TxFunc func(q *Queries) error
+
+// InTx calls fn inside a read-write transaction. The transaction is
+// committed if fn returns nil and rolled back if it returns an error
+// or panics; panics are re-raised after the rollback.
+//
+// If the database is busy, the transaction is retried from the start,
+// so fn may be called more than once and must not have side effects
+// outside of the transaction.
+func (s *Store) InTx(ctx context.Context, fn TxFunc) error {
+	return s.inTx(ctx, nil, busyRetries, fn)
+}
+
+// InReadTx calls fn inside a read-only transaction so that it sees
+// a consistent view of the database.
+func (s *Store) InReadTx(ctx context.Context, fn TxFunc) error {
+	return s.inTx(ctx, &sql.TxOptions{ReadOnly: true}, busyRetries, fn)
+}
+
+// inTxOnce is InTx without the retries, for functions that update
+// the caller's models as they go and can't safely be run twice.
+func (s *Store) inTxOnce(ctx context.Context, fn TxFunc) error {
+	return s.inTx(ctx, nil, 0, fn)
+}
+
+func (s *Store) inTx(ctx context.Context, opts *sql.TxOptions, retries int, fn TxFunc) (err error) {
+	delay := 100 * time.Millisecond
+	for attempt := 1; ; attempt++ {
+		err = s.tryTx(ctx, opts, fn)
+		if !isBusy(err) || attempt > retries {
+			return err
+		}
+		log.Printf("store: %s: busy, retrying transaction in %v\n", s.path, delay)
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+func (s *Store) tryTx(ctx context.Context, opts *sql.TxOptions, fn TxFunc) (err error) {
+	tx, err := s.db.BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			_ = tx.Rollback()
+			panic(r)
+		} else if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	if err = fn(s.q.WithTx(tx)); err != nil {
+		return err
+	} else if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	return nil
+}
+
+// isBusy returns true if the error is SQLITE_BUSY or SQLITE_LOCKED,
+// including the extended result codes.
+func isBusy(err error) bool {
+	var e *driver.Error
+	if !errors.As(err, &e) {
+		return false
+	}
+	code := e.Code() & 0xff
+	return code == sqlite3.SQLITE_BUSY || code == sqlite3.SQLITE_LOCKED
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package turns
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Schedule is when a game's turns run: one or more days of the week
+// at a time of day in a time zone, like "mon,thu 18:00 America/Chicago".
+type Schedule struct {
+	Days     [7]bool // indexed by time.Weekday
+	Hour     int
+	Minute   int
+	Location *time.Location
+}
+
+var weekdays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// ParseSchedule returns the schedule from text like "mon,thu 18:00 UTC".
+// Days are three letter abbreviations or "daily". The time zone is an
+// IANA name and defaults to UTC if it is omitted.
+func ParseSchedule(text string) (*Schedule, error) {
+	fields := strings.Fields(text)
+	if len(fields) < 2 || len(fields) > 3 {
+		return nil, fmt.Errorf("%q: want \"days hh:mm [zone]\"", text)
+	}
+	s := &Schedule{Location: time.UTC}
+	for _, day := range strings.Split(strings.ToLower(fields[0]), ",") {
+		if day == "daily" {
+			for n := range s.Days {
+				s.Days[n] = true
+			}
+			continue
+		}
+		found := false
+		for n, name := range weekdays {
+			if day == name {
+				s.Days[n], found = true, true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%q: unknown day %q", text, day)
+		}
+	}
+	if t, err := time.Parse("15:04", fields[1]); err != nil {
+		return nil, fmt.Errorf("%q: invalid time %q", text, fields[1])
+	} else {
+		s.Hour, s.Minute = t.Hour(), t.Minute()
+	}
+	if len(fields) == 3 {
+		loc, err := time.LoadLocation(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("%q: unknown time zone %q", text, fields[2])
+		}
+		s.Location = loc
+	}
+	return s, nil
+}
+
+// Next returns the first scheduled time that is after the given time.
+func (s *Schedule) Next(after time.Time) time.Time {
+	local := after.In(s.Location)
+	for n := 0; n <= 7; n++ {
+		day := local.AddDate(0, 0, n)
+		at := time.Date(day.Year(), day.Month(), day.Day(), s.Hour, s.Minute, 0, 0, s.Location)
+		if s.Days[at.Weekday()] && at.After(after) {
+			return at
+		}
+	}
+	// not reached; every schedule has at least one day.
+	panic(fmt.Sprintf("assert(schedule %q has a day)", s.String()))
+}
+
+// String returns the schedule in the form accepted by ParseSchedule.
+func (s *Schedule) String() string {
+	var days []string
+	for n, name := range weekdays {
+		if s.Days[n] {
+			days = append(days, name)
+		}
+	}
+	if len(days) == len(weekdays) {
+		days = []string{"daily"}
+	}
+	return fmt.Sprintf("%s %02d:%02d %s", strings.Join(days, ","), s.Hour, s.Minute, s.Location)
+}
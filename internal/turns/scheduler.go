@@ -0,0 +1,121 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package turns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/mdhender/moid/internal/models"
+	"github.com/mdhender/moid/internal/sqlite"
+	"log"
+	"os"
+	"time"
+)
+
+// Lease is how long a claim on a turn lasts. It must be longer than
+// it takes to run a turn. If a server dies while running a turn, the
+// turn is retried once the claim expires.
+const Lease = 10 * time.Minute
+
+// Owner returns the name used to claim turns for this process.
+func Owner() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s:%d", host, os.Getpid())
+}
+
+// Scheduler runs the turns for games that have a schedule.
+//
+// When a game has no deadline, the scheduler sets it to the next
+// scheduled time. When the deadline has passed, the scheduler claims
+// the turn, runs it, and sets the deadline for the new turn. Deadlines
+// are kept in the database, so a deadline that passed while the server
+// was down is caught up on the first check after it starts. Only the
+// one turn is run, since players need a chance to submit orders for
+// the next turn.
+type Scheduler struct {
+	db       *sqlite.Store
+	owner    string
+	interval time.Duration
+}
+
+// NewScheduler creates a scheduler that checks the games at the interval.
+func NewScheduler(db *sqlite.Store, interval time.Duration) (*Scheduler, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("%v: invalid interval", interval)
+	}
+	return &Scheduler{db: db, owner: Owner(), interval: interval}, nil
+}
+
+// Start checks the games right away and then at every interval until
+// the context is cancelled. A turn that is running when the context is
+// cancelled is rolled back and will be run again after its claim expires.
+func (s *Scheduler) Start(ctx context.Context) {
+	log.Printf("scheduler: %s: checking every %v\n", s.owner, s.interval)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		s.Check(ctx, time.Now())
+		select {
+		case <-ctx.Done():
+			log.Printf("scheduler: %s: stopped\n", s.owner)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check runs every scheduled game that is past its deadline.
+// Errors are logged since there is no one to return them to.
+func (s *Scheduler) Check(ctx context.Context, now time.Time) {
+	games, err := s.db.ReadGames(ctx)
+	if err != nil {
+		log.Printf("scheduler: %v\n", err)
+		return
+	}
+	for _, game := range games {
+		if ctx.Err() != nil {
+			return
+		} else if game.Schedule == "" {
+			continue
+		}
+		if err := s.check(ctx, game, now); err != nil {
+			log.Printf("scheduler: %s: %v\n", game.Code, err)
+		}
+	}
+}
+
+func (s *Scheduler) check(ctx context.Context, game *models.Game, now time.Time) error {
+	schedule, err := ParseSchedule(game.Schedule)
+	if err != nil {
+		return err
+	}
+	if game.Deadline.IsZero() {
+		next := schedule.Next(now)
+		log.Printf("scheduler: %s: turn %d: deadline set to %s\n", game.Code, game.CurrentTurn, next.Format(time.RFC3339))
+		return s.db.SetTurnDeadline(ctx, game.Code, next)
+	} else if now.Before(game.Deadline) {
+		return nil
+	}
+
+	if late := now.Sub(game.Deadline); late > 2*s.interval {
+		log.Printf("scheduler: %s: turn %d: deadline missed by %v, catching up\n", game.Code, game.CurrentTurn, late.Round(time.Second))
+	}
+	err = s.db.ClaimTurn(ctx, game.Code, game.CurrentTurn, s.owner, now.Add(Lease))
+	if errors.Is(err, sqlite.ErrTurnClaimed) || errors.Is(err, sqlite.ErrTurnChanged) {
+		// another server has it, or it was run by hand since we read the game.
+		log.Printf("scheduler: %v\n", err)
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if err := Run(ctx, s.db, game.Code, game.CurrentTurn, false); err != nil {
+		return err
+	}
+	next := schedule.Next(time.Now())
+	log.Printf("scheduler: %s: turn %d: deadline set to %s\n", game.Code, game.CurrentTurn+1, next.Format(time.RFC3339))
+	return s.db.SetTurnDeadline(ctx, game.Code, next)
+}
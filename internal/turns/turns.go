@@ -0,0 +1,66 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+// Package turns runs game turns, either by hand or on a schedule.
+package turns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/mdhender/moid/internal/engine"
+	"github.com/mdhender/moid/internal/models"
+	"github.com/mdhender/moid/internal/reports"
+	"github.com/mdhender/moid/internal/sqlite"
+	"log"
+)
+
+// ErrDryRun is returned by Run when the results were discarded.
+var ErrDryRun = errors.New("dry run")
+
+// Run processes the orders for the game's current turn, advances the
+// game to the next turn, and creates the reports for the new turn.
+//
+// If turn is not negative, the game must be on that turn; this keeps
+// a scheduled run from processing a turn that was run by hand.
+// If dryRun is set, the turn is run but nothing is saved and ErrDryRun
+// is returned.
+func Run(ctx context.Context, db *sqlite.Store, code string, turn int, dryRun bool) error {
+	if err := db.AdvanceTurn(ctx, code, process(code, turn, dryRun)); err != nil {
+		return err
+	}
+	return reports.Generate(ctx, db, code)
+}
+
+// Advance runs the game's current turn for the GM and creates the reports
+// for the new turn. The turn is recorded in the game's audit log. The game
+// must be on the given turn, so a second click on the console's button
+// can't run the next turn as well.
+func Advance(ctx context.Context, db *sqlite.Store, code string, turn int, edit models.Edit) error {
+	if err := db.AdvanceGameTurn(ctx, code, process(code, turn, false), edit); err != nil {
+		return err
+	}
+	return reports.Generate(ctx, db, code)
+}
+
+// process returns the function that runs the turn engine on the game.
+func process(code string, turn int, dryRun bool) sqlite.TurnFunc {
+	return func(game *models.Game, orders []*models.Orders) error {
+		if turn >= 0 && game.CurrentTurn != turn {
+			return fmt.Errorf("%s: turn %d: %w", code, turn, sqlite.ErrTurnChanged)
+		}
+		log.Printf("turn: %s: running turn %d with orders from %d of %d empires\n", code, game.CurrentTurn, len(orders), len(game.Empires))
+		t, err := engine.New(game, orders)
+		if err != nil {
+			return err
+		} else if err = t.Run(); err != nil {
+			return err
+		}
+		for _, event := range t.Events {
+			log.Printf("turn: %s: %s\n", code, event)
+		}
+		if dryRun {
+			return ErrDryRun
+		}
+		return nil
+	}
+}
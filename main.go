@@ -5,12 +5,14 @@ package main
 import (
 	"context"
 	"fmt"
+	"github.com/mdhender/moid/internal/backup"
 	"github.com/mdhender/moid/internal/config"
 	"github.com/mdhender/semver"
 	"log"
 	"net"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 )
 
@@ -27,13 +29,21 @@ func main() {
 
 	started := time.Now()
 
+	// commands are named by the first argument. options for the command
+	// must follow "--" so that they aren't parsed as configuration options.
+	// for example, "moid generate-cluster --env=test -- --game=ALPHA --seed=1"
+	args, command := os.Args[1:], ""
+	if len(args) > 0 && !strings.HasPrefix(args[0], "--") {
+		command, args = args[0], args[1:]
+	}
+
 	log.SetFlags(log.Lshortfile)
-	cfg, err := config.Default(os.Args[1:])
+	cfg, err := config.Default(args)
 	if err != nil {
 		log.Fatalf("error: %v\n", err)
 	}
 
-	err = cfg.Load(os.Args[1:])
+	err = cfg.Load(args)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -45,6 +55,28 @@ func main() {
 		log.Fatalf("error: %v\n", err)
 	}
 
+	if command != "" {
+		var commandArgs []string
+		for i, arg := range args {
+			if arg == "--" {
+				commandArgs = args[i+1:]
+				break
+			}
+		}
+		if err = app.Run(command, commandArgs); err != nil {
+			log.Fatalf("%s: %v\n", command, err)
+		}
+		log.Printf("moid: %s: completed in %v\n", command, time.Since(started))
+		return
+	}
+
+	// the lock keeps restore from replacing the database while we're using it.
+	unlock, err := backup.Lock(app.Database.Store.Path())
+	if err != nil {
+		log.Fatalf("error: %v\n", err)
+	}
+	defer unlock()
+
 	srv := &server{
 		scheme: "http",
 		host:   cfg.Server.Host,
@@ -59,7 +91,14 @@ func main() {
 			MaxHeaderBytes: cfg.Server.MaxHeaderBytes,
 		},
 	}
+	if app.Scheduler != nil {
+		srv.tasks = append(srv.tasks, app.Scheduler.Start)
+	}
+	if app.Backups != nil {
+		srv.tasks = append(srv.tasks, app.Backups.Start)
+	}
 	if err = srv.start(); err != nil {
+		unlock()
 		log.Fatal(err)
 	}
 
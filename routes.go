@@ -15,7 +15,7 @@ import (
 
 func (a *application) Routes() http.Handler {
 
-	r := router.New(middlewares.Static(a.Config.Assets.Path))
+	r := router.New(middlewares.Static(a.Config.Assets.Path), middlewares.Sessions(a.Database.Store))
 
 	// public routes (no authentication required)
 	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
@@ -23,7 +23,50 @@ func (a *application) Routes() http.Handler {
 	})
 	r.Get("/home", a.Controllers.Home.Show)
 	r.Get("/blogs", a.Controllers.Blogs.Show)
-	r.Get("/reports", a.Controllers.Reports.Show)
+	r.Get("/login", a.Controllers.Auth.Show)
+	r.Post("/login", a.Controllers.Auth.Login)
+	r.Post("/logout", a.Controllers.Auth.Logout)
+
+	// player routes (the player must be logged in)
+	r.Group(func(gr *router.Router) {
+		gr.Use(middlewares.RequireLogin)
+
+		gr.Get("/reports", a.Controllers.Reports.Show)
+	})
+
+	// GM routes (the GM must be logged in)
+	r.Group(func(gr *router.Router) {
+		gr.Use(middlewares.RequireGM)
+
+		gr.Get("/admin", a.Controllers.Admin.Show)
+		gr.Post("/admin/games", a.Controllers.Admin.CreateGame)
+		gr.Get("/admin/games/{code}", a.Controllers.Admin.Game)
+		gr.Post("/admin/games/{code}/rename", a.Controllers.Admin.Rename)
+		gr.Post("/admin/games/{code}/turn", a.Controllers.Admin.Turn)
+		gr.Post("/admin/games/{code}/empires/{id}/player", a.Controllers.Admin.SetEmpirePlayer)
+		gr.Post("/admin/games/{code}/empires/{id}/stockpile", a.Controllers.Admin.SetStockpile)
+		gr.Get("/admin/games/{code}/systems/{id}", a.Controllers.Admin.System)
+		gr.Post("/admin/games/{code}/stars/{id}", a.Controllers.Admin.SetStar)
+		gr.Post("/admin/games/{code}/orbits/{id}", a.Controllers.Admin.SetOrbit)
+		gr.Post("/admin/games/{code}/deposits/{id}", a.Controllers.Admin.SetDeposit)
+		gr.Get("/admin/games/{code}/map", a.Controllers.Maps.Show)
+		gr.Get("/admin/games/{code}/sector", a.Controllers.Maps.Sector)
+		gr.Get("/admin/games/{code}/route", a.Controllers.Maps.Route)
+	})
+
+	// empire routes (only the empire's player and the GM)
+	r.Group(func(gr *router.Router) {
+		gr.Use(middlewares.RequireEmpire(a.Database.Store))
+
+		gr.Get("/games/{code}/empires/{id}/orders", a.Controllers.Orders.Show)
+		gr.Post("/games/{code}/empires/{id}/orders", a.Controllers.Orders.Submit)
+		gr.Get("/games/{code}/empires/{id}/status", a.Controllers.Empires.Status)
+		gr.Get("/games/{code}/empires/{id}/map", a.Controllers.Maps.Empire)
+		gr.Get("/games/{code}/empires/{id}/sector", a.Controllers.Maps.EmpireSector)
+		gr.Get("/games/{code}/empires/{id}/route", a.Controllers.Maps.EmpireRoute)
+		gr.Get("/games/{code}/empires/{id}/reports/{turn}", a.Controllers.Reports.View)
+		gr.Get("/games/{code}/empires/{id}/reports/{turn}/download", a.Controllers.Reports.Download)
+	})
 
 	//r := router.New(mid("zero"))
 	//
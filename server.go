@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 )
@@ -20,6 +21,9 @@ type server struct {
 	host   string // should this be blank so that we're not bound to localhost?
 	port   string
 	ctx    context.Context
+
+	// background tasks are started with the server and stopped when it shuts down.
+	tasks []func(ctx context.Context)
 }
 
 func (s *server) BaseURL() string {
@@ -29,10 +33,22 @@ func (s *server) BaseURL() string {
 // start the server and block until the server is stopped.
 // implements a graceful shutdown of the server.
 //
-// TODO: the context should be used to cancel background tasks when shutting down the server.
+// background tasks are cancelled when shutting down the server.
 func (s *server) start() error {
 	started := time.Now()
 
+	// start the background tasks with a context that we cancel on shutdown.
+	ctx, cancelTasks := context.WithCancel(s.ctx)
+	defer cancelTasks()
+	var tasks sync.WaitGroup
+	for _, task := range s.tasks {
+		tasks.Add(1)
+		go func() {
+			defer tasks.Done()
+			task(ctx)
+		}()
+	}
+
 	// create a channel to listen for OS signals.
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM)
@@ -61,6 +77,11 @@ func (s *server) start() error {
 	log.Printf("server: canceling idle connections (%v)\n", time.Since(started))
 	s.SetKeepAlivesEnabled(false)
 
+	// stop the background tasks and wait for them to finish.
+	log.Printf("server: stopping background tasks (%v)\n", time.Since(started))
+	cancelTasks()
+	tasks.Wait()
+
 	log.Printf("server: shutting down the server (%v)\n", time.Since(started))
 	if err := s.Shutdown(ctxWithTimeout); err != nil {
 		return fmt.Errorf("server: shutdown: %w", err)